@@ -0,0 +1,308 @@
+package client
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/1inch/1inch-sdk-go/client/models"
+	"github.com/1inch/1inch-sdk-go/helpers"
+	"github.com/1inch/1inch-sdk-go/helpers/consts/amounts"
+	"github.com/1inch/1inch-sdk-go/helpers/consts/contracts"
+	"github.com/1inch/1inch-sdk-go/internal/approvals"
+	"github.com/1inch/1inch-sdk-go/internal/onchain"
+)
+
+// This file provides helper functions that inventory and revoke the ERC20 allowances of a wallet onchain.
+
+type ApprovalsService service
+
+// GetApprovals lists the spenders a wallet has approved by scanning its ERC20 Approval logs, along with their live allowances
+func (s *ApprovalsService) GetApprovals(ctx context.Context, params models.GetApprovalsParams) ([]models.TokenApproval, error) {
+	err := params.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	ethClient, err := s.client.GetEthClient(params.ChainId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get eth client: %v", err)
+	}
+
+	routers, err := contracts.Get1inchRoutersFromChainId(params.ChainId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get 1inch router addresses: %v", err)
+	}
+
+	spenders := params.Spenders
+	if params.OnlyOneInchRouters {
+		spenders = routers
+	}
+	var spenderAddresses []common.Address
+	for _, spender := range spenders {
+		spenderAddresses = append(spenderAddresses, common.HexToAddress(spender))
+	}
+
+	wallet := common.HexToAddress(params.Wallet)
+	approvalLogs, err := onchain.GetApprovalLogs(ctx, ethClient, onchain.ApprovalLogsConfig{
+		Owner:     wallet,
+		Spenders:  spenderAddresses,
+		FromBlock: params.FromBlock,
+		ToBlock:   params.ToBlock,
+		ChunkSize: params.BlockChunkSize,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get approval logs: %v", err)
+	}
+
+	var tokenApprovals []models.TokenApproval
+	for _, approvalLog := range approvals.GetLatestApprovals(approvalLogs) {
+		// Logs only show what was approved, the live allowance also reflects what has been spent since
+		allowance, err := onchain.ReadContractAllowance(ethClient, approvalLog.Token, wallet, approvalLog.Spender)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read allowance of %s for token %s: %v", approvalLog.Spender.Hex(), approvalLog.Token.Hex(), err)
+		}
+		if allowance.Sign() == 0 && !params.IncludeRevoked {
+			continue
+		}
+
+		tokenApprovals = append(tokenApprovals, models.TokenApproval{
+			Token:            approvalLog.Token.Hex(),
+			Spender:          approvalLog.Spender.Hex(),
+			Allowance:        allowance,
+			IsOneInchRouter:  isOneInchRouter(approvalLog.Spender.Hex(), routers),
+			LastUpdatedBlock: approvalLog.BlockNumber,
+		})
+	}
+
+	return tokenApprovals, nil
+}
+
+// GetAllowance reads the live allowance a spender has for a token on behalf of an owner
+func (s *ApprovalsService) GetAllowance(ctx context.Context, params models.GetAllowanceParams) (*big.Int, error) {
+	err := params.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	ethClient, err := s.client.GetEthClient(params.ChainId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get eth client: %v", err)
+	}
+
+	allowance, err := onchain.ReadContractAllowance(ethClient, common.HexToAddress(params.Token), common.HexToAddress(params.Owner), common.HexToAddress(params.Spender))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read allowance: %v", err)
+	}
+
+	return allowance, nil
+}
+
+// BuildApprovalTransaction returns a fully populated unsigned approval for an external signer instead of broadcasting it
+// The transaction uses the pending nonce of the wallet and can be sent with Broadcast once signed
+func (s *ApprovalsService) BuildApprovalTransaction(ctx context.Context, params models.BuildApprovalTransactionParams) (*models.UnsignedTransaction, error) {
+	err := params.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	ethClient, err := s.client.GetEthClient(params.ChainId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get eth client: %v", err)
+	}
+
+	spender := params.Spender
+	if spender == "" {
+		spender, err = contracts.Get1inchRouterFromChainId(params.ChainId)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get 1inch router address: %v", err)
+		}
+	}
+
+	amount := amounts.BigMaxUint256
+	if params.Amount != "" {
+		amount, err = helpers.BigIntFromString(params.Amount)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse amount: %v", err)
+		}
+	}
+
+	data, err := onchain.GetApproveCalldata(common.HexToAddress(spender), amount)
+	if err != nil {
+		return nil, err
+	}
+
+	publicAddress := common.HexToAddress(params.PublicAddress)
+	nonce, err := ethClient.PendingNonceAt(ctx, publicAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get nonce: %v", err)
+	}
+
+	chainId := big.NewInt(int64(params.ChainId))
+	tx, err := onchain.BuildTransaction(ctx, ethClient, nonce, onchain.TxConfig{
+		Description:   "Approval",
+		PublicAddress: publicAddress,
+		ChainId:       chainId,
+		Value:         big.NewInt(0),
+		To:            params.Token,
+		Data:          data,
+	}, onchain.ApprovalGasFallback)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build approval transaction: %v", err)
+	}
+
+	return &models.UnsignedTransaction{
+		Description: "Approval",
+		From:        publicAddress,
+		ChainId:     chainId,
+		Transaction: tx,
+	}, nil
+}
+
+// BuildRevokeTransactions returns one unsigned transaction per approval that resets its allowance to zero
+// approve(spender, 0) is used when the token accepts it, otherwise decreaseAllowance is used for the current allowance
+func (s *ApprovalsService) BuildRevokeTransactions(ctx context.Context, params models.BuildRevokeTransactionsParams) ([]models.RevokeTransaction, error) {
+	err := params.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	ethClient, err := s.client.GetEthClient(params.ChainId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get eth client: %v", err)
+	}
+
+	publicAddress := common.HexToAddress(params.PublicAddress)
+
+	var revokeTransactions []models.RevokeTransaction
+	for _, approval := range params.Approvals {
+		tokenAddress := common.HexToAddress(approval.Token)
+		spenderAddress := common.HexToAddress(approval.Spender)
+
+		allowance, err := onchain.ReadContractAllowance(ethClient, tokenAddress, publicAddress, spenderAddress)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read allowance of %s for token %s: %v", approval.Spender, approval.Token, err)
+		}
+		if allowance.Sign() == 0 {
+			continue
+		}
+
+		data, method, err := onchain.GetRevokeApprovalCalldata(ethClient, tokenAddress, publicAddress, spenderAddress, allowance)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build revoke for %s on token %s: %v", approval.Spender, approval.Token, err)
+		}
+
+		revokeTransactions = append(revokeTransactions, models.RevokeTransaction{
+			Token:   tokenAddress.Hex(),
+			Spender: spenderAddress.Hex(),
+			To:      tokenAddress.Hex(),
+			Data:    fmt.Sprintf("0x%x", data),
+			Method:  method,
+		})
+	}
+
+	return revokeTransactions, nil
+}
+
+// RevokeApprovals resets the given allowances to zero onchain, one transaction per approval
+// Every revocation is attempted even if an earlier one fails, the outcome of each is reported in the results
+func (s *ApprovalsService) RevokeApprovals(ctx context.Context, params models.RevokeApprovalsParams) ([]models.RevokeResult, error) {
+	err := params.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, err := crypto.HexToECDSA(params.WalletKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert private key: %v", err)
+	}
+
+	publicKeyECDSA, ok := privateKey.Public().(*ecdsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("could not cast public key to ECDSA")
+	}
+
+	if !strings.EqualFold(crypto.PubkeyToAddress(*publicKeyECDSA).Hex(), params.PublicAddress) {
+		return nil, fmt.Errorf("public address does not match private key")
+	}
+
+	ethClient, err := s.client.GetEthClient(params.ChainId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get eth client: %v", err)
+	}
+
+	revokeTransactions, err := s.BuildRevokeTransactions(ctx, models.BuildRevokeTransactionsParams{
+		ChainId:       params.ChainId,
+		PublicAddress: params.PublicAddress,
+		Approvals:     params.Approvals,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to build revoke transactions: %v", err)
+	}
+	if len(revokeTransactions) == 0 {
+		return nil, nil
+	}
+
+	if s.client.needsSummary(params.SkipWarnings) {
+		ok, err := s.client.confirm(ctx, &models.Summary{
+			Kind:        models.SummaryRevoke,
+			ChainId:     params.ChainId,
+			Wallet:      params.PublicAddress,
+			Revocations: revokeTransactions,
+		}, params.SkipWarnings)
+		if err != nil {
+			return nil, fmt.Errorf("failed to confirm revocations: %w", err)
+		}
+		if !ok {
+			return nil, errors.New("user rejected revocations")
+		}
+	}
+
+	var failed int
+	results := make([]models.RevokeResult, 0, len(revokeTransactions))
+	for _, revokeTransaction := range revokeTransactions {
+		data, err := hex.DecodeString(onchain.Remove0xPrefix(revokeTransaction.Data))
+		if err == nil {
+			_, err = onchain.ExecuteTransaction(ctx, onchain.TxConfig{
+				Description:   "Revoke Approval",
+				PublicAddress: common.HexToAddress(params.PublicAddress),
+				PrivateKey:    params.WalletKey,
+				ChainId:       big.NewInt(int64(params.ChainId)),
+				Value:         big.NewInt(0),
+				To:            revokeTransaction.To,
+				Data:          data,
+				HeadWatcher:   s.client.getHeadWatcher(params.ChainId),
+				Broadcaster:   s.client.getBroadcaster(params.ChainId),
+				WaitOptions:   params.WaitOptions,
+			}, ethClient, s.client.NonceCache)
+		}
+		if err != nil {
+			failed++
+		}
+		results = append(results, models.RevokeResult{
+			RevokeTransaction: revokeTransaction,
+			Error:             err,
+		})
+	}
+
+	if failed > 0 {
+		return results, fmt.Errorf("%d of %d revocations failed", failed, len(revokeTransactions))
+	}
+	return results, nil
+}
+
+func isOneInchRouter(address string, routers []string) bool {
+	for _, router := range routers {
+		if strings.EqualFold(address, router) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,63 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/1inch/1inch-sdk-go/helpers"
+	"github.com/1inch/1inch-sdk-go/internal/onchain"
+)
+
+// This file provides helper functions that send transactions signed outside of the SDK.
+
+// Broadcast sends a transaction signed by an external signer, such as one built by BuildSwapTransactions, and waits for its receipt
+// The chain is taken from the EIP-155 chain id of the signed transaction, which is sent through the private relays or
+// broadcast providers of the chain when it has any
+func (s *ActionService) Broadcast(ctx context.Context, signedRawTx string) (*types.Receipt, error) {
+	raw, err := hexutil.Decode(signedRawTx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode signed transaction: %v", err)
+	}
+
+	tx := new(types.Transaction)
+	err = tx.UnmarshalBinary(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode signed transaction: %v", err)
+	}
+
+	chainId := tx.ChainId()
+	if chainId.Sign() == 0 {
+		return nil, fmt.Errorf("signed transaction is not replay protected, a chain id is required")
+	}
+
+	from, err := types.Sender(types.LatestSignerForChainID(chainId), tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to recover transaction sender: %v", err)
+	}
+
+	ethClient, err := s.client.GetEthClient(int(chainId.Int64()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get eth client: %v", err)
+	}
+
+	broadcaster := s.client.getBroadcaster(int(chainId.Int64()))
+	if broadcaster == nil {
+		broadcaster = onchain.NewPublicBroadcaster(ethClient)
+	}
+	submission, err := broadcaster.Broadcast(ctx, tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send transaction: %v", err)
+	}
+
+	fmt.Println("Transaction sent!")
+	helpers.PrintBlockExplorerTxLink(int(chainId.Int64()), tx.Hash().String())
+
+	receipt, err := onchain.WaitForSubmission(ctx, ethClient, tx, from, s.client.getHeadWatcher(int(chainId.Int64())), onchain.WaitOptions{}, submission)
+	if err != nil {
+		return receipt, fmt.Errorf("failed to get transaction receipt: %w", err)
+	}
+	return receipt, nil
+}
@@ -0,0 +1,180 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/1inch/1inch-sdk-go/client/models"
+	"github.com/1inch/1inch-sdk-go/helpers/consts/chains"
+	"github.com/1inch/1inch-sdk-go/helpers/consts/tokens"
+)
+
+func TestBuildApprovalTransactionAndBroadcast(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	wallet := crypto.PubkeyToAddress(privateKey.PublicKey)
+	header := &types.Header{Number: big.NewInt(10), Difficulty: big.NewInt(0)}
+
+	// The stub provider is a Polygon node that mines every transaction it receives in block 10
+	var sentTx *types.Transaction
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Id     json.RawMessage   `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+
+		response := map[string]interface{}{"jsonrpc": "2.0", "id": request.Id}
+		switch request.Method {
+		case "eth_chainId":
+			response["result"] = hexutil.Uint64(chains.Polygon)
+		case "eth_getTransactionCount":
+			response["result"] = hexutil.Uint64(5)
+		case "eth_estimateGas":
+			response["result"] = hexutil.Uint64(40000)
+		case "eth_maxPriorityFeePerGas", "eth_gasPrice":
+			response["result"] = (*hexutil.Big)(big.NewInt(30e9))
+		case "eth_sendRawTransaction":
+			var rawTx hexutil.Bytes
+			require.NoError(t, json.Unmarshal(request.Params[0], &rawTx))
+			sentTx = new(types.Transaction)
+			require.NoError(t, sentTx.UnmarshalBinary(rawTx))
+			response["result"] = sentTx.Hash()
+		case "eth_getTransactionReceipt":
+			response["result"] = &types.Receipt{
+				Status:      types.ReceiptStatusSuccessful,
+				TxHash:      sentTx.Hash(),
+				BlockNumber: header.Number,
+				BlockHash:   header.Hash(),
+				Logs:        []*types.Log{},
+			}
+		case "eth_blockNumber":
+			response["result"] = hexutil.Uint64(10)
+		case "eth_getBlockByNumber":
+			response["result"] = header
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(response))
+	}))
+	defer server.Close()
+
+	c, err := NewClient(models.ClientConfig{
+		DevPortalApiKey:   "abc123",
+		Web3HttpProviders: []models.Web3Provider{{ChainId: chains.Polygon, Url: server.URL}},
+	})
+	require.NoError(t, err)
+	defer c.Close()
+
+	unsignedTx, err := c.Approvals.BuildApprovalTransaction(context.Background(), models.BuildApprovalTransactionParams{
+		ChainId:       chains.Polygon,
+		PublicAddress: wallet.Hex(),
+		Token:         tokens.PolygonDai,
+		Amount:        "1000",
+	})
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), unsignedTx.Transaction.Nonce())
+	require.Equal(t, uint64(50000), unsignedTx.Transaction.Gas())
+	require.Equal(t, common.HexToAddress(tokens.PolygonDai), *unsignedTx.Transaction.To())
+	require.Equal(t, big.NewInt(chains.Polygon), unsignedTx.ChainId)
+
+	// The external signer only ever sees the unsigned transaction
+	signedTx, err := types.SignTx(unsignedTx.Transaction, types.LatestSignerForChainID(unsignedTx.ChainId), privateKey)
+	require.NoError(t, err)
+	signedRawTx, err := signedTx.MarshalBinary()
+	require.NoError(t, err)
+
+	receipt, err := c.Actions.Broadcast(context.Background(), hexutil.Encode(signedRawTx))
+	require.NoError(t, err)
+	require.Equal(t, signedTx.Hash(), receipt.TxHash)
+	require.Equal(t, signedTx.Hash(), sentTx.Hash())
+}
+
+func TestBroadcastThroughPrivateRelay(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	wallet := crypto.PubkeyToAddress(privateKey.PublicKey)
+	header := &types.Header{Number: big.NewInt(10), Difficulty: big.NewInt(0)}
+
+	// The relay keeps the transaction away from the public node, which only sees it once it is mined
+	var relayedTx *types.Transaction
+	relay := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Method string `json:"method"`
+			Params []struct {
+				Tx string `json:"tx"`
+			} `json:"params"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		require.Equal(t, "eth_sendPrivateTransaction", request.Method)
+		relayedTx = new(types.Transaction)
+		require.NoError(t, relayedTx.UnmarshalBinary(hexutil.MustDecode(request.Params[0].Tx)))
+		require.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": 1, "result": relayedTx.Hash()}))
+	}))
+	defer relay.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Id     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+
+		response := map[string]interface{}{"jsonrpc": "2.0", "id": request.Id}
+		switch request.Method {
+		case "eth_chainId":
+			response["result"] = hexutil.Uint64(chains.Polygon)
+		case "eth_sendRawTransaction":
+			require.Fail(t, "transaction sent to the public mempool")
+		case "eth_getTransactionReceipt":
+			response["result"] = &types.Receipt{
+				Status:      types.ReceiptStatusSuccessful,
+				TxHash:      relayedTx.Hash(),
+				BlockNumber: header.Number,
+				BlockHash:   header.Hash(),
+				Logs:        []*types.Log{},
+			}
+		case "eth_blockNumber":
+			response["result"] = hexutil.Uint64(10)
+		case "eth_getBlockByNumber":
+			response["result"] = header
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(response))
+	}))
+	defer server.Close()
+
+	c, err := NewClient(models.ClientConfig{
+		DevPortalApiKey:   "abc123",
+		Web3HttpProviders: []models.Web3Provider{{ChainId: chains.Polygon, Url: server.URL}},
+		PrivateRelays:     []models.PrivateRelay{{ChainId: chains.Polygon, Url: relay.URL}},
+	})
+	require.NoError(t, err)
+	defer c.Close()
+
+	signedTx, err := types.SignTx(types.NewTx(&types.DynamicFeeTx{
+		ChainID:   big.NewInt(chains.Polygon),
+		Nonce:     1,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(1),
+		Gas:       21000,
+		To:        &wallet,
+		Value:     big.NewInt(0),
+	}), types.LatestSignerForChainID(big.NewInt(chains.Polygon)), privateKey)
+	require.NoError(t, err)
+	signedRawTx, err := signedTx.MarshalBinary()
+	require.NoError(t, err)
+
+	receipt, err := c.Actions.Broadcast(context.Background(), hexutil.Encode(signedRawTx))
+	require.NoError(t, err)
+	require.Equal(t, signedTx.Hash(), relayedTx.Hash())
+	require.Equal(t, signedTx.Hash(), receipt.TxHash)
+}
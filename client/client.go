@@ -10,13 +10,20 @@ import (
 	"net/http"
 	"net/url"
 	"reflect"
+	"sort"
 	"strings"
 
 	"github.com/1inch/1inch-sdk-go/client/models"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/google/go-querystring/query"
 
 	"github.com/1inch/1inch-sdk-go/helpers"
+	"github.com/1inch/1inch-sdk-go/helpers/consts/contracts"
+	"github.com/1inch/1inch-sdk-go/internal/onchain"
+	"github.com/1inch/1inch-sdk-go/internal/policy"
+	"github.com/1inch/1inch-sdk-go/internal/web3"
 )
 
 type service struct {
@@ -28,6 +35,23 @@ type Client struct {
 	httpClient *http.Client
 	// Ethereum client map
 	EthClientMap map[int]*ethclient.Client
+	// Provider pools backing the Ethereum clients, used to fail over between the providers of a chain
+	web3Pools map[int]*web3.Pool
+	// Watchers following the new blocks of each chain, shared by everything waiting on the chain
+	headWatchers map[int]*web3.HeadWatcher
+	// Broadcasters of the chains whose transactions are not only sent through their eth client
+	broadcasters map[int]onchain.Broadcaster
+	// Clients of the extra RPC endpoints transactions are broadcast to
+	broadcastClients []*ethclient.Client
+	// Confirmer approves or rejects the actions of the SDK that do not skip warnings
+	Confirmer models.Confirmer
+	// Prices summaries in USD before they are confirmed
+	summaryUsdValues bool
+	// Evaluates every action against the policy of the client, even when warnings are skipped
+	policy *policy.Engine
+	// Deadlines used when the params of a permit or an order do not set one
+	permitDeadline onchain.Deadline
+	orderExpiry    onchain.Deadline
 	// The URL of the 1inch API
 	ApiBaseURL *url.URL
 	// The API key to use for authentication
@@ -38,8 +62,10 @@ type Client struct {
 	common service
 	// Isolated namespaces for each API
 	Actions      *ActionService
+	Approvals    *ApprovalsService
 	SwapApi      *SwapService
 	OrderbookApi *OrderbookService
+	Safe         *SafeService
 }
 
 // NewClient creates and initializes a new Client instance based on the provided ClientConfig.
@@ -49,13 +75,68 @@ func NewClient(config models.ClientConfig) (*Client, error) {
 		return nil, fmt.Errorf("config validation error: %v", err)
 	}
 
-	ethClientMap := make(map[int]*ethclient.Client)
+	providersByChain := make(map[int][]web3.ProviderConfig)
+	var chainIds []int
 	for _, provider := range config.Web3HttpProviders {
-		ethClient, err := ethclient.Dial(provider.Url)
+		if _, ok := providersByChain[provider.ChainId]; !ok {
+			chainIds = append(chainIds, provider.ChainId)
+		}
+		providersByChain[provider.ChainId] = append(providersByChain[provider.ChainId], web3.ProviderConfig{
+			Url:      provider.Url,
+			Priority: provider.Priority,
+			Weight:   provider.Weight,
+		})
+	}
+
+	ethClientMap := make(map[int]*ethclient.Client)
+	web3Pools := make(map[int]*web3.Pool)
+	for _, chainId := range chainIds {
+		pool, err := web3.NewPool(chainId, providersByChain[chainId], config.Web3HealthCheck)
 		if err != nil {
+			for _, created := range web3Pools {
+				created.Close()
+			}
 			return nil, fmt.Errorf("failed to create eth client: %v", err)
 		}
-		ethClientMap[provider.ChainId] = ethClient
+		web3Pools[chainId] = pool
+		ethClientMap[chainId] = pool.EthClient()
+	}
+
+	err = verifyWeb3Providers(web3Pools, config.RouterCodeHashes)
+	if err != nil {
+		for _, pool := range web3Pools {
+			pool.Close()
+		}
+		return nil, err
+	}
+
+	wsProvidersByChain := make(map[int][]models.Web3Provider)
+	for _, provider := range config.Web3WsProviders {
+		wsProvidersByChain[provider.ChainId] = append(wsProvidersByChain[provider.ChainId], provider)
+	}
+	headWatchers := make(map[int]*web3.HeadWatcher)
+	for chainId, pool := range web3Pools {
+		// WebSocket providers are tried in order of priority when (re)connecting
+		wsProviders := wsProvidersByChain[chainId]
+		sort.SliceStable(wsProviders, func(i, j int) bool {
+			return wsProviders[i].Priority < wsProviders[j].Priority
+		})
+		var wsUrls []string
+		for _, wsProvider := range wsProviders {
+			wsUrls = append(wsUrls, wsProvider.Url)
+		}
+		headWatchers[chainId] = web3.NewHeadWatcher(chainId, wsUrls, pool.EthClient(), config.HeadWatcher)
+	}
+
+	broadcasters, broadcastClients, err := newBroadcasters(config, web3Pools)
+	if err != nil {
+		for _, headWatcher := range headWatchers {
+			headWatcher.Close()
+		}
+		for _, pool := range web3Pools {
+			pool.Close()
+		}
+		return nil, err
 	}
 
 	apiBaseUrl, err := url.Parse("https://api.1inch.dev")
@@ -66,20 +147,136 @@ func NewClient(config models.ClientConfig) (*Client, error) {
 	c := &Client{
 		httpClient:   &http.Client{},
 		EthClientMap: ethClientMap,
+		web3Pools:    web3Pools,
+		headWatchers: headWatchers,
+		broadcasters: broadcasters,
 		ApiBaseURL:   apiBaseUrl,
 		ApiKey:       config.DevPortalApiKey,
 		NonceCache:   make(map[string]uint64),
+
+		broadcastClients: broadcastClients,
+		Confirmer:        config.Confirmer,
+		summaryUsdValues: config.SummaryUsdValues,
+		permitDeadline:   config.PermitDeadline,
+		orderExpiry:      config.OrderExpiry,
+	}
+
+	if c.Confirmer == nil {
+		c.Confirmer = TerminalConfirmer{}
+	}
+	if config.Policy != nil {
+		c.policy = policy.NewEngine(*config.Policy)
 	}
 
 	c.common.client = c
 
 	c.Actions = (*ActionService)(&c.common)
+	c.Approvals = (*ApprovalsService)(&c.common)
 	c.SwapApi = (*SwapService)(&c.common)
 	c.OrderbookApi = (*OrderbookService)(&c.common)
+	c.Safe = (*SafeService)(&c.common)
+
+	for _, pool := range web3Pools {
+		pool.Start()
+	}
 
 	return c, nil
 }
 
+// verifyWeb3Providers makes sure every provider serves the chain it is configured for before anything gets signed
+// Providers that cannot be reached yet are verified before their first request instead of failing the client
+func verifyWeb3Providers(web3Pools map[int]*web3.Pool, routerCodeHashes map[int]string) error {
+	for chainId, pool := range web3Pools {
+		err := pool.VerifyChainId(context.Background())
+		if err != nil {
+			return fmt.Errorf("failed to verify web3 providers for chain id %d: %v", chainId, err)
+		}
+
+		codeHash, ok := routerCodeHashes[chainId]
+		if !ok {
+			continue
+		}
+		router, err := contracts.Get1inchRouterFromChainId(chainId)
+		if err != nil {
+			return fmt.Errorf("failed to get 1inch router address: %v", err)
+		}
+		err = pool.VerifyCodeHash(context.Background(), common.HexToAddress(router), common.HexToHash(codeHash))
+		if err != nil {
+			return fmt.Errorf("failed to verify 1inch router code for chain id %d: %v", chainId, err)
+		}
+	}
+	return nil
+}
+
+// newBroadcasters returns the broadcasters of the chains with private relays or extra broadcast providers
+// Chains without either send their transactions through their eth client
+func newBroadcasters(config models.ClientConfig, web3Pools map[int]*web3.Pool) (map[int]onchain.Broadcaster, []*ethclient.Client, error) {
+	broadcastersByChain := make(map[int][]onchain.Broadcaster)
+	for _, relay := range config.PrivateRelays {
+		relayConfig := onchain.PrivateRelayConfig{
+			Url:        relay.Url,
+			UseBundles: relay.UseBundles,
+			BlockRange: relay.BlockRange,
+		}
+		if relay.AuthKey != "" {
+			authKey, err := crypto.HexToECDSA(relay.AuthKey)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to convert private relay auth key: %v", err)
+			}
+			relayConfig.AuthKey = authKey
+		}
+		broadcastersByChain[relay.ChainId] = append(broadcastersByChain[relay.ChainId], onchain.NewPrivateRelayBroadcaster(web3Pools[relay.ChainId].EthClient(), relayConfig))
+	}
+
+	var broadcastClients []*ethclient.Client
+	for _, provider := range config.Web3BroadcastProviders {
+		if _, ok := broadcastersByChain[provider.ChainId]; !ok {
+			broadcastersByChain[provider.ChainId] = []onchain.Broadcaster{onchain.NewPublicBroadcaster(web3Pools[provider.ChainId].EthClient())}
+		}
+		ethClient, err := ethclient.Dial(provider.Url)
+		if err != nil {
+			for _, created := range broadcastClients {
+				created.Close()
+			}
+			return nil, nil, fmt.Errorf("failed to create broadcast client for %s: %v", web3.RedactUrl(provider.Url), err)
+		}
+		broadcastClients = append(broadcastClients, ethClient)
+		broadcastersByChain[provider.ChainId] = append(broadcastersByChain[provider.ChainId], onchain.NewPublicBroadcaster(ethClient))
+	}
+
+	broadcasters := make(map[int]onchain.Broadcaster)
+	for chainId, chainBroadcasters := range broadcastersByChain {
+		if len(chainBroadcasters) == 1 {
+			broadcasters[chainId] = chainBroadcasters[0]
+			continue
+		}
+		broadcasters[chainId] = onchain.NewMultiBroadcaster(chainBroadcasters...)
+	}
+	return broadcasters, broadcastClients, nil
+}
+
+// Close stops the background health checks and closes the connections to every web3 provider
+func (c *Client) Close() {
+	for _, headWatcher := range c.headWatchers {
+		headWatcher.Close()
+	}
+	for _, pool := range c.web3Pools {
+		pool.Close()
+	}
+	for _, ethClient := range c.broadcastClients {
+		ethClient.Close()
+	}
+}
+
+// GetWeb3ProviderStatuses returns the health of every web3 provider configured for a chain
+func (c *Client) GetWeb3ProviderStatuses(chainId int) ([]web3.ProviderStatus, error) {
+	pool, ok := c.web3Pools[chainId]
+	if !ok {
+		return nil, fmt.Errorf("no client for chain id %d", chainId)
+	}
+	return pool.Statuses(), nil
+}
+
 func (c *Client) GetEthClient(chainId int) (*ethclient.Client, error) {
 	ethClient, ok := c.EthClientMap[chainId]
 	if !ok {
@@ -88,6 +285,27 @@ func (c *Client) GetEthClient(chainId int) (*ethclient.Client, error) {
 	return ethClient, nil
 }
 
+// SubscribeNewHeads returns a subscription to the new block headers of a chain
+// Headers come from a WebSocket provider when one is configured and reachable, otherwise from polling the HTTP providers
+// The subscription must be unsubscribed once it is no longer needed
+func (c *Client) SubscribeNewHeads(chainId int) (*web3.HeadSubscription, error) {
+	headWatcher, ok := c.headWatchers[chainId]
+	if !ok {
+		return nil, fmt.Errorf("no client for chain id %d", chainId)
+	}
+	return headWatcher.Subscribe(), nil
+}
+
+// getHeadWatcher returns the head watcher of a chain, or nil when the chain has none and receipts have to be polled
+func (c *Client) getHeadWatcher(chainId int) *web3.HeadWatcher {
+	return c.headWatchers[chainId]
+}
+
+// getBroadcaster returns the broadcaster of a chain, or nil when transactions are sent through the eth client of the chain
+func (c *Client) getBroadcaster(chainId int) onchain.Broadcaster {
+	return c.broadcasters[chainId]
+}
+
 func (c *Client) NewRequest(method, urlStr string, body []byte) (*http.Request, error) {
 	u, err := c.ApiBaseURL.Parse(urlStr)
 	if err != nil {
@@ -1,12 +1,20 @@
 package client
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
 
 	"github.com/1inch/1inch-sdk-go/client/models"
 	"github.com/1inch/1inch-sdk-go/helpers/consts/chains"
+	"github.com/1inch/1inch-sdk-go/internal/onchain"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -60,6 +68,43 @@ func TestNewConfig(t *testing.T) {
 			},
 			expectedErrorDescription: "config validation error: at least one web3 provider URL is required",
 		},
+		{
+			description: "Error - private relay without web3 provider",
+			config: models.ClientConfig{
+				DevPortalApiKey:   "123",
+				Web3HttpProviders: []models.Web3Provider{{ChainId: chains.Ethereum, Url: "http://localhost:8545"}},
+				PrivateRelays:     []models.PrivateRelay{{ChainId: chains.Polygon, Url: "https://relay.example"}},
+			},
+			expectedErrorDescription: "config validation error: all private relays must have an HTTP provider for the same chain ID",
+		},
+		{
+			description: "Error - private relay combined with broadcast providers",
+			config: models.ClientConfig{
+				DevPortalApiKey:        "123",
+				Web3HttpProviders:      []models.Web3Provider{{ChainId: chains.Ethereum, Url: "http://localhost:8545"}},
+				Web3BroadcastProviders: []models.Web3Provider{{ChainId: chains.Ethereum, Url: "http://localhost:8546"}},
+				PrivateRelays:          []models.PrivateRelay{{ChainId: chains.Ethereum, Url: "https://relay.example"}},
+			},
+			expectedErrorDescription: "config validation error: web3 broadcast providers and private relays cannot be used together for chain ID 1",
+		},
+		{
+			description: "Error - negative order expiry",
+			config: models.ClientConfig{
+				DevPortalApiKey:   "123",
+				Web3HttpProviders: []models.Web3Provider{{ChainId: chains.Ethereum, Url: "http://localhost:8545"}},
+				OrderExpiry:       onchain.DeadlineAfter(-time.Hour),
+			},
+			expectedErrorDescription: "config validation error: invalid order expiry: deadline duration cannot be negative",
+		},
+		{
+			description: "Error - policy without chains",
+			config: models.ClientConfig{
+				DevPortalApiKey:   "123",
+				Web3HttpProviders: []models.Web3Provider{{ChainId: chains.Ethereum, Url: "http://localhost:8545"}},
+				Policy:            &models.Policy{},
+			},
+			expectedErrorDescription: "config validation error: invalid policy: request config errors: \nconfig validation error 'chains' is required in the request config\n",
+		},
 	}
 
 	for _, tc := range testcases {
@@ -77,3 +122,75 @@ func TestNewConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestNewClientVerifiesWeb3Providers(t *testing.T) {
+	routerCode := []byte{0x60, 0x80, 0x60, 0x40}
+
+	// The stub provider reports itself as Polygon and serves the router code above
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Id     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+
+		response := map[string]interface{}{"jsonrpc": "2.0", "id": request.Id}
+		switch request.Method {
+		case "eth_chainId":
+			response["result"] = hexutil.Uint64(chains.Polygon)
+		case "eth_getCode":
+			response["result"] = hexutil.Bytes(routerCode)
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(response))
+	}))
+	defer server.Close()
+
+	testcases := []struct {
+		description              string
+		chainId                  int
+		routerCodeHashes         map[int]string
+		expectedErrorDescription string
+	}{
+		{
+			description: "Provider on the configured chain",
+			chainId:     chains.Polygon,
+		},
+		{
+			description:      "Router code matches",
+			chainId:          chains.Polygon,
+			routerCodeHashes: map[int]string{chains.Polygon: crypto.Keccak256Hash(routerCode).Hex()},
+		},
+		{
+			description:              "Error - provider on another chain",
+			chainId:                  chains.Ethereum,
+			expectedErrorDescription: "is on chain id 137 but is configured for chain id 1",
+		},
+		{
+			description:              "Error - router code differs",
+			chainId:                  chains.Polygon,
+			routerCodeHashes:         map[int]string{chains.Polygon: crypto.Keccak256Hash([]byte{0x00}).Hex()},
+			expectedErrorDescription: "failed to verify 1inch router code for chain id 137",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.description, func(t *testing.T) {
+			c, err := NewClient(models.ClientConfig{
+				DevPortalApiKey: "abc123",
+				Web3HttpProviders: []models.Web3Provider{
+					{
+						ChainId: tc.chainId,
+						Url:     server.URL,
+					},
+				},
+				RouterCodeHashes: tc.routerCodeHashes,
+			})
+			if tc.expectedErrorDescription != "" {
+				require.ErrorContains(t, err, tc.expectedErrorDescription)
+				return
+			}
+			require.NoError(t, err)
+			c.Close()
+		})
+	}
+}
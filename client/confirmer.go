@@ -0,0 +1,227 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	"github.com/1inch/1inch-sdk-go/client/models"
+	"github.com/1inch/1inch-sdk-go/helpers"
+	"github.com/1inch/1inch-sdk-go/helpers/consts/amounts"
+	"github.com/1inch/1inch-sdk-go/helpers/consts/tokens"
+	"github.com/1inch/1inch-sdk-go/internal/approvals"
+	"github.com/1inch/1inch-sdk-go/internal/onchain"
+	"github.com/1inch/1inch-sdk-go/internal/orderbook"
+	"github.com/1inch/1inch-sdk-go/internal/policy"
+	"github.com/1inch/1inch-sdk-go/internal/swap"
+	"github.com/1inch/1inch-sdk-go/internal/wrap"
+)
+
+// TerminalConfirmer prints summaries to standard output and reads the answer from standard input
+// It is the Confirmer of clients that do not set one
+type TerminalConfirmer struct{}
+
+func (TerminalConfirmer) Confirm(ctx context.Context, summary *models.Summary) (bool, error) {
+	switch summary.Kind {
+	case models.SummarySwap:
+		return swap.ConfirmExecuteSwapWithUser(summary)
+	case models.SummaryApproval:
+		if summary.ApprovalFor == models.SummaryLimitOrder || summary.ApprovalFor == models.SummaryFillOrder {
+			return orderbook.ConfirmApprovalWithUser(summary)
+		}
+		return swap.ConfirmApprovalWithUser(summary)
+	case models.SummaryLimitOrder:
+		return orderbook.ConfirmLimitOrderWithUser(summary)
+	case models.SummaryWrap, models.SummaryUnwrap:
+		return wrap.ConfirmWrapNativeWithUser(summary)
+	case models.SummaryRevoke:
+		return approvals.ConfirmRevokeApprovalsWithUser(summary)
+	case models.SummaryCancelOrder, models.SummaryCancelAllOrders:
+		return orderbook.ConfirmCancelWithUser(summary)
+	case models.SummaryFillOrder:
+		return orderbook.ConfirmFillOrderWithUser(summary)
+	default:
+		return false, fmt.Errorf("unknown summary kind: %s", summary.Kind)
+	}
+}
+
+// AlwaysApproveConfirmer approves every action without asking, for automation where the params are checked beforehand
+type AlwaysApproveConfirmer struct{}
+
+func (AlwaysApproveConfirmer) Confirm(ctx context.Context, summary *models.Summary) (bool, error) {
+	return true, nil
+}
+
+// needsSummary reports whether an action has to be summarized, which is the case unless it skips warnings and the client has no policy
+func (c *Client) needsSummary(skipWarnings bool) bool {
+	return !skipWarnings || c.policy != nil
+}
+
+// confirm evaluates a summary against the policy of the client and then hands it to its Confirmer unless warnings are skipped
+// The summary is priced in USD first when the client or its policy needs it
+// Trades are confirmed with confirmTrade instead, so their value only counts towards the daily limits once they executed
+func (c *Client) confirm(ctx context.Context, summary *models.Summary, skipWarnings bool) (bool, error) {
+	reservation, ok, err := c.confirmTrade(ctx, summary, skipWarnings)
+	reservation.Record()
+	return ok, err
+}
+
+// confirmTrade is confirm for a trade, its USD value is reserved against the daily limits of the policy when it is approved
+// The caller settles the reservation with settleTrade once the outcome of the trade is known
+func (c *Client) confirmTrade(ctx context.Context, summary *models.Summary, skipWarnings bool) (*policy.Reservation, bool, error) {
+	if c.summaryUsdValues {
+		c.priceSummaryInUsd(ctx, summary)
+	}
+
+	var reservation *policy.Reservation
+	if c.policy != nil {
+		if c.policy.NeedsUsdValues(summary.ChainId) {
+			c.priceSummaryInUsd(ctx, summary)
+		}
+		var err error
+		reservation, err = c.policy.Reserve(summary, time.Now())
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
+	if !skipWarnings {
+		ok, err := c.Confirmer.Confirm(ctx, summary)
+		if err != nil || !ok {
+			reservation.Release()
+			return nil, false, err
+		}
+	}
+	return reservation, true, nil
+}
+
+// settleTrade records the reserved value of a trade whose transaction may have gone through and releases it otherwise
+// A trade certainly failed when its transaction reverted, was dropped or replaced, or was never sent. A wait cut short by
+// its context leaves the outcome open, so the trade keeps counting
+func settleTrade(reservation *policy.Reservation, receipt *types.Receipt, err error) {
+	if receipt != nil && receipt.Status == types.ReceiptStatusSuccessful ||
+		receipt == nil && (errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)) {
+		reservation.Record()
+		return
+	}
+	reservation.Release()
+}
+
+// checkPolicy evaluates a summary against the policy of the client without confirming or recording it
+// Actions call it before sending the transactions they depend on, so a denied action leaves nothing onchain
+func (c *Client) checkPolicy(ctx context.Context, summary *models.Summary) error {
+	if c.policy == nil {
+		return nil
+	}
+	if c.policy.NeedsUsdValues(summary.ChainId) {
+		c.priceSummaryInUsd(ctx, summary)
+	}
+	return c.policy.Evaluate(summary, time.Now())
+}
+
+// priceSummaryInUsd sets the USD value of the amounts of a summary by quoting them against the USDC of the chain
+// USD values are informational, so amounts that cannot be quoted are left without one
+func (c *Client) priceSummaryInUsd(ctx context.Context, summary *models.Summary) {
+	usdc, err := tokens.GetUsdcFromChainId(summary.ChainId)
+	if err != nil {
+		return
+	}
+
+	for _, amount := range []*models.SummaryAmount{summary.Selling, summary.Buying, summary.Approval, summary.GasCost} {
+		if amount == nil || amount.Amount == nil || amount.Amount.Sign() == 0 || amount.UsdValue != nil {
+			continue
+		}
+
+		usdAmount := amount.Amount
+		usdDecimals := int(amount.Token.Decimals)
+		if !strings.EqualFold(amount.Token.Address, usdc) {
+			quote, _, err := c.SwapApi.GetQuote(ctx, models.GetQuoteParams{
+				ChainId: summary.ChainId,
+				AggregationControllerGetQuoteParams: models.AggregationControllerGetQuoteParams{
+					Src:    amount.Token.Address,
+					Dst:    usdc,
+					Amount: amount.Amount.String(),
+				},
+			})
+			if err != nil {
+				continue
+			}
+			usdAmount, err = helpers.BigIntFromString(quote.ToAmount)
+			if err != nil {
+				continue
+			}
+			usdDecimals = int(quote.ToToken.Decimals)
+		}
+
+		value, _ := new(big.Float).Quo(new(big.Float).SetInt(usdAmount), new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(usdDecimals)), nil))).Float64()
+		amount.UsdValue = &value
+	}
+}
+
+// readTokenInfo reads the symbol and decimals of an ERC20 token for a summary
+func readTokenInfo(ethClient *ethclient.Client, token string) (*models.TokenInfo, error) {
+	symbol, err := onchain.ReadContractSymbol(ethClient, common.HexToAddress(token))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read symbol: %v", err)
+	}
+	decimals, err := onchain.ReadContractDecimals(ethClient, common.HexToAddress(token))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read decimals: %v", err)
+	}
+	return &models.TokenInfo{
+		Address:  token,
+		Symbol:   symbol,
+		Decimals: float32(decimals),
+	}, nil
+}
+
+// newApprovalSummaryAmount returns the allowance granted by an approval, without an amount when it is unlimited
+func newApprovalSummaryAmount(token *models.TokenInfo, approvalAmount *big.Int) *models.SummaryAmount {
+	if approvalAmount.Cmp(amounts.BigMaxUint256) == 0 {
+		return &models.SummaryAmount{Token: token}
+	}
+	return &models.SummaryAmount{Token: token, Amount: approvalAmount}
+}
+
+// newLimitOrderSummary reads the tokens of a limit order to summarize it
+// The buying amount of an order with dynamic pricing is its current price
+func newLimitOrderSummary(ethClient *ethclient.Client, chainId int, order *models.Order, pricing onchain.DynamicPricing) (*models.Summary, error) {
+	makerToken, err := readTokenInfo(ethClient, order.Data.MakerAsset)
+	if err != nil {
+		return nil, err
+	}
+	takerToken, err := readTokenInfo(ethClient, order.Data.TakerAsset)
+	if err != nil {
+		return nil, err
+	}
+	makingAmount, err := helpers.BigIntFromString(order.Data.MakingAmount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert making amount to big.Int: %v", err)
+	}
+	takingAmount, err := helpers.BigIntFromString(order.Data.TakingAmount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert taking amount to big.Int: %v", err)
+	}
+	if pricing != nil {
+		takingAmount, err = pricing.TakingAmount(makingAmount, makingAmount, makingAmount, time.Now())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get current taking amount: %v", err)
+		}
+	}
+	return &models.Summary{
+		Kind:         models.SummaryLimitOrder,
+		ChainId:      chainId,
+		Wallet:       order.Data.Maker,
+		Selling:      &models.SummaryAmount{Token: makerToken, Amount: makingAmount},
+		Buying:       &models.SummaryAmount{Token: takerToken, Amount: takingAmount},
+		Receiver:     order.Data.Receiver,
+		DynamicPrice: pricing != nil,
+	}, nil
+}
@@ -0,0 +1,268 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/1inch/1inch-sdk-go/client/models"
+	"github.com/1inch/1inch-sdk-go/helpers/consts/chains"
+	"github.com/1inch/1inch-sdk-go/helpers/consts/tokens"
+	"github.com/1inch/1inch-sdk-go/internal/onchain"
+	"github.com/1inch/1inch-sdk-go/internal/policy"
+)
+
+type recordingConfirmer struct {
+	approve   bool
+	summaries []*models.Summary
+}
+
+func (c *recordingConfirmer) Confirm(ctx context.Context, summary *models.Summary) (bool, error) {
+	c.summaries = append(c.summaries, summary)
+	return c.approve, nil
+}
+
+// newConfirmerTestServer serves a Polygon node answering eth_call with a token symbol and the quote endpoint of the swap API
+func newConfirmerTestServer(t *testing.T, quotedAmount string) *httptest.Server {
+	stringType, err := abi.NewType("string", "", nil)
+	require.NoError(t, err)
+	symbol, err := abi.Arguments{{Type: stringType}}.Pack("WMATIC")
+	require.NoError(t, err)
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			require.Equal(t, "/swap/v5.2/137/quote", r.URL.Path)
+			require.Equal(t, tokens.PolygonUsdc, r.URL.Query().Get("dst"))
+			require.NoError(t, json.NewEncoder(w).Encode(models.QuoteResponse{
+				ToAmount: quotedAmount,
+				ToToken:  &models.TokenInfo{Address: tokens.PolygonUsdc, Symbol: "USDC", Decimals: 6},
+			}))
+			return
+		}
+
+		var request struct {
+			Id     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+
+		response := map[string]interface{}{"jsonrpc": "2.0", "id": request.Id}
+		switch request.Method {
+		case "eth_chainId":
+			response["result"] = hexutil.Uint64(chains.Polygon)
+		case "eth_call":
+			response["result"] = hexutil.Bytes(symbol)
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(response))
+	}))
+}
+
+func TestConfirmerReceivesSummary(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	wallet := crypto.PubkeyToAddress(privateKey.PublicKey).Hex()
+
+	server := newConfirmerTestServer(t, "")
+	defer server.Close()
+
+	confirmer := &recordingConfirmer{}
+	c, err := NewClient(models.ClientConfig{
+		DevPortalApiKey:   "abc123",
+		Web3HttpProviders: []models.Web3Provider{{ChainId: chains.Polygon, Url: server.URL}},
+		Confirmer:         confirmer,
+	})
+	require.NoError(t, err)
+	defer c.Close()
+
+	err = c.Actions.UnwrapNative(context.Background(), models.UnwrapNativeParams{
+		ChainId:       chains.Polygon,
+		PublicAddress: wallet,
+		WalletKey:     hexutil.Encode(crypto.FromECDSA(privateKey))[2:],
+		Amount:        "1500000000000000000",
+	})
+	require.EqualError(t, err, "user rejected wrap")
+
+	require.Len(t, confirmer.summaries, 1)
+	summary := confirmer.summaries[0]
+	require.Equal(t, models.SummaryUnwrap, summary.Kind)
+	require.Equal(t, chains.Polygon, summary.ChainId)
+	require.Equal(t, wallet, summary.Wallet)
+	require.Equal(t, "1.5 WMATIC", summary.Selling.String())
+	require.Equal(t, "1.5 MATIC", summary.Buying.String())
+}
+
+func TestPolicyDeniesSkippedWarnings(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	server := newConfirmerTestServer(t, "")
+	defer server.Close()
+
+	confirmer := &recordingConfirmer{approve: true}
+	c, err := NewClient(models.ClientConfig{
+		DevPortalApiKey:   "abc123",
+		Web3HttpProviders: []models.Web3Provider{{ChainId: chains.Polygon, Url: server.URL}},
+		Confirmer:         confirmer,
+		Policy:            &models.Policy{Chains: map[int]models.ChainPolicy{chains.Ethereum: {}}},
+	})
+	require.NoError(t, err)
+	defer c.Close()
+
+	err = c.Actions.UnwrapNative(context.Background(), models.UnwrapNativeParams{
+		ChainId:       chains.Polygon,
+		PublicAddress: crypto.PubkeyToAddress(privateKey.PublicKey).Hex(),
+		WalletKey:     hexutil.Encode(crypto.FromECDSA(privateKey))[2:],
+		Amount:        "1500000000000000000",
+		SkipWarnings:  true,
+	})
+	require.EqualError(t, err, "failed to confirm wrap: unwrap denied by policy: no rules for chain 137 (chain)")
+	var policyError *models.PolicyError
+	require.ErrorAs(t, err, &policyError)
+	require.Empty(t, confirmer.summaries)
+}
+
+func TestConfirmers(t *testing.T) {
+	ok, err := AlwaysApproveConfirmer{}.Confirm(context.Background(), &models.Summary{Kind: models.SummarySwap})
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	_, err = TerminalConfirmer{}.Confirm(context.Background(), &models.Summary{Kind: "transfer"})
+	require.EqualError(t, err, "unknown summary kind: transfer")
+
+	c, err := NewClient(models.ClientConfig{
+		DevPortalApiKey:   "abc123",
+		Web3HttpProviders: []models.Web3Provider{{ChainId: chains.Polygon, Url: newConfirmerTestServer(t, "").URL}},
+	})
+	require.NoError(t, err)
+	defer c.Close()
+	require.Equal(t, TerminalConfirmer{}, c.Confirmer)
+}
+
+func TestPriceSummaryInUsd(t *testing.T) {
+	server := newConfirmerTestServer(t, "2500000")
+	defer server.Close()
+
+	confirmer := &recordingConfirmer{approve: true}
+	c, err := NewClient(models.ClientConfig{
+		DevPortalApiKey:   "abc123",
+		Web3HttpProviders: []models.Web3Provider{{ChainId: chains.Polygon, Url: server.URL}},
+		Confirmer:         confirmer,
+		SummaryUsdValues:  true,
+	})
+	require.NoError(t, err)
+	defer c.Close()
+	c.ApiBaseURL, err = url.Parse(server.URL)
+	require.NoError(t, err)
+
+	usdc := &models.TokenInfo{Address: tokens.PolygonUsdc, Symbol: "USDC", Decimals: 6}
+	ok, err := c.confirm(context.Background(), &models.Summary{
+		Kind:     models.SummarySwap,
+		ChainId:  chains.Polygon,
+		Selling:  &models.SummaryAmount{Token: getNativeTokenDetails(chains.Polygon), Amount: big.NewInt(3e18)},
+		Buying:   &models.SummaryAmount{Token: usdc, Amount: big.NewInt(2490000)},
+		Approval: &models.SummaryAmount{Token: usdc},
+	}, false)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	summary := confirmer.summaries[0]
+	require.Equal(t, "3 MATIC (~$2.50)", summary.Selling.String())
+	require.Equal(t, "2.49 USDC (~$2.49)", summary.Buying.String())
+	require.Nil(t, summary.Approval.UsdValue, "unlimited approvals have no USD value")
+}
+
+func TestSettleTrade(t *testing.T) {
+	usdValue := 1000.0
+	trade := &models.Summary{
+		Kind:    models.SummarySwap,
+		ChainId: chains.Polygon,
+		Wallet:  "0x1111111111111111111111111111111111111111",
+		Selling: &models.SummaryAmount{Token: &models.TokenInfo{Address: tokens.PolygonUsdc, Symbol: "USDC", Decimals: 6}, Amount: big.NewInt(1e9), UsdValue: &usdValue},
+	}
+
+	testcases := []struct {
+		description string
+		receipt     *types.Receipt
+		err         error
+		counted     bool
+	}{
+		{
+			description: "Mined swap",
+			receipt:     &types.Receipt{Status: types.ReceiptStatusSuccessful},
+			counted:     true,
+		},
+		{
+			description: "Reverted swap",
+			receipt:     &types.Receipt{Status: types.ReceiptStatusFailed},
+			err:         fmt.Errorf("failed to get transaction receipt: %w", onchain.ErrTransactionReverted),
+		},
+		{
+			description: "Dropped swap",
+			err:         fmt.Errorf("failed to get transaction receipt: %w", onchain.ErrTransactionDropped),
+		},
+		{
+			description: "Swap that was never sent",
+			err:         errors.New("failed to send transaction: insufficient funds"),
+		},
+		{
+			description: "Wait that timed out",
+			err:         fmt.Errorf("failed to get transaction receipt: %w", context.DeadlineExceeded),
+			counted:     true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.description, func(t *testing.T) {
+			c := &Client{
+				Confirmer: &recordingConfirmer{approve: true},
+				policy:    policy.NewEngine(models.Policy{Chains: map[int]models.ChainPolicy{chains.Polygon: {MaxDailyUsd: 1500}}}),
+			}
+
+			reservation, ok, err := c.confirmTrade(context.Background(), trade, false)
+			require.NoError(t, err)
+			require.True(t, ok)
+			settleTrade(reservation, tc.receipt, tc.err)
+
+			// A second trade of the same value only fits in the daily limit when the first one was released
+			err = c.policy.Evaluate(trade, time.Now())
+			if tc.counted {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRejectedTradeReleasesReservation(t *testing.T) {
+	usdValue := 1000.0
+	trade := &models.Summary{
+		Kind:    models.SummarySwap,
+		ChainId: chains.Polygon,
+		Wallet:  "0x1111111111111111111111111111111111111111",
+		Selling: &models.SummaryAmount{Token: &models.TokenInfo{Address: tokens.PolygonUsdc, Symbol: "USDC", Decimals: 6}, Amount: big.NewInt(1e9), UsdValue: &usdValue},
+	}
+	c := &Client{
+		Confirmer: &recordingConfirmer{approve: false},
+		policy:    policy.NewEngine(models.Policy{Chains: map[int]models.ChainPolicy{chains.Polygon: {MaxDailyUsd: 1500}}}),
+	}
+
+	reservation, ok, err := c.confirmTrade(context.Background(), trade, false)
+	require.NoError(t, err)
+	require.False(t, ok)
+	require.Nil(t, reservation)
+	require.NoError(t, c.policy.Evaluate(trade, time.Now()))
+}
@@ -0,0 +1,113 @@
+package models
+
+import (
+	"fmt"
+
+	"github.com/1inch/1inch-sdk-go/internal/onchain"
+	"github.com/1inch/1inch-sdk-go/internal/validate"
+)
+
+type GetApprovalsParams struct {
+	ChainId            int
+	Wallet             string
+	Spenders           []string // Optional, defaults to every spender
+	OnlyOneInchRouters bool     // Restricts the scan to the current and previous 1inch routers
+	FromBlock          uint64
+	ToBlock            uint64 // Optional, defaults to the latest block
+	BlockChunkSize     uint64 // Optional, splits the scan into smaller eth_getLogs requests
+	IncludeRevoked     bool   // Includes spenders whose live allowance is already zero
+}
+
+func (params *GetApprovalsParams) Validate() error {
+	var validationErrors []error
+	validationErrors = validate.Parameter(params.ChainId, "chainId", validate.CheckChainIdRequired, validationErrors)
+	validationErrors = validate.Parameter(params.Wallet, "wallet", validate.CheckEthereumAddressRequired, validationErrors)
+	for i, spender := range params.Spenders {
+		validationErrors = validate.Parameter(spender, fmt.Sprintf("spenders[%d]", i), validate.CheckEthereumAddressRequired, validationErrors)
+	}
+	if len(params.Spenders) > 0 && params.OnlyOneInchRouters {
+		validationErrors = append(validationErrors, validate.NewParameterCustomError("spenders and onlyOneInchRouters cannot be used together"))
+	}
+	if params.ToBlock != 0 && params.FromBlock > params.ToBlock {
+		validationErrors = append(validationErrors, validate.NewParameterCustomError("fromBlock must not be after toBlock"))
+	}
+	return validate.ConsolidateValidationErorrs(validationErrors)
+}
+
+type GetAllowanceParams struct {
+	ChainId int
+	Token   string
+	Owner   string
+	Spender string
+}
+
+func (params *GetAllowanceParams) Validate() error {
+	var validationErrors []error
+	validationErrors = validate.Parameter(params.ChainId, "chainId", validate.CheckChainIdRequired, validationErrors)
+	validationErrors = validate.Parameter(params.Token, "token", validate.CheckEthereumAddressRequired, validationErrors)
+	validationErrors = validate.Parameter(params.Owner, "owner", validate.CheckEthereumAddressRequired, validationErrors)
+	validationErrors = validate.Parameter(params.Spender, "spender", validate.CheckEthereumAddressRequired, validationErrors)
+	return validate.ConsolidateValidationErorrs(validationErrors)
+}
+
+type BuildApprovalTransactionParams struct {
+	ChainId       int
+	PublicAddress string
+	Token         string
+	Spender       string // Optional, defaults to the 1inch router of the chain
+	Amount        string // Optional, defaults to an unlimited approval
+}
+
+func (params *BuildApprovalTransactionParams) Validate() error {
+	var validationErrors []error
+	validationErrors = validate.Parameter(params.ChainId, "chainId", validate.CheckChainIdRequired, validationErrors)
+	validationErrors = validate.Parameter(params.PublicAddress, "publicAddress", validate.CheckEthereumAddressRequired, validationErrors)
+	validationErrors = validate.Parameter(params.Token, "token", validate.CheckEthereumAddressRequired, validationErrors)
+	validationErrors = validate.Parameter(params.Spender, "spender", validate.CheckEthereumAddress, validationErrors)
+	validationErrors = validate.Parameter(params.Amount, "amount", validate.CheckBigInt, validationErrors)
+	return validate.ConsolidateValidationErorrs(validationErrors)
+}
+
+type BuildRevokeTransactionsParams struct {
+	ChainId       int
+	PublicAddress string
+	Approvals     []TokenApproval
+}
+
+func (params *BuildRevokeTransactionsParams) Validate() error {
+	var validationErrors []error
+	validationErrors = validate.Parameter(params.ChainId, "chainId", validate.CheckChainIdRequired, validationErrors)
+	validationErrors = validate.Parameter(params.PublicAddress, "publicAddress", validate.CheckEthereumAddressRequired, validationErrors)
+	validationErrors = append(validationErrors, validateTokenApprovals(params.Approvals)...)
+	return validate.ConsolidateValidationErorrs(validationErrors)
+}
+
+type RevokeApprovalsParams struct {
+	ChainId       int
+	PublicAddress string
+	WalletKey     string
+	Approvals     []TokenApproval
+	WaitOptions   onchain.WaitOptions
+	SkipWarnings  bool
+}
+
+func (params *RevokeApprovalsParams) Validate() error {
+	var validationErrors []error
+	validationErrors = validate.Parameter(params.ChainId, "chainId", validate.CheckChainIdRequired, validationErrors)
+	validationErrors = validate.Parameter(params.PublicAddress, "publicAddress", validate.CheckEthereumAddressRequired, validationErrors)
+	validationErrors = validate.Parameter(params.WalletKey, "walletKey", validate.CheckPrivateKeyRequired, validationErrors)
+	validationErrors = append(validationErrors, validateTokenApprovals(params.Approvals)...)
+	return validate.ConsolidateValidationErorrs(validationErrors)
+}
+
+func validateTokenApprovals(approvals []TokenApproval) []error {
+	var validationErrors []error
+	if len(approvals) == 0 {
+		validationErrors = append(validationErrors, validate.NewParameterMissingError("approvals"))
+	}
+	for i, approval := range approvals {
+		validationErrors = validate.Parameter(approval.Token, fmt.Sprintf("approvals[%d].token", i), validate.CheckEthereumAddressRequired, validationErrors)
+		validationErrors = validate.Parameter(approval.Spender, fmt.Sprintf("approvals[%d].spender", i), validate.CheckEthereumAddressRequired, validationErrors)
+	}
+	return validationErrors
+}
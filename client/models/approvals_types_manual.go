@@ -0,0 +1,27 @@
+package models
+
+import "math/big"
+
+// TokenApproval is an allowance a wallet has granted to a spender
+type TokenApproval struct {
+	Token            string
+	Spender          string
+	Allowance        *big.Int
+	IsOneInchRouter  bool
+	LastUpdatedBlock uint64
+}
+
+// RevokeTransaction is an unsigned transaction that resets a single allowance to zero
+type RevokeTransaction struct {
+	Token   string
+	Spender string
+	To      string
+	Data    string
+	// Method is either "approve" or "decreaseAllowance" for tokens that reject approve(spender, 0)
+	Method string
+}
+
+type RevokeResult struct {
+	RevokeTransaction
+	Error error
+}
@@ -0,0 +1,174 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/1inch/1inch-sdk-go/helpers/consts/chains"
+	"github.com/1inch/1inch-sdk-go/internal/validate"
+)
+
+func TestGetApprovalsParams_Validate(t *testing.T) {
+	testCases := []struct {
+		description  string
+		params       GetApprovalsParams
+		expectErrors []string
+	}{
+		{
+			description: "Valid parameters",
+			params: GetApprovalsParams{
+				ChainId:            chains.Ethereum,
+				Wallet:             "0x1234567890abcdef1234567890abcdef12345678",
+				OnlyOneInchRouters: true,
+				FromBlock:          100,
+				ToBlock:            200,
+			},
+		},
+		{
+			description: "Missing required parameters",
+			params:      GetApprovalsParams{},
+			expectErrors: []string{
+				"'chainId' is required",
+				"'wallet' is required",
+			},
+		},
+		{
+			description: "Conflicting spender filters and block range",
+			params: GetApprovalsParams{
+				ChainId:            chains.Ethereum,
+				Wallet:             "0x1234567890abcdef1234567890abcdef12345678",
+				Spenders:           []string{"0x1234567890abcdef1234567890abcdef12345679"},
+				OnlyOneInchRouters: true,
+				FromBlock:          200,
+				ToBlock:            100,
+			},
+			expectErrors: []string{
+				"spenders and onlyOneInchRouters cannot be used together",
+				"fromBlock must not be after toBlock",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := tc.params.Validate()
+
+			if len(tc.expectErrors) > 0 {
+				require.Error(t, err)
+				for _, expectedError := range tc.expectErrors {
+					require.Contains(t, err.Error(), expectedError, "Error message should contain the expected text")
+				}
+				require.Equal(t, len(tc.expectErrors), validate.GetValidatorErrorsCount(err), "The number of errors returned should match the length of the expected errors: %s\n", err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRevokeApprovalsParams_Validate(t *testing.T) {
+	testCases := []struct {
+		description  string
+		params       RevokeApprovalsParams
+		expectErrors []string
+	}{
+		{
+			description: "Valid parameters",
+			params: RevokeApprovalsParams{
+				ChainId:       chains.Ethereum,
+				PublicAddress: "0x1234567890abcdef1234567890abcdef12345678",
+				WalletKey:     "a0b1c2d3e4f5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b1",
+				Approvals: []TokenApproval{
+					{
+						Token:   "0x1234567890abcdef1234567890abcdef12345679",
+						Spender: "0x1234567890abcdef1234567890abcdef1234567a",
+					},
+				},
+			},
+		},
+		{
+			description: "Missing required parameters",
+			params:      RevokeApprovalsParams{},
+			expectErrors: []string{
+				"'chainId' is required",
+				"'publicAddress' is required",
+				"'walletKey' is required",
+				"'approvals' is required",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := tc.params.Validate()
+
+			if len(tc.expectErrors) > 0 {
+				require.Error(t, err)
+				for _, expectedError := range tc.expectErrors {
+					require.Contains(t, err.Error(), expectedError, "Error message should contain the expected text")
+				}
+				require.Equal(t, len(tc.expectErrors), validate.GetValidatorErrorsCount(err), "The number of errors returned should match the length of the expected errors: %s\n", err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestBuildApprovalTransactionParams_Validate(t *testing.T) {
+	testCases := []struct {
+		description  string
+		params       BuildApprovalTransactionParams
+		expectErrors []string
+	}{
+		{
+			description: "Valid parameters",
+			params: BuildApprovalTransactionParams{
+				ChainId:       chains.Ethereum,
+				PublicAddress: "0x1234567890abcdef1234567890abcdef12345678",
+				Token:         "0x1234567890abcdef1234567890abcdef12345679",
+				Amount:        "1000000",
+			},
+		},
+		{
+			description: "Missing required parameters",
+			params:      BuildApprovalTransactionParams{},
+			expectErrors: []string{
+				"'chainId' is required",
+				"'publicAddress' is required",
+				"'token' is required",
+			},
+		},
+		{
+			description: "Invalid optional parameters",
+			params: BuildApprovalTransactionParams{
+				ChainId:       chains.Ethereum,
+				PublicAddress: "0x1234567890abcdef1234567890abcdef12345678",
+				Token:         "0x1234567890abcdef1234567890abcdef12345679",
+				Spender:       "0x123",
+				Amount:        "-",
+			},
+			expectErrors: []string{
+				"'spender'",
+				"'amount'",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := tc.params.Validate()
+
+			if len(tc.expectErrors) > 0 {
+				require.Error(t, err)
+				for _, expectedError := range tc.expectErrors {
+					require.Contains(t, err.Error(), expectedError, "Error message should contain the expected text")
+				}
+				require.Equal(t, len(tc.expectErrors), validate.GetValidatorErrorsCount(err), "The number of errors returned should match the length of the expected errors: %s\n", err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -1,15 +1,70 @@
 package models
 
-import "fmt"
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/1inch/1inch-sdk-go/internal/onchain"
+	"github.com/1inch/1inch-sdk-go/internal/web3"
+)
+
+var hashRegex = regexp.MustCompile(`^0x[0-9a-fA-F]{64}$`)
+var privateKeyRegex = regexp.MustCompile(`^[0-9a-fA-F]{64}$`)
 
 type ClientConfig struct {
 	DevPortalApiKey   string
 	Web3HttpProviders []Web3Provider
+	// Web3HealthCheck controls the background checks used to fail over between providers of the same chain
+	Web3HealthCheck web3.HealthCheckConfig
+	// Web3WsProviders optionally adds WebSocket endpoints used to follow new blocks instead of polling
+	// Every chain listed here also needs an HTTP provider, which is polled whenever the WebSocket is down
+	Web3WsProviders []Web3Provider
+	// HeadWatcher controls how new blocks are followed
+	HeadWatcher web3.HeadWatcherConfig
+	// RouterCodeHashes optionally maps chain ids to the expected keccak256 hash of the 1inch router bytecode
+	// Every provider of a listed chain must return matching code when the client is created
+	RouterCodeHashes map[int]string
+	// Web3BroadcastProviders optionally adds RPC endpoints that the signed transactions of their chain are also sent to
+	// Transactions are sent to all of them and to the HTTP providers of the chain at once
+	Web3BroadcastProviders []Web3Provider
+	// PrivateRelays optionally sends the signed transactions of a chain to private relays instead of the public mempool
+	PrivateRelays []PrivateRelay
+	// PermitDeadline is the default deadline of the permits signed for swaps (defaults to one minute)
+	PermitDeadline onchain.Deadline
+	// OrderExpiry is the default expiry of limit orders, which also applies to their permits (defaults to one minute)
+	OrderExpiry onchain.Deadline
+	// Confirmer approves or rejects actions before they are signed, sent or posted (defaults to a prompt in the terminal)
+	Confirmer Confirmer
+	// SummaryUsdValues prices the amounts of every summary in USD before it is given to the Confirmer
+	// Each amount costs one quote request, amounts on chains without a known USDC are not priced
+	SummaryUsdValues bool
+	// Policy optionally restricts the swaps, approvals, wraps and limit orders of the client, actions it denies fail with a
+	// PolicyError. It also applies to actions that skip warnings, amounts are priced in USD when its rules need them
+	Policy *Policy
 }
 
+// Web3Provider is an RPC endpoint for a chain, several providers can be given for the same chain
 type Web3Provider struct {
 	ChainId int
 	Url     string
+	// Priority orders the providers of a chain, lower values are tried first
+	Priority int
+	// Weight spreads requests between healthy providers sharing a priority (defaults to 1)
+	Weight int
+}
+
+// PrivateRelay is a Flashbots-style relay that keeps transactions out of the public mempool until they are mined
+type PrivateRelay struct {
+	ChainId int
+	Url     string
+	// AuthKey is an optional private key used to sign the X-Flashbots-Signature header, it only identifies the sender
+	// to the relay and should not hold any funds
+	AuthKey string
+	// UseBundles sends transactions with eth_sendBundle instead of eth_sendPrivateTransaction
+	UseBundles bool
+	// BlockRange is the number of blocks the relay may include a transaction in before it is dropped (defaults to 25)
+	BlockRange uint64
 }
 
 func (c *ClientConfig) Validate() error {
@@ -20,6 +75,7 @@ func (c *ClientConfig) Validate() error {
 	if len(c.Web3HttpProviders) == 0 {
 		return fmt.Errorf("at least one web3 provider URL is required")
 	}
+	seen := make(map[int]map[string]bool)
 	for _, provider := range c.Web3HttpProviders {
 		if provider.ChainId == 0 {
 			return fmt.Errorf("all web3 providers must have a chain ID set")
@@ -27,6 +83,73 @@ func (c *ClientConfig) Validate() error {
 		if provider.Url == "" {
 			return fmt.Errorf("all web3 providers must have a URL set")
 		}
+		if provider.Weight < 0 {
+			return fmt.Errorf("web3 provider weights cannot be negative")
+		}
+		if seen[provider.ChainId] == nil {
+			seen[provider.ChainId] = make(map[string]bool)
+		}
+		if seen[provider.ChainId][provider.Url] {
+			return fmt.Errorf("duplicate web3 provider %s for chain ID %d", web3.RedactUrl(provider.Url), provider.ChainId)
+		}
+		seen[provider.ChainId][provider.Url] = true
+	}
+	for _, provider := range c.Web3WsProviders {
+		if seen[provider.ChainId] == nil {
+			return fmt.Errorf("all web3 WebSocket providers must have an HTTP provider for the same chain ID")
+		}
+		if !strings.HasPrefix(provider.Url, "ws://") && !strings.HasPrefix(provider.Url, "wss://") {
+			return fmt.Errorf("all web3 WebSocket providers must have a ws:// or wss:// URL")
+		}
+	}
+	if c.HeadWatcher.PollInterval < 0 || c.HeadWatcher.ReconnectDelay < 0 || c.HeadWatcher.MaxReconnectDelay < 0 {
+		return fmt.Errorf("head watcher durations cannot be negative")
+	}
+	for chainId, codeHash := range c.RouterCodeHashes {
+		if seen[chainId] == nil {
+			return fmt.Errorf("router code hash given for chain ID %d which has no web3 provider", chainId)
+		}
+		if !hashRegex.MatchString(codeHash) {
+			return fmt.Errorf("router code hash for chain ID %d must be a 32 byte hex string", chainId)
+		}
+	}
+	for _, provider := range c.Web3BroadcastProviders {
+		if seen[provider.ChainId] == nil {
+			return fmt.Errorf("all web3 broadcast providers must have an HTTP provider for the same chain ID")
+		}
+		if provider.Url == "" {
+			return fmt.Errorf("all web3 broadcast providers must have a URL set")
+		}
+	}
+	for _, relay := range c.PrivateRelays {
+		if seen[relay.ChainId] == nil {
+			return fmt.Errorf("all private relays must have an HTTP provider for the same chain ID")
+		}
+		if !strings.HasPrefix(relay.Url, "http://") && !strings.HasPrefix(relay.Url, "https://") {
+			return fmt.Errorf("all private relays must have an http:// or https:// URL")
+		}
+		if relay.AuthKey != "" && !privateKeyRegex.MatchString(relay.AuthKey) {
+			return fmt.Errorf("private relay auth key for chain ID %d must be a 32 byte hex string", relay.ChainId)
+		}
+		for _, provider := range c.Web3BroadcastProviders {
+			if provider.ChainId == relay.ChainId {
+				return fmt.Errorf("web3 broadcast providers and private relays cannot be used together for chain ID %d", relay.ChainId)
+			}
+		}
+	}
+	if err := c.PermitDeadline.Validate(); err != nil {
+		return fmt.Errorf("invalid permit deadline: %v", err)
+	}
+	if err := c.OrderExpiry.Validate(); err != nil {
+		return fmt.Errorf("invalid order expiry: %v", err)
+	}
+	if c.Policy != nil {
+		if err := c.Policy.Validate(); err != nil {
+			return fmt.Errorf("invalid policy: %v", err)
+		}
+	}
+	if c.Web3HealthCheck.Interval < 0 || c.Web3HealthCheck.Timeout < 0 || c.Web3HealthCheck.MaxLatency < 0 {
+		return fmt.Errorf("web3 health check durations cannot be negative")
 	}
 
 	return nil
@@ -0,0 +1,76 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/1inch/1inch-sdk-go/helpers"
+)
+
+// Confirmer approves or rejects the actions the SDK is about to sign, send or post
+// It is not asked when the params of an action set SkipWarnings, nor when the policy of the client denies the action
+type Confirmer interface {
+	Confirm(ctx context.Context, summary *Summary) (bool, error)
+}
+
+type SummaryKind string
+
+const (
+	SummarySwap       SummaryKind = "swap"
+	SummaryApproval   SummaryKind = "approval"
+	SummaryLimitOrder SummaryKind = "limitOrder"
+	SummaryWrap       SummaryKind = "wrap"
+	SummaryUnwrap     SummaryKind = "unwrap"
+	SummaryRevoke     SummaryKind = "revoke"
+	// SummaryCancelOrder cancels a single limit order and SummaryCancelAllOrders every open limit order of the wallet
+	SummaryCancelOrder     SummaryKind = "cancelOrder"
+	SummaryCancelAllOrders SummaryKind = "cancelAllOrders"
+	// SummaryFillOrder fills the limit order of another maker, selling the taker asset and buying the maker asset
+	SummaryFillOrder SummaryKind = "fillOrder"
+)
+
+// Summary describes an action waiting to be confirmed, fields that do not apply to its kind are left empty
+type Summary struct {
+	Kind    SummaryKind
+	ChainId int
+	Wallet  string
+	Selling *SummaryAmount // Tokens leaving the wallet
+	Buying  *SummaryAmount // Tokens received, an estimation for swaps
+	// Receiver gets the tokens bought, the wallet itself when empty
+	Receiver string
+	// Approval is the allowance granted to the 1inch router, its Amount is nil for an unlimited approval
+	Approval *SummaryAmount
+	// ApprovalFor is the kind of action an approval is needed for
+	ApprovalFor SummaryKind
+	// ApprovalType is how the router is allowed to spend the tokens sold by a swap ("Permit1" or "Contract approval")
+	ApprovalType string
+	Slippage     float32        // Swaps only, in percent
+	GasCost      *SummaryAmount // Optional estimated gas cost of every transaction of the action
+	GasCostQuote *SummaryAmount // Optional, GasCost priced in the quote token of the limits of SwapTokens
+	PriceImpact  *float64       // Optional, in percent
+	Revocations  []RevokeTransaction
+	OrderHash    string // Only set when canceling or filling an order whose hash is known
+	// DynamicPrice is set for limit orders priced by a calculator contract, their Buying amount is the current price
+	DynamicPrice bool
+}
+
+// SummaryAmount is an amount of a token shown in a summary
+type SummaryAmount struct {
+	Token    *TokenInfo
+	Amount   *big.Int
+	UsdValue *float64 // Only set when the client prices summaries in USD
+}
+
+// String formats the amount with the decimals and symbol of its token, followed by its USD value when known
+// An amount without a value, such as an unlimited approval, is shown as unlimited
+func (a *SummaryAmount) String() string {
+	value := "unlimited"
+	if a.Amount != nil {
+		value = fmt.Sprintf("%s %s", helpers.SimplifyValue(a.Amount.String(), int(a.Token.Decimals)), a.Token.Symbol)
+	}
+	if a.UsdValue != nil {
+		value += fmt.Sprintf(" (~$%.2f)", *a.UsdValue)
+	}
+	return value
+}
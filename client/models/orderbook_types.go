@@ -1,6 +1,12 @@
 package models
 
 import (
+	"context"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/1inch/1inch-sdk-go/helpers/consts/addresses"
 	"github.com/1inch/1inch-sdk-go/helpers/consts/tokens"
 	"github.com/1inch/1inch-sdk-go/internal/onchain"
 	"github.com/1inch/1inch-sdk-go/internal/validate"
@@ -8,9 +14,12 @@ import (
 
 type CreateOrderParams struct {
 	ApprovalType                   onchain.ApprovalType
+	ApprovalPolicy                 onchain.ApprovalPolicy
+	WaitOptions                    onchain.WaitOptions
 	ChainId                        int
 	PrivateKey                     string
-	ExpireAfter                    int64
+	ExpireAfter                    int64            // Optional unix timestamp in seconds, defaults to Expiry
+	Expiry                         onchain.Deadline // Optional, defaults to the order expiry of the client
 	Maker                          string
 	MakerAsset                     string
 	TakerAsset                     string
@@ -19,25 +28,71 @@ type CreateOrderParams struct {
 	Taker                          string
 	SkipWarnings                   bool
 	EnableOnchainApprovalsIfNeeded bool
+	AutoWrapNative                 bool                   // Wraps the native gas token when it is the maker asset and the wrapped balance is insufficient
+	ContractSigner                 ContractSigner         // Signs for a smart contract wallet maker instead of PrivateKey
+	Predicate                      onchain.Predicate      // Optional, an extra condition that must hold for the order to be filled
+	Pricing                        onchain.DynamicPricing // Optional, prices the order with a calculator contract instead of a fixed ratio
 }
 
+// ContractSigner returns the EIP-1271 signature a smart contract wallet maker accepts for an order hash
+// For a Safe, this is typically the concatenated signatures of enough owners over the order hash
+type ContractSigner func(ctx context.Context, orderHash common.Hash, orderData OrderData) ([]byte, error)
+
 func (params *CreateOrderParams) Validate() error {
 	var validationErrors []error
 	validationErrors = validate.Parameter(params.ChainId, "chainId", validate.CheckChainIdRequired, validationErrors)
-	validationErrors = validate.Parameter(params.PrivateKey, "privateKey", validate.CheckPrivateKeyRequired, validationErrors)
+	if params.ContractSigner == nil {
+		validationErrors = validate.Parameter(params.PrivateKey, "privateKey", validate.CheckPrivateKeyRequired, validationErrors)
+	} else {
+		validationErrors = validate.Parameter(params.PrivateKey, "privateKey", validate.CheckPrivateKey, validationErrors)
+	}
 	validationErrors = validate.Parameter(params.Maker, "maker", validate.CheckEthereumAddressRequired, validationErrors)
 	validationErrors = validate.Parameter(params.ExpireAfter, "expireAfter", validate.CheckExpireAfter, validationErrors)
+	if err := params.Expiry.Validate(); err != nil {
+		validationErrors = append(validationErrors, validate.NewParameterValidationError("expiry", err.Error()))
+	}
+	if params.ExpireAfter != 0 && !params.Expiry.IsZero() {
+		validationErrors = append(validationErrors, validate.NewParameterCustomError("expireAfter and expiry cannot be used together"))
+	}
 	validationErrors = validate.Parameter(params.MakerAsset, "makerAsset", validate.CheckEthereumAddressRequired, validationErrors)
 	validationErrors = validate.Parameter(params.TakerAsset, "takerAsset", validate.CheckEthereumAddressRequired, validationErrors)
 	validationErrors = validate.Parameter(params.TakingAmount, "takingAmount", validate.CheckBigIntRequired, validationErrors)
 	validationErrors = validate.Parameter(params.MakingAmount, "makingAmount", validate.CheckBigIntRequired, validationErrors)
 	validationErrors = validate.Parameter(params.Taker, "taker", validate.CheckEthereumAddress, validationErrors)
+	if params.Pricing != nil {
+		if err := params.Pricing.Validate(); err != nil {
+			validationErrors = append(validationErrors, validate.NewParameterValidationError("pricing", err.Error()))
+		}
+	}
 	if params.MakerAsset == params.TakerAsset && (params.MakerAsset != "" && params.TakerAsset != "") {
 		validationErrors = append(validationErrors, validate.NewParameterCustomError("maker asset and taker asset cannot be the same"))
 	}
-	if params.MakerAsset == tokens.NativeToken || params.TakerAsset == tokens.NativeToken {
+	if (params.MakerAsset == tokens.NativeToken && !params.AutoWrapNative) || params.TakerAsset == tokens.NativeToken {
 		validationErrors = append(validationErrors, validate.NewParameterCustomError("native gas token is not supported as maker or taker asset"))
 	}
+	if params.MakerAsset == tokens.NativeToken && params.AutoWrapNative && params.ChainId != 0 {
+		wrappedNativeToken, err := tokens.GetWrappedNativeTokenFromChainId(params.ChainId)
+		if err != nil {
+			validationErrors = append(validationErrors, validate.NewParameterCustomError(err.Error()))
+		} else if strings.EqualFold(wrappedNativeToken, params.TakerAsset) {
+			validationErrors = append(validationErrors, validate.NewParameterCustomError("maker asset and taker asset cannot be the same"))
+		}
+	}
+	if params.ContractSigner != nil {
+		// Contract makers can only sign orders, their approvals have to be executed by the contract itself
+		if params.PrivateKey != "" {
+			validationErrors = append(validationErrors, validate.NewParameterCustomError("privateKey and contractSigner cannot be used together"))
+		}
+		if params.AutoWrapNative || params.EnableOnchainApprovalsIfNeeded {
+			validationErrors = append(validationErrors, validate.NewParameterCustomError("contract makers cannot wrap native tokens or approve tokens from the SDK"))
+		}
+		if params.ApprovalType == onchain.PermitAlways {
+			validationErrors = append(validationErrors, validate.NewParameterCustomError("contract makers cannot sign permits"))
+		}
+	}
+	if err := params.ApprovalPolicy.Validate(); err != nil {
+		validationErrors = append(validationErrors, validate.NewParameterCustomError(err.Error()))
+	}
 
 	return validate.ConsolidateValidationErorrs(validationErrors)
 }
@@ -127,3 +182,110 @@ func (params *GetActiveOrdersWithPermitParams) Validate() error {
 	validationErrors = validate.Parameter(params.Token, "token", validate.CheckEthereumAddressRequired, validationErrors)
 	return validate.ConsolidateValidationErorrs(validationErrors)
 }
+
+type CancelOrderParams struct {
+	ChainId      int
+	PrivateKey   string
+	Maker        string
+	Order        OrderData // The order as signed by the maker, such as the Data of an OrderResponse
+	OrderHash    string    // Optional, only shown in the summary of the cancellation
+	WaitOptions  onchain.WaitOptions
+	SkipWarnings bool
+}
+
+func (params *CancelOrderParams) Validate() error {
+	var validationErrors []error
+	validationErrors = validate.Parameter(params.ChainId, "chainId", validate.CheckChainIdRequired, validationErrors)
+	validationErrors = validate.Parameter(params.PrivateKey, "privateKey", validate.CheckPrivateKeyRequired, validationErrors)
+	validationErrors = validate.Parameter(params.Maker, "maker", validate.CheckEthereumAddressRequired, validationErrors)
+	validationErrors = append(validationErrors, validateOrderData(params.Order, "order")...)
+	validationErrors = validate.Parameter(params.OrderHash, "orderHash", validate.CheckOrderHash, validationErrors)
+	// Only the maker of an order can cancel it
+	if params.Maker != "" && params.Order.Maker != "" && !strings.EqualFold(params.Maker, params.Order.Maker) {
+		validationErrors = append(validationErrors, validate.NewParameterCustomError("maker does not match the maker of the order"))
+	}
+	return validate.ConsolidateValidationErorrs(validationErrors)
+}
+
+type CancelAllOrdersParams struct {
+	ChainId        int
+	PrivateKey     string
+	Maker          string
+	NonceIncrement uint8 // Optional, how much the series nonce of the maker is raised by (defaults to 1)
+	WaitOptions    onchain.WaitOptions
+	SkipWarnings   bool
+}
+
+func (params *CancelAllOrdersParams) Validate() error {
+	var validationErrors []error
+	validationErrors = validate.Parameter(params.ChainId, "chainId", validate.CheckChainIdRequired, validationErrors)
+	validationErrors = validate.Parameter(params.PrivateKey, "privateKey", validate.CheckPrivateKeyRequired, validationErrors)
+	validationErrors = validate.Parameter(params.Maker, "maker", validate.CheckEthereumAddressRequired, validationErrors)
+	return validate.ConsolidateValidationErorrs(validationErrors)
+}
+
+type FillOrderParams struct {
+	ChainId      int
+	PrivateKey   string
+	Taker        string
+	Order        OrderResponse // The order to fill, as returned by GetAllOrders
+	MakingAmount string        // Amount of the maker asset to receive, only one of MakingAmount and TakingAmount can be set
+	TakingAmount string        // Amount of the taker asset to pay, only one of MakingAmount and TakingAmount can be set
+	// Threshold is the largest amount of the taker asset to pay for MakingAmount, or the smallest amount of the maker asset
+	// to receive for TakingAmount
+	Threshold      string
+	Receiver       string // Optional, receives the maker asset instead of the taker
+	ApprovalType   onchain.ApprovalType
+	ApprovalPolicy onchain.ApprovalPolicy
+	PermitDeadline onchain.Deadline // Optional, defaults to the permit deadline of the client
+	WaitOptions    onchain.WaitOptions
+	SkipWarnings   bool
+}
+
+func (params *FillOrderParams) Validate() error {
+	var validationErrors []error
+	validationErrors = validate.Parameter(params.ChainId, "chainId", validate.CheckChainIdRequired, validationErrors)
+	validationErrors = validate.Parameter(params.PrivateKey, "privateKey", validate.CheckPrivateKeyRequired, validationErrors)
+	validationErrors = validate.Parameter(params.Taker, "taker", validate.CheckEthereumAddressRequired, validationErrors)
+	if params.Order.Signature == "" {
+		validationErrors = append(validationErrors, validate.NewParameterMissingError("order.signature"))
+	}
+	validationErrors = append(validationErrors, validateOrderData(params.Order.Data, "order.data")...)
+	validationErrors = validate.Parameter(params.MakingAmount, "makingAmount", validate.CheckBigInt, validationErrors)
+	validationErrors = validate.Parameter(params.TakingAmount, "takingAmount", validate.CheckBigInt, validationErrors)
+	if (params.MakingAmount == "") == (params.TakingAmount == "") {
+		validationErrors = append(validationErrors, validate.NewParameterCustomError("exactly one of makingAmount and takingAmount must be set"))
+	}
+	validationErrors = validate.Parameter(params.Threshold, "threshold", validate.CheckBigIntRequired, validationErrors)
+	validationErrors = validate.Parameter(params.Receiver, "receiver", validate.CheckEthereumAddress, validationErrors)
+	// Private orders can only be filled by the sender they allow
+	allowedSender := params.Order.Data.AllowedSender
+	if params.Taker != "" && allowedSender != "" && allowedSender != addresses.Zero && !strings.EqualFold(allowedSender, params.Taker) {
+		validationErrors = append(validationErrors, validate.NewParameterCustomError("order can only be filled by "+allowedSender))
+	}
+	if params.Order.Data.TakerAsset == tokens.NativeToken {
+		validationErrors = append(validationErrors, validate.NewParameterCustomError("native gas token is not supported as taker asset"))
+	}
+	if err := params.PermitDeadline.Validate(); err != nil {
+		validationErrors = append(validationErrors, validate.NewParameterValidationError("permitDeadline", err.Error()))
+	}
+	if err := params.ApprovalPolicy.Validate(); err != nil {
+		validationErrors = append(validationErrors, validate.NewParameterCustomError(err.Error()))
+	}
+	return validate.ConsolidateValidationErorrs(validationErrors)
+}
+
+// validateOrderData checks the fields of a signed order that are needed to send it back to the router
+func validateOrderData(order OrderData, prefix string) []error {
+	var validationErrors []error
+	validationErrors = validate.Parameter(order.Salt, prefix+".salt", validate.CheckBigIntRequired, validationErrors)
+	validationErrors = validate.Parameter(order.MakerAsset, prefix+".makerAsset", validate.CheckEthereumAddressRequired, validationErrors)
+	validationErrors = validate.Parameter(order.TakerAsset, prefix+".takerAsset", validate.CheckEthereumAddressRequired, validationErrors)
+	validationErrors = validate.Parameter(order.Maker, prefix+".maker", validate.CheckEthereumAddressRequired, validationErrors)
+	validationErrors = validate.Parameter(order.Receiver, prefix+".receiver", validate.CheckEthereumAddress, validationErrors)
+	validationErrors = validate.Parameter(order.AllowedSender, prefix+".allowedSender", validate.CheckEthereumAddress, validationErrors)
+	validationErrors = validate.Parameter(order.MakingAmount, prefix+".makingAmount", validate.CheckBigIntRequired, validationErrors)
+	validationErrors = validate.Parameter(order.TakingAmount, prefix+".takingAmount", validate.CheckBigIntRequired, validationErrors)
+	validationErrors = validate.Parameter(order.Offsets, prefix+".offsets", validate.CheckBigIntRequired, validationErrors)
+	return validationErrors
+}
@@ -1,34 +1,49 @@
 package models
 
-import "time"
+import (
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
 
 type CreateOrderResponse struct {
 	Success bool `json:"success"`
 }
 
 type OrderResponse struct {
-	Signature            string    `json:"signature"`
-	OrderHash            string    `json:"orderHash"`
-	CreateDateTime       time.Time `json:"createDateTime"`
-	RemainingMakerAmount string    `json:"remainingMakerAmount"`
-	MakerBalance         string    `json:"makerBalance"`
-	MakerAllowance       string    `json:"makerAllowance"`
-	Data                 struct {
-		MakerAsset    string `json:"makerAsset"`
-		TakerAsset    string `json:"takerAsset"`
-		Salt          string `json:"salt"`
-		Receiver      string `json:"receiver"`
-		AllowedSender string `json:"allowedSender"`
-		MakingAmount  string `json:"makingAmount"`
-		TakingAmount  string `json:"takingAmount"`
-		Maker         string `json:"maker"`
-		Interactions  string `json:"interactions"`
-		Offsets       string `json:"offsets"`
-	} `json:"data"`
-	MakerRate          string      `json:"makerRate"`
-	TakerRate          string      `json:"takerRate"`
-	IsMakerContract    bool        `json:"isMakerContract"`
-	OrderInvalidReason interface{} `json:"orderInvalidReason"`
+	Signature            string      `json:"signature"`
+	OrderHash            string      `json:"orderHash"`
+	CreateDateTime       time.Time   `json:"createDateTime"`
+	RemainingMakerAmount string      `json:"remainingMakerAmount"`
+	MakerBalance         string      `json:"makerBalance"`
+	MakerAllowance       string      `json:"makerAllowance"`
+	Data                 OrderData   `json:"data"`
+	MakerRate            string      `json:"makerRate"`
+	TakerRate            string      `json:"takerRate"`
+	IsMakerContract      bool        `json:"isMakerContract"`
+	OrderInvalidReason   interface{} `json:"orderInvalidReason"`
+}
+
+// CancelResult is the transaction that canceled one or every limit order of a maker
+type CancelResult struct {
+	TxHash  string
+	Receipt *types.Receipt
+	// Nonce is the series nonce of the maker once every order is canceled, it is only set by CancelAllOrders
+	Nonce *big.Int
+}
+
+// FillResult is the outcome of a fill, the amounts are the ones the fill transaction returned in its simulation right before
+// it was sent
+type FillResult struct {
+	ApprovalTxHash string // Empty when the router already had enough allowance
+	UsedPermit     bool   // Set when the taker asset was spent through a permit executed within the fill transaction
+	Method         string // Router method used for the fill
+	TxHash         string
+	Receipt        *types.Receipt
+	OrderHash      string
+	MakingAmount   *big.Int // Amount of the maker asset received
+	TakingAmount   *big.Int // Amount of the taker asset paid
 }
 
 type CountResponse struct {
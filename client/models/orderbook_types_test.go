@@ -1,16 +1,25 @@
 package models
 
 import (
+	"context"
 	"fmt"
+	"math/big"
 	"testing"
+	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
 
 	"github.com/1inch/1inch-sdk-go/helpers/consts/chains"
 	"github.com/1inch/1inch-sdk-go/helpers/consts/tokens"
+	"github.com/1inch/1inch-sdk-go/internal/onchain"
 	"github.com/1inch/1inch-sdk-go/internal/validate"
 )
 
+func testContractSigner(ctx context.Context, orderHash common.Hash, orderData OrderData) ([]byte, error) {
+	return nil, nil
+}
+
 func TestCreateOrderParams_Validate(t *testing.T) {
 	testCases := []struct {
 		description  string
@@ -42,6 +51,52 @@ func TestCreateOrderParams_Validate(t *testing.T) {
 				"'makingAmount' is required",
 			},
 		},
+		{
+			description: "Valid parameters - expiry as a duration",
+			params: CreateOrderParams{
+				ChainId:      chains.Ethereum,
+				PrivateKey:   "a0b1c2d3e4f5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b1",
+				Maker:        "0x1234567890abcdef1234567890abcdef12345678",
+				MakerAsset:   "0x1234567890abcdef1234567890abcdef12345678",
+				TakerAsset:   "0x1234567890abcdef1234567890abcdef12345679",
+				TakingAmount: "1000000000000000000",
+				MakingAmount: "2000000000000000000",
+				Expiry:       onchain.DeadlineAfter(24 * time.Hour),
+			},
+		},
+		{
+			description: "Error - expiry in the past",
+			params: CreateOrderParams{
+				ChainId:      chains.Ethereum,
+				PrivateKey:   "a0b1c2d3e4f5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b1",
+				Maker:        "0x1234567890abcdef1234567890abcdef12345678",
+				MakerAsset:   "0x1234567890abcdef1234567890abcdef12345678",
+				TakerAsset:   "0x1234567890abcdef1234567890abcdef12345679",
+				TakingAmount: "1000000000000000000",
+				MakingAmount: "2000000000000000000",
+				Expiry:       onchain.DeadlineAt(time.Now().Add(-time.Hour)),
+			},
+			expectErrors: []string{
+				"deadline must be in the future",
+			},
+		},
+		{
+			description: "Error - expireAfter and expiry used together",
+			params: CreateOrderParams{
+				ChainId:      chains.Ethereum,
+				PrivateKey:   "a0b1c2d3e4f5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b1",
+				Maker:        "0x1234567890abcdef1234567890abcdef12345678",
+				MakerAsset:   "0x1234567890abcdef1234567890abcdef12345678",
+				TakerAsset:   "0x1234567890abcdef1234567890abcdef12345679",
+				TakingAmount: "1000000000000000000",
+				MakingAmount: "2000000000000000000",
+				ExpireAfter:  time.Now().Add(time.Hour).Unix(),
+				Expiry:       onchain.DeadlineAfter(time.Hour),
+			},
+			expectErrors: []string{
+				"expireAfter and expiry cannot be used together",
+			},
+		},
 		{
 			description: "Error - MakerAsset is native token",
 			params: CreateOrderParams{
@@ -72,6 +127,134 @@ func TestCreateOrderParams_Validate(t *testing.T) {
 				"native gas token is not supported as maker or taker asset",
 			},
 		},
+		{
+			description: "Native MakerAsset with auto wrap",
+			params: CreateOrderParams{
+				ChainId:        chains.Ethereum,
+				PrivateKey:     "a0b1c2d3e4f5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b1",
+				Maker:          "0x1234567890abcdef1234567890abcdef12345678",
+				MakerAsset:     tokens.NativeToken,
+				TakerAsset:     tokens.EthereumUsdc,
+				TakingAmount:   "1000000000000000000",
+				MakingAmount:   "2000000000000000000",
+				AutoWrapNative: true,
+			},
+		},
+		{
+			description: "Error - native MakerAsset with auto wrap for the wrapped native TakerAsset",
+			params: CreateOrderParams{
+				ChainId:        chains.Ethereum,
+				PrivateKey:     "a0b1c2d3e4f5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b1",
+				Maker:          "0x1234567890abcdef1234567890abcdef12345678",
+				MakerAsset:     tokens.NativeToken,
+				TakerAsset:     tokens.EthereumWeth,
+				TakingAmount:   "1000000000000000000",
+				MakingAmount:   "2000000000000000000",
+				AutoWrapNative: true,
+			},
+			expectErrors: []string{
+				"maker asset and taker asset cannot be the same",
+			},
+		},
+		{
+			description: "Error - native MakerAsset with auto wrap on a chain without a known wrapped native token",
+			params: CreateOrderParams{
+				ChainId:        chains.Klaytn,
+				PrivateKey:     "a0b1c2d3e4f5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b1",
+				Maker:          "0x1234567890abcdef1234567890abcdef12345678",
+				MakerAsset:     tokens.NativeToken,
+				TakerAsset:     tokens.EthereumUsdc,
+				TakingAmount:   "1000000000000000000",
+				MakingAmount:   "2000000000000000000",
+				AutoWrapNative: true,
+			},
+			expectErrors: []string{
+				"no wrapped native token known for chain id: 8217",
+			},
+		},
+		{
+			description: "Contract maker without a private key",
+			params: CreateOrderParams{
+				ChainId:        chains.Ethereum,
+				Maker:          "0x1234567890abcdef1234567890abcdef12345678",
+				MakerAsset:     tokens.EthereumUsdc,
+				TakerAsset:     tokens.EthereumDai,
+				TakingAmount:   "1000000000000000000",
+				MakingAmount:   "2000000000000000000",
+				ContractSigner: testContractSigner,
+			},
+		},
+		{
+			description: "Error - contract maker with a private key and onchain actions",
+			params: CreateOrderParams{
+				ChainId:                        chains.Ethereum,
+				PrivateKey:                     "a0b1c2d3e4f5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b1",
+				Maker:                          "0x1234567890abcdef1234567890abcdef12345678",
+				MakerAsset:                     tokens.EthereumUsdc,
+				TakerAsset:                     tokens.EthereumDai,
+				TakingAmount:                   "1000000000000000000",
+				MakingAmount:                   "2000000000000000000",
+				ApprovalType:                   onchain.PermitAlways,
+				EnableOnchainApprovalsIfNeeded: true,
+				ContractSigner:                 testContractSigner,
+			},
+			expectErrors: []string{
+				"privateKey and contractSigner cannot be used together",
+				"contract makers cannot wrap native tokens or approve tokens from the SDK",
+				"contract makers cannot sign permits",
+			},
+		},
+		{
+			description: "Error - approval cap is missing",
+			params: CreateOrderParams{
+				ChainId:        chains.Ethereum,
+				PrivateKey:     "a0b1c2d3e4f5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b1",
+				Maker:          "0x1234567890abcdef1234567890abcdef12345678",
+				MakerAsset:     tokens.EthereumUsdc,
+				TakerAsset:     tokens.EthereumDai,
+				TakingAmount:   "1000000000000000000",
+				MakingAmount:   "2000000000000000000",
+				ApprovalPolicy: onchain.ApprovalPolicy{Type: onchain.ApprovalCap},
+			},
+			expectErrors: []string{
+				"approval cap must be a positive amount",
+			},
+		},
+		{
+			description: "Dutch auction pricing",
+			params: CreateOrderParams{
+				ChainId:      chains.Ethereum,
+				PrivateKey:   "a0b1c2d3e4f5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b1",
+				Maker:        "0x1234567890abcdef1234567890abcdef12345678",
+				MakerAsset:   tokens.EthereumUsdc,
+				TakerAsset:   tokens.EthereumDai,
+				TakingAmount: "1000000000000000000",
+				MakingAmount: "2000000",
+				Pricing: onchain.DutchAuction{
+					Calculator:        common.HexToAddress("0x1234567890abcdef1234567890abcdef12345670"),
+					StartTime:         time.Now().Unix(),
+					EndTime:           time.Now().Add(time.Hour).Unix(),
+					TakingAmountStart: big.NewInt(2e18),
+					TakingAmountEnd:   big.NewInt(1e18),
+				},
+			},
+		},
+		{
+			description: "Error - range pricing without a calculator",
+			params: CreateOrderParams{
+				ChainId:      chains.Ethereum,
+				PrivateKey:   "a0b1c2d3e4f5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b1",
+				Maker:        "0x1234567890abcdef1234567890abcdef12345678",
+				MakerAsset:   tokens.EthereumUsdc,
+				TakerAsset:   tokens.EthereumDai,
+				TakingAmount: "1000000000000000000",
+				MakingAmount: "2000000",
+				Pricing:      onchain.RangePricing{PriceStart: big.NewInt(1), PriceEnd: big.NewInt(2)},
+			},
+			expectErrors: []string{
+				"calculator is required",
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -370,3 +553,211 @@ func TestGetActiveOrdersWithPermitParams_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestCancelOrderParams_Validate(t *testing.T) {
+	order := OrderData{
+		MakerAsset:    tokens.PolygonWeth,
+		TakerAsset:    tokens.PolygonDai,
+		MakingAmount:  "1000000000000000000",
+		TakingAmount:  "3000000000000000000000",
+		Salt:          "1700000000000",
+		Maker:         "0x1234567890abcdef1234567890abcdef12345678",
+		AllowedSender: "0x0000000000000000000000000000000000000000",
+		Receiver:      "0x0000000000000000000000000000000000000000",
+		Offsets:       "0",
+		Interactions:  "0x",
+	}
+
+	testCases := []struct {
+		description  string
+		params       CancelOrderParams
+		expectErrors []string
+	}{
+		{
+			description: "Valid parameters",
+			params: CancelOrderParams{
+				ChainId:    chains.Polygon,
+				PrivateKey: "a0b1c2d3e4f5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b1",
+				Maker:      "0x1234567890ABCDEF1234567890abcdef12345678",
+				Order:      order,
+			},
+		},
+		{
+			description: "Missing required parameters",
+			params:      CancelOrderParams{},
+			expectErrors: []string{
+				"'chainId' is required",
+				"'privateKey' is required",
+				"'maker' is required",
+				"'order.salt' is required",
+				"'order.makerAsset' is required",
+				"'order.takerAsset' is required",
+				"'order.maker' is required",
+				"'order.makingAmount' is required",
+				"'order.takingAmount' is required",
+				"'order.offsets' is required",
+			},
+		},
+		{
+			description: "Maker of another order",
+			params: CancelOrderParams{
+				ChainId:    chains.Polygon,
+				PrivateKey: "a0b1c2d3e4f5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b1",
+				Maker:      "0x1234567890abcdef1234567890abcdef12345679",
+				Order:      order,
+			},
+			expectErrors: []string{
+				"maker does not match the maker of the order",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := tc.params.Validate()
+
+			if len(tc.expectErrors) > 0 {
+				require.Error(t, err)
+				for _, expectedError := range tc.expectErrors {
+					require.Contains(t, err.Error(), expectedError, "Error message should contain the expected text")
+				}
+				require.Equal(t, len(tc.expectErrors), validate.GetValidatorErrorsCount(err), "The number of errors returned should match the length of the expected errors: %s\n", err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCancelAllOrdersParams_Validate(t *testing.T) {
+	testCases := []struct {
+		description  string
+		params       CancelAllOrdersParams
+		expectErrors []string
+	}{
+		{
+			description: "Valid parameters",
+			params: CancelAllOrdersParams{
+				ChainId:    chains.Polygon,
+				PrivateKey: "a0b1c2d3e4f5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b1",
+				Maker:      "0x1234567890abcdef1234567890abcdef12345678",
+			},
+		},
+		{
+			description: "Missing required parameters",
+			params:      CancelAllOrdersParams{},
+			expectErrors: []string{
+				"'chainId' is required",
+				"'privateKey' is required",
+				"'maker' is required",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := tc.params.Validate()
+
+			if len(tc.expectErrors) > 0 {
+				require.Error(t, err)
+				for _, expectedError := range tc.expectErrors {
+					require.Contains(t, err.Error(), expectedError, "Error message should contain the expected text")
+				}
+				require.Equal(t, len(tc.expectErrors), validate.GetValidatorErrorsCount(err), "The number of errors returned should match the length of the expected errors")
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestFillOrderParams_Validate(t *testing.T) {
+	order := OrderResponse{
+		Signature: "0x1234",
+		Data: OrderData{
+			MakerAsset:    tokens.PolygonWeth,
+			TakerAsset:    tokens.PolygonDai,
+			MakingAmount:  "1000000000000000000",
+			TakingAmount:  "3000000000000000000000",
+			Salt:          "1700000000000",
+			Maker:         "0x1111111111111111111111111111111111111111",
+			AllowedSender: "0x0000000000000000000000000000000000000000",
+			Receiver:      "0x0000000000000000000000000000000000000000",
+			Offsets:       "0",
+			Interactions:  "0x",
+		},
+	}
+	privateOrder := order
+	privateOrder.Data.AllowedSender = "0x2222222222222222222222222222222222222222"
+
+	testCases := []struct {
+		description  string
+		params       FillOrderParams
+		expectErrors []string
+	}{
+		{
+			description: "Valid parameters",
+			params: FillOrderParams{
+				ChainId:      chains.Polygon,
+				PrivateKey:   "a0b1c2d3e4f5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b1",
+				Taker:        "0x1234567890abcdef1234567890abcdef12345678",
+				Order:        order,
+				MakingAmount: "500000000000000000",
+				Threshold:    "1600000000000000000000",
+			},
+		},
+		{
+			description: "Missing required parameters",
+			params:      FillOrderParams{},
+			expectErrors: []string{
+				"'chainId' is required",
+				"'privateKey' is required",
+				"'taker' is required",
+				"'order.signature' is required",
+				"'order.data.salt' is required",
+				"'order.data.makerAsset' is required",
+				"'order.data.takerAsset' is required",
+				"'order.data.maker' is required",
+				"'order.data.makingAmount' is required",
+				"'order.data.takingAmount' is required",
+				"'order.data.offsets' is required",
+				"exactly one of makingAmount and takingAmount must be set",
+				"'threshold' is required",
+			},
+		},
+		{
+			description: "Both amounts for a private order of another taker",
+			params: FillOrderParams{
+				ChainId:      chains.Polygon,
+				PrivateKey:   "a0b1c2d3e4f5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b1",
+				Taker:        "0x1234567890abcdef1234567890abcdef12345678",
+				Order:        privateOrder,
+				MakingAmount: "500000000000000000",
+				TakingAmount: "1500000000000000000000",
+				Threshold:    "1600000000000000000000",
+				Receiver:     "0x1234",
+			},
+			expectErrors: []string{
+				"exactly one of makingAmount and takingAmount must be set",
+				"receiver",
+				"order can only be filled by 0x2222222222222222222222222222222222222222",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := tc.params.Validate()
+
+			if len(tc.expectErrors) > 0 {
+				require.Error(t, err)
+				for _, expectedError := range tc.expectErrors {
+					require.Contains(t, err.Error(), expectedError, "Error message should contain the expected text")
+				}
+				require.Equal(t, len(tc.expectErrors), validate.GetValidatorErrorsCount(err), "The number of errors returned should match the length of the expected errors: %s\n", err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+type PolicyRule string
+
+const (
+	PolicyRuleChain            PolicyRule = "chain"
+	PolicyRuleAllowedTokens    PolicyRule = "allowedTokens"
+	PolicyRuleDeniedTokens     PolicyRule = "deniedTokens"
+	PolicyRuleMaxTradeUsd      PolicyRule = "maxTradeUsd"
+	PolicyRuleMaxDailyUsd      PolicyRule = "maxDailyUsd"
+	PolicyRuleMaxSlippage      PolicyRule = "maxSlippage"
+	PolicyRuleAllowedReceivers PolicyRule = "allowedReceivers"
+	PolicyRuleApprovalCaps     PolicyRule = "approvalCaps"
+)
+
+// PolicyViolation is a rule of a policy that an action breaks and the reason why
+type PolicyViolation struct {
+	Rule   PolicyRule
+	Reason string
+}
+
+// PolicyError is returned when a policy denies an action, it lists every rule the action breaks
+type PolicyError struct {
+	Kind       SummaryKind
+	Violations []PolicyViolation
+}
+
+func (e *PolicyError) Error() string {
+	reasons := make([]string, 0, len(e.Violations))
+	for _, violation := range e.Violations {
+		reasons = append(reasons, fmt.Sprintf("%s (%s)", violation.Reason, violation.Rule))
+	}
+	return fmt.Sprintf("%s denied by policy: %s", e.Kind, strings.Join(reasons, "; "))
+}
@@ -0,0 +1,137 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/1inch/1inch-sdk-go/internal/validate"
+)
+
+// Policy is a set of rules that swaps, approvals, wraps, limit orders and fills must pass before they are executed
+// It is evaluated even when the params of an action set SkipWarnings, actions on chains without rules are denied
+type Policy struct {
+	Chains map[int]ChainPolicy `json:"chains" yaml:"chains"`
+}
+
+// ChainPolicy holds the rules of a single chain, rules left empty do not restrict anything unless stated otherwise
+type ChainPolicy struct {
+	// AllowedTokens lists the only tokens that can be sold, bought or approved (the native token uses 0xEeeeeEeeeEeEeeEeEeEeeEEEeeeeEeeeeeeeEEeE)
+	AllowedTokens []string `json:"allowedTokens,omitempty" yaml:"allowedTokens,omitempty"`
+	// DeniedTokens lists tokens that can never be sold, bought or approved
+	DeniedTokens []string `json:"deniedTokens,omitempty" yaml:"deniedTokens,omitempty"`
+	// MaxTradeUsd is the largest USD value a single swap, limit order or fill can sell
+	MaxTradeUsd float64 `json:"maxTradeUsd,omitempty" yaml:"maxTradeUsd,omitempty"`
+	// MaxDailyUsd is the largest USD value the swaps, limit orders and fills of the chain can sell over a rolling 24 hours
+	// A trade holds its value while it executes and only keeps it once its transaction may have gone through, or once the
+	// orderbook accepted a limit order
+	MaxDailyUsd float64 `json:"maxDailyUsd,omitempty" yaml:"maxDailyUsd,omitempty"`
+	// MaxSlippage is the largest slippage a swap can use, in percent
+	MaxSlippage float32 `json:"maxSlippage,omitempty" yaml:"maxSlippage,omitempty"`
+	// AllowedReceivers lists the addresses other than the wallet itself that can receive the output of a trade
+	// When empty, only the wallet can receive it
+	AllowedReceivers []string `json:"allowedReceivers,omitempty" yaml:"allowedReceivers,omitempty"`
+	// ApprovalCaps maps token addresses to the largest allowance that can be granted for them, in the base units of the token
+	ApprovalCaps map[string]string `json:"approvalCaps,omitempty" yaml:"approvalCaps,omitempty"`
+	// AllowUnlimitedApprovals permits unlimited approvals of tokens without an approval cap
+	AllowUnlimitedApprovals bool `json:"allowUnlimitedApprovals,omitempty" yaml:"allowUnlimitedApprovals,omitempty"`
+}
+
+func (p *Policy) Validate() error {
+	var validationErrors []error
+	if len(p.Chains) == 0 {
+		validationErrors = append(validationErrors, validate.NewParameterMissingError("chains"))
+	}
+	// Chains and tokens are sorted so the errors are always reported in the same order
+	chainIds := make([]int, 0, len(p.Chains))
+	for chainId := range p.Chains {
+		chainIds = append(chainIds, chainId)
+	}
+	sort.Ints(chainIds)
+	for _, chainId := range chainIds {
+		rules := p.Chains[chainId]
+		prefix := fmt.Sprintf("chains.%d", chainId)
+		validationErrors = validate.Parameter(chainId, prefix, validate.CheckChainIdRequired, validationErrors)
+		for _, token := range rules.AllowedTokens {
+			validationErrors = validate.Parameter(token, prefix+".allowedTokens", validate.CheckEthereumAddressRequired, validationErrors)
+		}
+		for _, token := range rules.DeniedTokens {
+			validationErrors = validate.Parameter(token, prefix+".deniedTokens", validate.CheckEthereumAddressRequired, validationErrors)
+		}
+		for _, receiver := range rules.AllowedReceivers {
+			validationErrors = validate.Parameter(receiver, prefix+".allowedReceivers", validate.CheckEthereumAddressRequired, validationErrors)
+		}
+		cappedTokens := make([]string, 0, len(rules.ApprovalCaps))
+		for token := range rules.ApprovalCaps {
+			cappedTokens = append(cappedTokens, token)
+		}
+		sort.Strings(cappedTokens)
+		for _, token := range cappedTokens {
+			approvalCap := rules.ApprovalCaps[token]
+			validationErrors = validate.Parameter(token, prefix+".approvalCaps", validate.CheckEthereumAddressRequired, validationErrors)
+			validationErrors = validate.Parameter(approvalCap, prefix+".approvalCaps."+token, validate.CheckBigIntRequired, validationErrors)
+		}
+		if rules.MaxTradeUsd < 0 {
+			validationErrors = append(validationErrors, validate.NewParameterCustomError(prefix+".maxTradeUsd cannot be negative"))
+		}
+		if rules.MaxDailyUsd < 0 {
+			validationErrors = append(validationErrors, validate.NewParameterCustomError(prefix+".maxDailyUsd cannot be negative"))
+		}
+		validationErrors = validate.Parameter(rules.MaxSlippage, prefix+".maxSlippage", validate.CheckSlippage, validationErrors)
+	}
+	return validate.ConsolidateValidationErorrs(validationErrors)
+}
+
+// ParsePolicyJson reads a policy from JSON, fields that are not part of a policy are refused
+func ParsePolicyJson(data []byte) (*Policy, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	var policy Policy
+	err := decoder.Decode(&policy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse policy: %v", err)
+	}
+	err = policy.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid policy: %v", err)
+	}
+	return &policy, nil
+}
+
+// ParsePolicyYaml reads a policy from YAML, fields that are not part of a policy are refused
+func ParsePolicyYaml(data []byte) (*Policy, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	var policy Policy
+	err := decoder.Decode(&policy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse policy: %v", err)
+	}
+	err = policy.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid policy: %v", err)
+	}
+	return &policy, nil
+}
+
+// LoadPolicyFile reads a policy from a .json, .yaml or .yml file
+func LoadPolicyFile(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy file: %v", err)
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return ParsePolicyJson(data)
+	case ".yaml", ".yml":
+		return ParsePolicyYaml(data)
+	default:
+		return nil, fmt.Errorf("unsupported policy file extension: %s", filepath.Ext(path))
+	}
+}
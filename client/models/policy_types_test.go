@@ -0,0 +1,167 @@
+package models
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/1inch/1inch-sdk-go/helpers/consts/chains"
+	"github.com/1inch/1inch-sdk-go/internal/validate"
+)
+
+var testPolicy = Policy{
+	Chains: map[int]ChainPolicy{
+		chains.Polygon: {
+			AllowedTokens:    []string{"0x3c499c542cef5e3811e1192ce70d8cc03d5c3359", "0xEeeeeEeeeEeEeeEeEeEeeEEEeeeeEeeeeeeeEEeE"},
+			DeniedTokens:     []string{"0x0000000000000000000000000000000000001010"},
+			MaxTradeUsd:      1000,
+			MaxDailyUsd:      5000,
+			MaxSlippage:      1,
+			AllowedReceivers: []string{"0x1234567890abcdef1234567890abcdef12345678"},
+			ApprovalCaps:     map[string]string{"0x3c499c542cef5e3811e1192ce70d8cc03d5c3359": "1000000000"},
+		},
+	},
+}
+
+func TestPolicy_Validate(t *testing.T) {
+	testCases := []struct {
+		description  string
+		policy       Policy
+		expectErrors []string
+	}{
+		{
+			description: "Valid policy",
+			policy:      testPolicy,
+		},
+		{
+			description: "Missing chains",
+			policy:      Policy{},
+			expectErrors: []string{
+				"'chains' is required",
+			},
+		},
+		{
+			description: "Invalid rules",
+			policy: Policy{
+				Chains: map[int]ChainPolicy{
+					5: {},
+					chains.Polygon: {
+						AllowedTokens:    []string{"usdc"},
+						DeniedTokens:     []string{""},
+						MaxTradeUsd:      -1,
+						MaxDailyUsd:      -1,
+						MaxSlippage:      60,
+						AllowedReceivers: []string{"0x1234"},
+						ApprovalCaps:     map[string]string{"0x3c499c542cef5e3811e1192ce70d8cc03d5c3359": "1.5"},
+					},
+				},
+			},
+			expectErrors: []string{
+				"chains.5",
+				"chains.137.allowedTokens",
+				"chains.137.deniedTokens",
+				"chains.137.allowedReceivers",
+				"chains.137.approvalCaps.0x3c499c542cef5e3811e1192ce70d8cc03d5c3359",
+				"chains.137.maxTradeUsd cannot be negative",
+				"chains.137.maxDailyUsd cannot be negative",
+				"chains.137.maxSlippage",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := tc.policy.Validate()
+
+			if len(tc.expectErrors) > 0 {
+				require.Error(t, err)
+				for _, expectedError := range tc.expectErrors {
+					require.Contains(t, err.Error(), expectedError, "Error message should contain the expected text")
+				}
+				require.Equal(t, len(tc.expectErrors), validate.GetValidatorErrorsCount(err), "The number of errors returned should match the length of the expected errors: %s\n", err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestLoadPolicyFile(t *testing.T) {
+	testCases := []struct {
+		description   string
+		fileName      string
+		content       string
+		expectedError string
+	}{
+		{
+			description: "JSON",
+			fileName:    "policy.json",
+			content: `{"chains": {"137": {
+				"allowedTokens": ["0x3c499c542cef5e3811e1192ce70d8cc03d5c3359", "0xEeeeeEeeeEeEeeEeEeEeeEEEeeeeEeeeeeeeEEeE"],
+				"deniedTokens": ["0x0000000000000000000000000000000000001010"],
+				"maxTradeUsd": 1000,
+				"maxDailyUsd": 5000,
+				"maxSlippage": 1,
+				"allowedReceivers": ["0x1234567890abcdef1234567890abcdef12345678"],
+				"approvalCaps": {"0x3c499c542cef5e3811e1192ce70d8cc03d5c3359": "1000000000"}
+			}}}`,
+		},
+		{
+			description: "YAML",
+			fileName:    "policy.yaml",
+			content: `chains:
+  137:
+    allowedTokens:
+      - "0x3c499c542cef5e3811e1192ce70d8cc03d5c3359"
+      - "0xEeeeeEeeeEeEeeEeEeEeeEEEeeeeEeeeeeeeEEeE"
+    deniedTokens: ["0x0000000000000000000000000000000000001010"]
+    maxTradeUsd: 1000
+    maxDailyUsd: 5000
+    maxSlippage: 1
+    allowedReceivers: ["0x1234567890abcdef1234567890abcdef12345678"]
+    approvalCaps:
+      "0x3c499c542cef5e3811e1192ce70d8cc03d5c3359": "1000000000"
+`,
+		},
+		{
+			description:   "Unknown JSON field",
+			fileName:      "policy.json",
+			content:       `{"chains": {"137": {"maxTrade": 1000}}}`,
+			expectedError: `failed to parse policy: json: unknown field "maxTrade"`,
+		},
+		{
+			description:   "Unknown YAML field",
+			fileName:      "policy.yml",
+			content:       "chains:\n  137:\n    maxSlipage: 1\n",
+			expectedError: "field maxSlipage not found",
+		},
+		{
+			description:   "Invalid rules",
+			fileName:      "policy.yml",
+			content:       "chains:\n  137:\n    maxSlippage: 60\n",
+			expectedError: "invalid policy:",
+		},
+		{
+			description:   "Unsupported extension",
+			fileName:      "policy.toml",
+			expectedError: "unsupported policy file extension: .toml",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tc.fileName)
+			require.NoError(t, os.WriteFile(path, []byte(tc.content), 0600))
+
+			policy, err := LoadPolicyFile(path)
+			if tc.expectedError != "" {
+				require.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, testPolicy, *policy)
+		})
+	}
+}
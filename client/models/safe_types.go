@@ -0,0 +1,98 @@
+package models
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/1inch/1inch-sdk-go/internal/validate"
+)
+
+type BuildSafeTransactionParams struct {
+	ChainId     int
+	Safe        string
+	Description string // Optional, used as the name of the Transaction Builder batch
+	Calls       []SafeCall
+	Nonce       string // Optional, defaults to the current nonce of the Safe
+}
+
+func (params *BuildSafeTransactionParams) Validate() error {
+	var validationErrors []error
+	validationErrors = validate.Parameter(params.ChainId, "chainId", validate.CheckChainIdRequired, validationErrors)
+	validationErrors = validate.Parameter(params.Safe, "safe", validate.CheckEthereumAddressRequired, validationErrors)
+	validationErrors = validate.Parameter(params.Nonce, "nonce", validate.CheckBigInt, validationErrors)
+	validationErrors = append(validationErrors, validateSafeCalls(params.Calls)...)
+	return validate.ConsolidateValidationErorrs(validationErrors)
+}
+
+type BuildSafeSwapTransactionParams struct {
+	Swap  *ExecuteSwapConfig // Swap generated by GetSwap with the Safe as the sender
+	Nonce string             // Optional, defaults to the current nonce of the Safe
+}
+
+func (params *BuildSafeSwapTransactionParams) Validate() error {
+	var validationErrors []error
+	if params.Swap == nil {
+		validationErrors = append(validationErrors, validate.NewParameterMissingError("swap"))
+	} else {
+		validationErrors = validate.Parameter(params.Swap.PublicAddress, "swap.publicAddress", validate.CheckEthereumAddressRequired, validationErrors)
+	}
+	validationErrors = validate.Parameter(params.Nonce, "nonce", validate.CheckBigInt, validationErrors)
+	return validate.ConsolidateValidationErorrs(validationErrors)
+}
+
+type BuildSafeApprovalTransactionParams struct {
+	ChainId int
+	Safe    string
+	Token   string
+	Spender string // Optional, defaults to the 1inch router of the chain, which also fills limit orders
+	Amount  string // Optional, defaults to an unlimited approval
+	Nonce   string // Optional, defaults to the current nonce of the Safe
+}
+
+func (params *BuildSafeApprovalTransactionParams) Validate() error {
+	var validationErrors []error
+	validationErrors = validate.Parameter(params.ChainId, "chainId", validate.CheckChainIdRequired, validationErrors)
+	validationErrors = validate.Parameter(params.Safe, "safe", validate.CheckEthereumAddressRequired, validationErrors)
+	validationErrors = validate.Parameter(params.Token, "token", validate.CheckEthereumAddressRequired, validationErrors)
+	validationErrors = validate.Parameter(params.Spender, "spender", validate.CheckEthereumAddress, validationErrors)
+	validationErrors = validate.Parameter(params.Amount, "amount", validate.CheckBigInt, validationErrors)
+	validationErrors = validate.Parameter(params.Nonce, "nonce", validate.CheckBigInt, validationErrors)
+	return validate.ConsolidateValidationErorrs(validationErrors)
+}
+
+type ProposeSafeTransactionParams struct {
+	TransactionServiceUrl string // Root of the Safe Transaction Service of the chain (e.g. https://safe-transaction-mainnet.safe.global)
+	Transaction           *SafeTransaction
+	OwnerKey              string // Private key of the owner of the Safe proposing the transaction
+	Origin                string // Optional, shown to the other owners as the source of the transaction
+}
+
+func (params *ProposeSafeTransactionParams) Validate() error {
+	var validationErrors []error
+	if params.TransactionServiceUrl == "" {
+		validationErrors = append(validationErrors, validate.NewParameterMissingError("transactionServiceUrl"))
+	}
+	if params.Transaction == nil {
+		validationErrors = append(validationErrors, validate.NewParameterMissingError("transaction"))
+	}
+	validationErrors = validate.Parameter(params.OwnerKey, "ownerKey", validate.CheckPrivateKeyRequired, validationErrors)
+	return validate.ConsolidateValidationErorrs(validationErrors)
+}
+
+func validateSafeCalls(calls []SafeCall) []error {
+	var validationErrors []error
+	if len(calls) == 0 {
+		validationErrors = append(validationErrors, validate.NewParameterMissingError("calls"))
+	}
+	for i, call := range calls {
+		validationErrors = validate.Parameter(call.To, fmt.Sprintf("calls[%d].to", i), validate.CheckEthereumAddressRequired, validationErrors)
+		validationErrors = validate.Parameter(call.Value, fmt.Sprintf("calls[%d].value", i), validate.CheckBigInt, validationErrors)
+		if call.Data != "" {
+			if _, err := hexutil.Decode(call.Data); err != nil {
+				validationErrors = append(validationErrors, validate.NewParameterValidationError(fmt.Sprintf("calls[%d].data", i), "not valid hex data"))
+			}
+		}
+	}
+	return validationErrors
+}
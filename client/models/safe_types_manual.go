@@ -0,0 +1,75 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/1inch/1inch-sdk-go/helpers"
+	"github.com/1inch/1inch-sdk-go/internal/safe"
+)
+
+// SafeCall is a single call made by a Safe
+type SafeCall struct {
+	Description string
+	To          string
+	Value       string // Optional, defaults to zero
+	Data        string // Hex encoded calldata
+}
+
+// SafeTransaction is a Safe transaction ready to be signed by the owners of the Safe
+// Several calls are batched into one delegate call to the MultiSendCallOnly contract, Calls keeps them individually
+type SafeTransaction struct {
+	Description string
+	ChainId     *big.Int
+	Safe        common.Address
+	Calls       []SafeCall
+	SafeTx      *safe.SafeTx
+	SafeTxHash  common.Hash // EIP-712 hash signed by the owners, computed locally
+}
+
+// TransactionBuilderJson returns the calls as a batch file for the Transaction Builder app of the Safe interface
+func (t *SafeTransaction) TransactionBuilderJson() ([]byte, error) {
+	calls, err := ToSafeCalls(t.Calls)
+	if err != nil {
+		return nil, err
+	}
+
+	batch := safe.NewTransactionBuilderBatch(t.ChainId, t.Safe, t.Description, "", time.Now().UnixMilli(), calls)
+	return json.MarshalIndent(batch, "", "  ")
+}
+
+// ToSafeCalls converts calls to the representation used to encode them
+func ToSafeCalls(calls []SafeCall) ([]safe.SafeCall, error) {
+	converted := make([]safe.SafeCall, 0, len(calls))
+	for i, call := range calls {
+		value := big.NewInt(0)
+		if call.Value != "" {
+			var err error
+			value, err = helpers.BigIntFromString(call.Value)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse value of call %d: %v", i+1, err)
+			}
+		}
+
+		var data []byte
+		if call.Data != "" {
+			var err error
+			data, err = hexutil.Decode(call.Data)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode data of call %d: %v", i+1, err)
+			}
+		}
+
+		converted = append(converted, safe.SafeCall{
+			To:    common.HexToAddress(call.To),
+			Value: value,
+			Data:  data,
+		})
+	}
+	return converted, nil
+}
@@ -0,0 +1,115 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/1inch/1inch-sdk-go/helpers/consts/chains"
+	"github.com/1inch/1inch-sdk-go/internal/validate"
+)
+
+func TestBuildSafeTransactionParams_Validate(t *testing.T) {
+	testCases := []struct {
+		description  string
+		params       BuildSafeTransactionParams
+		expectErrors []string
+	}{
+		{
+			description: "Valid parameters",
+			params: BuildSafeTransactionParams{
+				ChainId: chains.Ethereum,
+				Safe:    "0x1234567890abcdef1234567890abcdef12345678",
+				Calls: []SafeCall{
+					{To: "0x1234567890abcdef1234567890abcdef12345679", Data: "0x095ea7b3"},
+					{To: "0x1234567890abcdef1234567890abcdef1234567a", Value: "10"},
+				},
+				Nonce: "4",
+			},
+		},
+		{
+			description: "Missing required parameters",
+			params:      BuildSafeTransactionParams{},
+			expectErrors: []string{
+				"'chainId' is required",
+				"'safe' is required",
+				"'calls' is required",
+			},
+		},
+		{
+			description: "Invalid calls",
+			params: BuildSafeTransactionParams{
+				ChainId: chains.Ethereum,
+				Safe:    "0x1234567890abcdef1234567890abcdef12345678",
+				Calls: []SafeCall{
+					{To: "0x123", Value: "-", Data: "0xzz"},
+				},
+				Nonce: "-",
+			},
+			expectErrors: []string{
+				"'calls[0].to'",
+				"'calls[0].value'",
+				"'calls[0].data'",
+				"'nonce'",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := tc.params.Validate()
+
+			if len(tc.expectErrors) > 0 {
+				require.Error(t, err)
+				for _, expectedError := range tc.expectErrors {
+					require.Contains(t, err.Error(), expectedError, "Error message should contain the expected text")
+				}
+				require.Equal(t, len(tc.expectErrors), validate.GetValidatorErrorsCount(err), "The number of errors returned should match the length of the expected errors: %s\n", err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestProposeSafeTransactionParams_Validate(t *testing.T) {
+	testCases := []struct {
+		description  string
+		params       ProposeSafeTransactionParams
+		expectErrors []string
+	}{
+		{
+			description: "Valid parameters",
+			params: ProposeSafeTransactionParams{
+				TransactionServiceUrl: "https://safe-transaction-mainnet.safe.global",
+				Transaction:           &SafeTransaction{},
+				OwnerKey:              "4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318",
+			},
+		},
+		{
+			description: "Missing required parameters",
+			params:      ProposeSafeTransactionParams{},
+			expectErrors: []string{
+				"'transactionServiceUrl' is required",
+				"'transaction' is required",
+				"'ownerKey' is required",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := tc.params.Validate()
+
+			if len(tc.expectErrors) > 0 {
+				require.Error(t, err)
+				for _, expectedError := range tc.expectErrors {
+					require.Contains(t, err.Error(), expectedError, "Error message should contain the expected text")
+				}
+				require.Equal(t, len(tc.expectErrors), validate.GetValidatorErrorsCount(err), "The number of errors returned should match the length of the expected errors: %s\n", err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package models
+
+import "fmt"
+
+type SwapGuardViolation string
+
+const (
+	SwapGuardViolationRouter    SwapGuardViolation = "router"
+	SwapGuardViolationReceiver  SwapGuardViolation = "receiver"
+	SwapGuardViolationAmount    SwapGuardViolation = "amount"
+	SwapGuardViolationMinReturn SwapGuardViolation = "minReturn"
+	SwapGuardViolationValue     SwapGuardViolation = "value"
+)
+
+// SwapGuardError is returned when swap transaction data does not match what was requested and is refused before signing
+type SwapGuardError struct {
+	Violation SwapGuardViolation
+	Expected  string
+	Actual    string
+}
+
+func (e *SwapGuardError) Error() string {
+	return fmt.Sprintf("swap transaction failed safety check (%s): expected %s, got %s", e.Violation, e.Expected, e.Actual)
+}
+
+type SwapLimit string
+
+const (
+	SwapLimitFeeNative   SwapLimit = "maxFeeNative"
+	SwapLimitFeeQuote    SwapLimit = "maxFeeQuote"
+	SwapLimitPriceImpact SwapLimit = "maxPriceImpact"
+)
+
+// SwapLimitError is returned when a swap made by SwapTokens exceeds one of its limits and is aborted before signing
+type SwapLimitError struct {
+	Limit  SwapLimit
+	Max    string
+	Actual string
+}
+
+func (e *SwapLimitError) Error() string {
+	return fmt.Sprintf("swap exceeds %s: limit is %s, estimated %s", e.Limit, e.Max, e.Actual)
+}
@@ -8,14 +8,26 @@ import (
 )
 
 type SwapTokensParams struct {
-	ApprovalType  onchain.ApprovalType
-	ChainId       int
-	SkipWarnings  bool
-	PublicAddress string
-	WalletKey     string
+	ApprovalType   onchain.ApprovalType
+	ApprovalPolicy onchain.ApprovalPolicy
+	WaitOptions    onchain.WaitOptions
+	ChainId        int
+	SkipWarnings   bool
+	PublicAddress  string
+	WalletKey      string
+	Limits         SwapLimits
+	PermitDeadline onchain.Deadline // Optional, defaults to the permit deadline of the client
 	AggregationControllerGetSwapParams
 }
 
+// SwapLimits are the guardrails of SwapTokens, the swap is aborted before anything is signed when one of them is exceeded
+type SwapLimits struct {
+	MaxFeeNative   string  // Maximum gas cost of all the transactions of the swap, in wei, also enforced on their gas limit at their fee cap
+	MaxFeeQuote    string  // Maximum gas cost of all the transactions of the swap, in the smallest unit of QuoteToken
+	QuoteToken     string  // Token MaxFeeQuote is expressed in, usually a stablecoin
+	MaxPriceImpact float32 // Maximum price impact of the swap, in percent
+}
+
 // TODO Add validation to all optional parameters here
 
 func (params *SwapTokensParams) Validate() error {
@@ -40,6 +52,16 @@ func (params *SwapTokensParams) Validate() error {
 	validationErrors = validate.Parameter(params.Permit, "permit", validate.CheckPermitHash, validationErrors)
 	validationErrors = validate.Parameter(params.Receiver, "receiver", validate.CheckEthereumAddress, validationErrors)
 	validationErrors = validate.Parameter(params.Referrer, "referrer", validate.CheckEthereumAddress, validationErrors)
+	validationErrors = validate.Parameter(params.Limits.MaxFeeNative, "limits.maxFeeNative", validate.CheckBigIntRequired, validationErrors)
+	validationErrors = validate.Parameter(params.Limits.MaxFeeQuote, "limits.maxFeeQuote", validate.CheckBigIntRequired, validationErrors)
+	validationErrors = validate.Parameter(params.Limits.QuoteToken, "limits.quoteToken", validate.CheckEthereumAddressRequired, validationErrors)
+	validationErrors = validate.Parameter(params.Limits.MaxPriceImpact, "limits.maxPriceImpact", validate.CheckPriceImpactRequired, validationErrors)
+	if err := params.PermitDeadline.Validate(); err != nil {
+		validationErrors = append(validationErrors, validate.NewParameterValidationError("permitDeadline", err.Error()))
+	}
+	if err := params.ApprovalPolicy.Validate(); err != nil {
+		validationErrors = append(validationErrors, validate.NewParameterCustomError(err.Error()))
+	}
 	return validate.ConsolidateValidationErorrs(validationErrors)
 }
 
@@ -1,5 +1,13 @@
 package models
 
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/1inch/1inch-sdk-go/internal/onchain"
+)
+
 type ExecuteSwapConfig struct {
 	WalletKey          string
 	ChainId            int
@@ -10,6 +18,76 @@ type ExecuteSwapConfig struct {
 	Slippage           float32
 	EstimatedAmountOut string
 	TransactionData    string
+	TransactionTo      string // Required, the swap is only signed when it is the aggregation router
+	TransactionValue   string // Required, the swap is only signed when it is the amount of a native swap and 0 otherwise
+	EstimatedGas       uint64 // Optional, the gas assumed for the swap when it cannot be estimated yet, a generous default is used otherwise
+	AllowedReceivers   []string
 	IsPermitSwap       bool
+	ApprovalPolicy     onchain.ApprovalPolicy
+	WaitOptions        onchain.WaitOptions // Confirmations and timeout applied to every transaction of the swap
 	SkipWarnings       bool
+	CostEstimate       *SwapCostEstimate // Optional, shown in the confirmation summary
+	MaxFeeNative       *big.Int          // Optional, a transaction of the swap is not sent when its gas limit at its fee cap could take the gas cost of the swap over this amount, in wei
+}
+
+// SwapCostEstimate is the expected cost of a swap before it is executed
+type SwapCostEstimate struct {
+	GasCost      *big.Int // Gas cost of all the transactions of the swap, in wei
+	NativeToken  *TokenInfo
+	GasCostQuote *big.Int // GasCost priced in QuoteToken
+	QuoteToken   *TokenInfo
+	PriceImpact  float64 // In percent, negative when the swap gets a better rate than a quote for a small amount
+}
+
+// ExecutionResult describes a swap executed onchain by ExecuteSwap
+type ExecutionResult struct {
+	ApprovalTxHash string // Empty when the router already had enough allowance
+	// UsedPermit is set when the tokens were spent through a permit, which is signed offchain and executed within the swap
+	// transaction, so it has no transaction hash of its own
+	UsedPermit        bool
+	SwapTxHash        string
+	RevokeTxHash      string // Empty when no allowance was revoked after the swap
+	Receipt           *types.Receipt
+	GasUsed           uint64
+	EffectiveGasPrice *big.Int
+	GasCost           *big.Int // Native tokens paid for the gas of the swap transaction
+	// AmountReceived is taken from the ERC20 Transfer logs to the receiver, or from the change of the native balance of the
+	// receiver in the block of the swap when buying the native token
+	AmountReceived *big.Int
+	// RealizedSlippage is how far below EstimatedAmountOut, in percent, AmountReceived is (negative when more was received)
+	RealizedSlippage float64
+}
+
+// DecodedSwap is a structured view of AggregationRouterV5 swap calldata
+//
+// Fields that are not encoded in the calldata of a given method are left empty. For example, the destination token of
+// unoswap and uniswapV3Swap calls is only known by the pools themselves, and the source token of uniswapV3Swap is never encoded.
+// An empty Receiver means the output is sent to the sender of the transaction
+type DecodedSwap struct {
+	Method           string
+	Executor         string
+	SrcToken         string
+	DstToken         string
+	SrcReceiver      string
+	Receiver         string
+	Amount           *big.Int
+	MinReturn        *big.Int
+	Flags            *big.Int
+	PartialFill      bool
+	RequiresExtraEth bool
+	Pools            []DecodedPool
+	Permit           []byte
+	ExecutorData     []byte
+}
+
+// DecodedPool is a single pool entry from an unoswap or uniswapV3Swap route
+type DecodedPool struct {
+	Address string
+	Raw     *big.Int
+	// Reverse is set when the pool is traded from token1 to token0
+	Reverse bool
+	// UnwrapWeth is set when the output of the pool is unwrapped to the native token
+	UnwrapWeth bool
+	// Numerator is the fee-adjusted numerator used by unoswap pairs (unset for Uniswap V3 pools)
+	Numerator uint32
 }
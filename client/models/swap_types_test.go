@@ -10,6 +10,13 @@ import (
 	"github.com/1inch/1inch-sdk-go/helpers/consts/tokens"
 )
 
+var testSwapLimits = SwapLimits{
+	MaxFeeNative:   "10000000000000000",
+	MaxFeeQuote:    "20000000",
+	QuoteToken:     "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48",
+	MaxPriceImpact: 1,
+}
+
 func TestSwapTokensParams_Validate(t *testing.T) {
 	testCases := []struct {
 		description  string
@@ -22,6 +29,7 @@ func TestSwapTokensParams_Validate(t *testing.T) {
 				ChainId:       chains.Ethereum,
 				PublicAddress: "0x1234567890abcdef1234567890abcdef12345678",
 				WalletKey:     "a0b1c2d3e4f5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b1",
+				Limits:        testSwapLimits,
 				AggregationControllerGetSwapParams: AggregationControllerGetSwapParams{
 					Src:      "0x1234567890abcdef1234567890abcdef12345678",
 					Dst:      "0x1234567890abcdef1234567890abcdef12345679",
@@ -43,6 +51,36 @@ func TestSwapTokensParams_Validate(t *testing.T) {
 				"'amount' is required",
 				"'from' is required",
 				"'slippage' is required",
+				"'limits.maxFeeNative' is required",
+				"'limits.maxFeeQuote' is required",
+				"'limits.quoteToken' is required",
+				"'limits.maxPriceImpact' is required",
+			},
+		},
+		{
+			description: "Invalid limits",
+			params: SwapTokensParams{
+				ChainId:       chains.Ethereum,
+				PublicAddress: "0x1234567890abcdef1234567890abcdef12345678",
+				WalletKey:     "a0b1c2d3e4f5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b1",
+				Limits: SwapLimits{
+					MaxFeeNative:   "0.1",
+					MaxFeeQuote:    "5000000",
+					QuoteToken:     "usdc",
+					MaxPriceImpact: 150,
+				},
+				AggregationControllerGetSwapParams: AggregationControllerGetSwapParams{
+					Src:      "0x1234567890abcdef1234567890abcdef12345678",
+					Dst:      "0x1234567890abcdef1234567890abcdef12345679",
+					Amount:   "10000",
+					From:     "0x1234567890abcdef1234567890abcdef12345678",
+					Slippage: 0.5,
+				},
+			},
+			expectErrors: []string{
+				"limits.maxFeeNative",
+				"limits.quoteToken",
+				"limits.maxPriceImpact",
 			},
 		},
 	}
@@ -0,0 +1,90 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// UnsignedTransaction is a fully populated transaction left for an external signer, such as a custodian, to sign and broadcast
+type UnsignedTransaction struct {
+	Description string
+	From        common.Address
+	ChainId     *big.Int
+	Transaction *types.Transaction
+}
+
+// SigningHash returns the hash the signer has to sign
+func (t *UnsignedTransaction) SigningHash() common.Hash {
+	return types.LatestSignerForChainID(t.ChainId).Hash(t.Transaction)
+}
+
+// RawHex returns the RLP encoded signing payload of the transaction, prefixed with its type for typed transactions
+// Legacy transactions use the EIP-155 payload, which includes the chain id
+func (t *UnsignedTransaction) RawHex() (string, error) {
+	tx := t.Transaction
+	switch tx.Type() {
+	case types.LegacyTxType:
+		raw, err := rlp.EncodeToBytes([]interface{}{
+			tx.Nonce(), tx.GasPrice(), tx.Gas(), tx.To(), tx.Value(), tx.Data(), t.ChainId, uint(0), uint(0),
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to encode transaction: %v", err)
+		}
+		return hexutil.Encode(raw), nil
+	case types.DynamicFeeTxType:
+		raw, err := rlp.EncodeToBytes([]interface{}{
+			t.ChainId, tx.Nonce(), tx.GasTipCap(), tx.GasFeeCap(), tx.Gas(), tx.To(), tx.Value(), tx.Data(), tx.AccessList(),
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to encode transaction: %v", err)
+		}
+		return hexutil.Encode(append([]byte{types.DynamicFeeTxType}, raw...)), nil
+	default:
+		return "", fmt.Errorf("unsupported transaction type: %d", tx.Type())
+	}
+}
+
+// unsignedTransactionJson follows the transaction object of eth_signTransaction requests
+type unsignedTransactionJson struct {
+	Description          string          `json:"description,omitempty"`
+	Type                 hexutil.Uint64  `json:"type"`
+	ChainId              *hexutil.Big    `json:"chainId"`
+	From                 common.Address  `json:"from"`
+	To                   *common.Address `json:"to"`
+	Nonce                hexutil.Uint64  `json:"nonce"`
+	Gas                  hexutil.Uint64  `json:"gas"`
+	GasPrice             *hexutil.Big    `json:"gasPrice,omitempty"`
+	MaxFeePerGas         *hexutil.Big    `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas *hexutil.Big    `json:"maxPriorityFeePerGas,omitempty"`
+	Value                *hexutil.Big    `json:"value"`
+	Data                 hexutil.Bytes   `json:"data"`
+}
+
+// MarshalJSON encodes the transaction as the transaction object of an eth_signTransaction request
+func (t UnsignedTransaction) MarshalJSON() ([]byte, error) {
+	tx := t.Transaction
+	encoded := unsignedTransactionJson{
+		Description: t.Description,
+		Type:        hexutil.Uint64(tx.Type()),
+		ChainId:     (*hexutil.Big)(t.ChainId),
+		From:        t.From,
+		To:          tx.To(),
+		Nonce:       hexutil.Uint64(tx.Nonce()),
+		Gas:         hexutil.Uint64(tx.Gas()),
+		Value:       (*hexutil.Big)(tx.Value()),
+		Data:        tx.Data(),
+	}
+	if tx.Type() == types.LegacyTxType {
+		encoded.GasPrice = (*hexutil.Big)(tx.GasPrice())
+	} else {
+		encoded.MaxFeePerGas = (*hexutil.Big)(tx.GasFeeCap())
+		encoded.MaxPriorityFeePerGas = (*hexutil.Big)(tx.GasTipCap())
+	}
+	return json.Marshal(encoded)
+}
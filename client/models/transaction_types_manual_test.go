@@ -0,0 +1,98 @@
+package models
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnsignedTransaction(t *testing.T) {
+	to := common.HexToAddress("0x1111111254eeb25477b68fb85ed929f73a960582")
+	from := common.HexToAddress("0x50c5df26654b5efbdd0c54a062dfa6012933defe")
+
+	testCases := []struct {
+		description    string
+		chainId        int64
+		tx             *types.Transaction
+		expectedFields map[string]interface{}
+	}{
+		{
+			description: "Legacy transaction",
+			chainId:     56,
+			tx: types.NewTx(&types.LegacyTx{
+				Nonce:    7,
+				GasPrice: big.NewInt(3e9),
+				Gas:      210000,
+				To:       &to,
+				Value:    big.NewInt(1e18),
+				Data:     []byte{0x12, 0x34},
+			}),
+			expectedFields: map[string]interface{}{
+				"type":     "0x0",
+				"chainId":  "0x38",
+				"nonce":    "0x7",
+				"gas":      "0x33450",
+				"gasPrice": "0xb2d05e00",
+				"value":    "0xde0b6b3a7640000",
+				"data":     "0x1234",
+			},
+		},
+		{
+			description: "Dynamic fee transaction",
+			chainId:     1,
+			tx: types.NewTx(&types.DynamicFeeTx{
+				ChainID:   big.NewInt(1),
+				Nonce:     8,
+				GasTipCap: big.NewInt(1e9),
+				GasFeeCap: big.NewInt(30e9),
+				Gas:       100000,
+				To:        &to,
+				Value:     big.NewInt(0),
+				Data:      []byte{0x56},
+			}),
+			expectedFields: map[string]interface{}{
+				"type":                 "0x2",
+				"chainId":              "0x1",
+				"nonce":                "0x8",
+				"gas":                  "0x186a0",
+				"maxFeePerGas":         "0x6fc23ac00",
+				"maxPriorityFeePerGas": "0x3b9aca00",
+				"value":                "0x0",
+				"data":                 "0x56",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			unsignedTx := UnsignedTransaction{
+				Description: "Swap",
+				From:        from,
+				ChainId:     big.NewInt(tc.chainId),
+				Transaction: tc.tx,
+			}
+
+			// The raw payload is exactly what the signer hashes
+			rawHex, err := unsignedTx.RawHex()
+			require.NoError(t, err)
+			require.Equal(t, unsignedTx.SigningHash(), crypto.Keccak256Hash(hexutil.MustDecode(rawHex)))
+
+			encoded, err := json.Marshal(unsignedTx)
+			require.NoError(t, err)
+			var fields map[string]interface{}
+			require.NoError(t, json.Unmarshal(encoded, &fields))
+			for name, expected := range tc.expectedFields {
+				require.Equal(t, expected, fields[name], name)
+			}
+			require.Equal(t, "Swap", fields["description"])
+			require.Equal(t, from.Hex(), common.HexToAddress(fields["from"].(string)).Hex())
+			require.Equal(t, to.Hex(), common.HexToAddress(fields["to"].(string)).Hex())
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"github.com/1inch/1inch-sdk-go/internal/onchain"
+	"github.com/1inch/1inch-sdk-go/internal/validate"
+)
+
+type WrapNativeParams struct {
+	ChainId       int
+	PublicAddress string
+	WalletKey     string
+	Amount        string
+	WaitOptions   onchain.WaitOptions
+	SkipWarnings  bool
+}
+
+func (params *WrapNativeParams) Validate() error {
+	var validationErrors []error
+	validationErrors = validate.Parameter(params.ChainId, "chainId", validate.CheckChainIdRequired, validationErrors)
+	validationErrors = validate.Parameter(params.PublicAddress, "publicAddress", validate.CheckEthereumAddressRequired, validationErrors)
+	validationErrors = validate.Parameter(params.WalletKey, "walletKey", validate.CheckPrivateKeyRequired, validationErrors)
+	validationErrors = validate.Parameter(params.Amount, "amount", validate.CheckBigIntRequired, validationErrors)
+	return validate.ConsolidateValidationErorrs(validationErrors)
+}
+
+type UnwrapNativeParams struct {
+	ChainId       int
+	PublicAddress string
+	WalletKey     string
+	Amount        string
+	WaitOptions   onchain.WaitOptions
+	SkipWarnings  bool
+}
+
+func (params *UnwrapNativeParams) Validate() error {
+	var validationErrors []error
+	validationErrors = validate.Parameter(params.ChainId, "chainId", validate.CheckChainIdRequired, validationErrors)
+	validationErrors = validate.Parameter(params.PublicAddress, "publicAddress", validate.CheckEthereumAddressRequired, validationErrors)
+	validationErrors = validate.Parameter(params.WalletKey, "walletKey", validate.CheckPrivateKeyRequired, validationErrors)
+	validationErrors = validate.Parameter(params.Amount, "amount", validate.CheckBigIntRequired, validationErrors)
+	return validate.ConsolidateValidationErorrs(validationErrors)
+}
@@ -0,0 +1,110 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/1inch/1inch-sdk-go/helpers/consts/chains"
+	"github.com/1inch/1inch-sdk-go/internal/validate"
+)
+
+func TestWrapNativeParams_Validate(t *testing.T) {
+	testCases := []struct {
+		description  string
+		params       WrapNativeParams
+		expectErrors []string
+	}{
+		{
+			description: "Valid parameters",
+			params: WrapNativeParams{
+				ChainId:       chains.Ethereum,
+				PublicAddress: "0x1234567890abcdef1234567890abcdef12345678",
+				WalletKey:     "a0b1c2d3e4f5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b1",
+				Amount:        "1000000000000000000",
+			},
+		},
+		{
+			description: "Missing required parameters",
+			params:      WrapNativeParams{},
+			expectErrors: []string{
+				"'chainId' is required",
+				"'publicAddress' is required",
+				"'walletKey' is required",
+				"'amount' is required",
+			},
+		},
+		{
+			description: "Invalid amount",
+			params: WrapNativeParams{
+				ChainId:       chains.Ethereum,
+				PublicAddress: "0x1234567890abcdef1234567890abcdef12345678",
+				WalletKey:     "a0b1c2d3e4f5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b1",
+				Amount:        "1.5",
+			},
+			expectErrors: []string{
+				"'amount'",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := tc.params.Validate()
+
+			if len(tc.expectErrors) > 0 {
+				require.Error(t, err)
+				for _, expectedError := range tc.expectErrors {
+					require.Contains(t, err.Error(), expectedError, "Error message should contain the expected text")
+				}
+				require.Equal(t, len(tc.expectErrors), validate.GetValidatorErrorsCount(err), "The number of errors returned should match the length of the expected errors: %s\n", err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestUnwrapNativeParams_Validate(t *testing.T) {
+	testCases := []struct {
+		description  string
+		params       UnwrapNativeParams
+		expectErrors []string
+	}{
+		{
+			description: "Valid parameters",
+			params: UnwrapNativeParams{
+				ChainId:       chains.Polygon,
+				PublicAddress: "0x1234567890abcdef1234567890abcdef12345678",
+				WalletKey:     "a0b1c2d3e4f5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b1",
+				Amount:        "1000000000000000000",
+			},
+		},
+		{
+			description: "Missing required parameters",
+			params:      UnwrapNativeParams{},
+			expectErrors: []string{
+				"'chainId' is required",
+				"'publicAddress' is required",
+				"'walletKey' is required",
+				"'amount' is required",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := tc.params.Validate()
+
+			if len(tc.expectErrors) > 0 {
+				require.Error(t, err)
+				for _, expectedError := range tc.expectErrors {
+					require.Contains(t, err.Error(), expectedError, "Error message should contain the expected text")
+				}
+				require.Equal(t, len(tc.expectErrors), validate.GetValidatorErrorsCount(err), "The number of errors returned should match the length of the expected errors: %s\n", err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
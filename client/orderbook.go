@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math/big"
 	"net/http"
 	"time"
 
@@ -13,12 +14,16 @@ import (
 	"github.com/1inch/1inch-sdk-go/helpers"
 	"github.com/1inch/1inch-sdk-go/helpers/consts/addresses"
 	"github.com/1inch/1inch-sdk-go/helpers/consts/contracts"
+	"github.com/1inch/1inch-sdk-go/helpers/consts/tokens"
 	"github.com/1inch/1inch-sdk-go/internal/onchain"
 	"github.com/1inch/1inch-sdk-go/internal/orderbook"
+	"github.com/1inch/1inch-sdk-go/internal/policy"
 	"github.com/1inch/1inch-sdk-go/internal/tenderly"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
 )
 
 type OrderbookService service
@@ -32,9 +37,9 @@ func (s *OrderbookService) CreateOrder(ctx context.Context, params models.Create
 		return nil, nil, err
 	}
 
-	// Orders only last one minute if not specified in the request
+	// Orders only last one minute unless an expiry is set on the params or the client
 	if params.ExpireAfter == 0 {
-		params.ExpireAfter = time.Now().Add(time.Minute).Unix()
+		params.ExpireAfter = onchain.ResolveDeadline(time.Now(), params.Expiry, s.client.orderExpiry)
 	}
 
 	// To post an order that is open to anyone, the taker address must be the zero address
@@ -42,6 +47,23 @@ func (s *OrderbookService) CreateOrder(ctx context.Context, params models.Create
 		params.Taker = addresses.Zero
 	}
 
+	// The order is checked against the policy before the wrap and approval it may need are sent
+	if s.client.policy != nil {
+		err = s.checkOrderPolicy(ctx, params)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// Validation only lets the native gas token through as the maker asset when it should be wrapped first
+	if params.MakerAsset == tokens.NativeToken {
+		wrappedNativeToken, err := s.wrapNativeForOrder(ctx, params)
+		if err != nil {
+			return nil, nil, err
+		}
+		params.MakerAsset = wrappedNativeToken
+	}
+
 	aggregationRouter, err := contracts.Get1inchRouterFromChainId(params.ChainId)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to get 1inch router address: %v", err)
@@ -55,21 +77,44 @@ func (s *OrderbookService) CreateOrder(ctx context.Context, params models.Create
 		return nil, nil, fmt.Errorf("failed to get eth client: %v", err)
 	}
 
-	privateKey, err := crypto.HexToECDSA(params.PrivateKey)
+	// Contract makers have no key, their orders are signed by the contract signer of the request
+	var derivedPublicAddress common.Address
+	if params.ContractSigner == nil {
+		privateKey, err := crypto.HexToECDSA(params.PrivateKey)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to convert private key: %v", err)
+		}
+
+		publicKey := privateKey.Public()
+		publicKeyECDSA, ok := publicKey.(*ecdsa.PublicKey)
+		if !ok {
+			return nil, nil, fmt.Errorf("could not cast public key to ECDSA")
+		}
+
+		derivedPublicAddress = crypto.PubkeyToAddress(*publicKeyECDSA)
+	}
+
+	makingAmountBig, err := helpers.BigIntFromString(params.MakingAmount)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to convert private key: %v", err)
+		return nil, nil, fmt.Errorf("failed to parse making amount: %v", err)
 	}
 
-	publicKey := privateKey.Public()
-	publicKeyECDSA, ok := publicKey.(*ecdsa.PublicKey)
-	if !ok {
-		return nil, nil, fmt.Errorf("could not cast public key to ECDSA")
+	approvalAmount, err := params.ApprovalPolicy.GetApprovalAmount(makingAmountBig)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get approval amount: %v", err)
 	}
 
-	derivedPublicAddress := crypto.PubkeyToAddress(*publicKeyECDSA)
+	// An order the maker cannot cover would be posted to the orderbook without ever being fillable
+	_, isSimulation := ctx.Value(tenderly.SwapConfigKey).(tenderly.SimulationConfig)
+	if !isSimulation {
+		err = s.checkMakerFunds(ctx, ethClient, params, makingAmountBig)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
 
 	var usePermit bool
-	if params.ApprovalType != onchain.ApprovalAlways {
+	if params.ApprovalType != onchain.ApprovalAlways && params.ContractSigner == nil {
 		usePermit = onchain.ShouldUsePermit(ethClient, params.ChainId, params.MakerAsset)
 	}
 
@@ -82,10 +127,13 @@ func (s *OrderbookService) CreateOrder(ctx context.Context, params models.Create
 			ChainId:       params.ChainId,
 			PrivateKey:    params.PrivateKey,
 			Deadline:      params.ExpireAfter,
+			Value:         approvalAmount,
 		})
-		if err != nil {
-			fmt.Printf("failed to create permit: %v", err)
-			fmt.Println("defaulting to Approval")
+		switch {
+		case errors.Is(err, onchain.ErrDaiPermitIsUnlimited) && params.ApprovalType == onchain.PermitIfPossible:
+			// The approval policy cannot be honored by this token's permit, so an approval is used instead
+		case err != nil:
+			return nil, nil, fmt.Errorf("failed to create permit: %v", err)
 		}
 	}
 
@@ -95,20 +143,43 @@ func (s *OrderbookService) CreateOrder(ctx context.Context, params models.Create
 			return nil, nil, fmt.Errorf("failed to read allowance: %v", err)
 		}
 
-		makingAmountBig, err := helpers.BigIntFromString(params.MakingAmount)
-		if err != nil {
-			return nil, nil, fmt.Errorf("failed to parse making amount: %v", err)
-		}
-		if allowance.Cmp(makingAmountBig) <= 0 {
+		if allowance.Cmp(makingAmountBig) < 0 {
 
 			if !params.EnableOnchainApprovalsIfNeeded {
 				return nil, nil, models.ErrorFailWhenApprovalIsNeeded
 			}
 
-			if !params.SkipWarnings {
-				ok, err := orderbook.ConfirmApprovalWithUser(ethClient, params.Maker, params.MakerAsset)
+			if !isSimulation {
+				approvalData, err := onchain.GetApproveCalldata(aggregationRouterAddress, approvalAmount)
 				if err != nil {
-					return nil, nil, fmt.Errorf("failed to confirm approval: %v", err)
+					return nil, nil, err
+				}
+				err = onchain.CheckFunds(ctx, ethClient, onchain.PreflightConfig{
+					PublicAddress: publicAddress,
+					NativeSymbol:  getNativeTokenDetails(params.ChainId).Symbol,
+					Transactions: []ethereum.CallMsg{
+						{To: &fromTokenAddress, Data: approvalData, Gas: onchain.ApprovalGasFallback},
+					},
+				})
+				if err != nil {
+					return nil, nil, err
+				}
+			}
+
+			if s.client.needsSummary(params.SkipWarnings) {
+				makerToken, err := readTokenInfo(ethClient, params.MakerAsset)
+				if err != nil {
+					return nil, nil, err
+				}
+				ok, err := s.client.confirm(ctx, &models.Summary{
+					Kind:        models.SummaryApproval,
+					ApprovalFor: models.SummaryLimitOrder,
+					ChainId:     params.ChainId,
+					Wallet:      params.Maker,
+					Approval:    newApprovalSummaryAmount(makerToken, approvalAmount),
+				}, params.SkipWarnings)
+				if err != nil {
+					return nil, nil, fmt.Errorf("failed to confirm approval: %w", err)
 				}
 				if !ok {
 					return nil, nil, errors.New("user rejected approval")
@@ -116,17 +187,21 @@ func (s *OrderbookService) CreateOrder(ctx context.Context, params models.Create
 			}
 
 			// Only run the approval if Tenderly data is not present
-			if _, ok = ctx.Value(tenderly.SwapConfigKey).(tenderly.SimulationConfig); !ok {
+			if !isSimulation {
 				erc20Config := onchain.Erc20ApprovalConfig{
 					ChainId:        params.ChainId,
 					Key:            params.PrivateKey,
 					Erc20Address:   fromTokenAddress,
 					PublicAddress:  publicAddress,
 					SpenderAddress: aggregationRouterAddress,
+					Amount:         approvalAmount,
+					HeadWatcher:    s.client.getHeadWatcher(params.ChainId),
+					Broadcaster:    s.client.getBroadcaster(params.ChainId),
+					WaitOptions:    params.WaitOptions,
 				}
-				err := onchain.ApproveTokenForRouter(ctx, ethClient, s.client.NonceCache, erc20Config)
+				_, err := onchain.ApproveTokenForRouter(ctx, ethClient, s.client.NonceCache, erc20Config)
 				if err != nil {
-					return nil, nil, fmt.Errorf("failed to approve token for router: %v", err)
+					return nil, nil, fmt.Errorf("failed to approve token for router: %w", err)
 				}
 				helpers.Sleep()
 			}
@@ -138,18 +213,35 @@ func (s *OrderbookService) CreateOrder(ctx context.Context, params models.Create
 		return nil, nil, fmt.Errorf("failed to get series nonce manager address: %v", err)
 	}
 
-	interactions, err := orderbook.GetInteractions(ethClient, seriesNonceManager, params.ExpireAfter, params.Maker, params.MakerAsset, permitParams)
+	interactions, err := orderbook.GetInteractions(ethClient, seriesNonceManager, params.ExpireAfter, params.Maker, params.MakerAsset, permitParams, params.Predicate)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to get interactions: %v", err)
 	}
+	if params.Pricing != nil {
+		interactions, err = orderbook.WithDynamicPricing(interactions, params.Pricing, makingAmountBig)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
 
-	order, err := orderbook.CreateLimitOrderMessage(params, interactions)
+	var order *models.Order
+	if params.ContractSigner != nil {
+		order, err = orderbook.CreateContractLimitOrderMessage(ctx, ethClient, params, interactions)
+	} else {
+		order, err = orderbook.CreateLimitOrderMessage(params, interactions)
+	}
 	if err != nil {
 		return nil, nil, err
 	}
 
-	if !params.SkipWarnings {
-		ok, err := orderbook.ConfirmLimitOrderWithUser(order, ethClient)
+	var reservation *policy.Reservation
+	if s.client.needsSummary(params.SkipWarnings) {
+		summary, err := newLimitOrderSummary(ethClient, params.ChainId, order, params.Pricing)
+		if err != nil {
+			return nil, nil, err
+		}
+		var ok bool
+		reservation, ok, err = s.client.confirmTrade(ctx, summary, params.SkipWarnings)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -157,6 +249,8 @@ func (s *OrderbookService) CreateOrder(ctx context.Context, params models.Create
 			return nil, nil, errors.New("user rejected trade")
 		}
 	}
+	// The order only counts towards the daily limits of the policy once the orderbook accepted it
+	defer reservation.Release()
 
 	body, err := json.Marshal(order)
 	if err != nil {
@@ -174,9 +268,473 @@ func (s *OrderbookService) CreateOrder(ctx context.Context, params models.Create
 		return nil, nil, err
 	}
 
+	reservation.Record()
 	return &createOrderResponse, res, nil
 }
 
+// checkOrderPolicy evaluates the summary of an order against the policy of the client before anything is sent for it
+func (s *OrderbookService) checkOrderPolicy(ctx context.Context, params models.CreateOrderParams) error {
+	ethClient, err := s.client.GetEthClient(params.ChainId)
+	if err != nil {
+		return fmt.Errorf("failed to get eth client: %v", err)
+	}
+
+	// The native gas token is wrapped before the order is posted, so the order sells the wrapped token
+	makerAsset := params.MakerAsset
+	if makerAsset == tokens.NativeToken {
+		makerAsset, err = tokens.GetWrappedNativeTokenFromChainId(params.ChainId)
+		if err != nil {
+			return fmt.Errorf("failed to get wrapped native token address: %v", err)
+		}
+	}
+
+	summary, err := newLimitOrderSummary(ethClient, params.ChainId, &models.Order{Data: models.OrderData{
+		MakerAsset:   makerAsset,
+		TakerAsset:   params.TakerAsset,
+		MakingAmount: params.MakingAmount,
+		TakingAmount: params.TakingAmount,
+		Maker:        params.Maker,
+	}}, params.Pricing)
+	if err != nil {
+		return err
+	}
+	return s.client.checkPolicy(ctx, summary)
+}
+
+// checkMakerFunds makes sure the maker holds the making amount of the maker asset
+func (s *OrderbookService) checkMakerFunds(ctx context.Context, ethClient *ethclient.Client, params models.CreateOrderParams, makingAmount *big.Int) error {
+	makerAssetAddress := common.HexToAddress(params.MakerAsset)
+
+	symbol, err := onchain.ReadContractSymbol(ethClient, makerAssetAddress)
+	if err != nil {
+		return fmt.Errorf("failed to read symbol: %v", err)
+	}
+
+	decimals, err := onchain.ReadContractDecimals(ethClient, makerAssetAddress)
+	if err != nil {
+		return fmt.Errorf("failed to read decimals: %v", err)
+	}
+
+	return onchain.CheckFunds(ctx, ethClient, onchain.PreflightConfig{
+		PublicAddress: common.HexToAddress(params.Maker),
+		Token:         makerAssetAddress,
+		TokenSymbol:   symbol,
+		TokenDecimals: decimals,
+		Amount:        makingAmount,
+		NativeSymbol:  getNativeTokenDetails(params.ChainId).Symbol,
+	})
+}
+
+// wrapNativeForOrder wraps the part of the making amount the maker does not hold as wrapped native tokens yet
+// and returns the address of the wrapped native token to use as the maker asset
+func (s *OrderbookService) wrapNativeForOrder(ctx context.Context, params models.CreateOrderParams) (string, error) {
+	wrappedNativeToken, err := tokens.GetWrappedNativeTokenFromChainId(params.ChainId)
+	if err != nil {
+		return "", fmt.Errorf("failed to get wrapped native token address: %v", err)
+	}
+
+	ethClient, err := s.client.GetEthClient(params.ChainId)
+	if err != nil {
+		return "", fmt.Errorf("failed to get eth client: %v", err)
+	}
+
+	makingAmount, err := helpers.BigIntFromString(params.MakingAmount)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse making amount: %v", err)
+	}
+
+	balance, err := onchain.ReadContractBalance(ethClient, common.HexToAddress(wrappedNativeToken), common.HexToAddress(params.Maker))
+	if err != nil {
+		return "", fmt.Errorf("failed to read wrapped native token balance: %v", err)
+	}
+	if balance.Cmp(makingAmount) >= 0 {
+		return wrappedNativeToken, nil
+	}
+
+	// Only wrap when Tenderly data is not present
+	if _, ok := ctx.Value(tenderly.SwapConfigKey).(tenderly.SimulationConfig); ok {
+		return wrappedNativeToken, nil
+	}
+
+	shortfall := new(big.Int).Sub(makingAmount, balance)
+
+	wrappedNativeAddress := common.HexToAddress(wrappedNativeToken)
+	wrapData, err := onchain.GetWrapNativeCalldata()
+	if err != nil {
+		return "", err
+	}
+	err = onchain.CheckFunds(ctx, ethClient, onchain.PreflightConfig{
+		PublicAddress: common.HexToAddress(params.Maker),
+		NativeSymbol:  getNativeTokenDetails(params.ChainId).Symbol,
+		Transactions: []ethereum.CallMsg{
+			{To: &wrappedNativeAddress, Value: shortfall, Data: wrapData},
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	err = s.client.Actions.executeWrap(ctx, params.ChainId, params.Maker, params.PrivateKey, shortfall, params.WaitOptions, params.SkipWarnings, false)
+	if err != nil {
+		return "", fmt.Errorf("failed to wrap native token for order: %w", err)
+	}
+	helpers.Sleep()
+
+	return wrappedNativeToken, nil
+}
+
+// CancelOrder cancels a limit order onchain by sending its cancelOrder transaction to the 1inch router
+// The order stays in the orderbook API until the API sees the cancellation
+func (s *OrderbookService) CancelOrder(ctx context.Context, params models.CancelOrderParams) (*models.CancelResult, error) {
+	err := params.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	aggregationRouter, err := contracts.Get1inchRouterFromChainId(params.ChainId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get 1inch router address: %v", err)
+	}
+
+	ethClient, err := s.client.GetEthClient(params.ChainId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get eth client: %v", err)
+	}
+
+	data, err := orderbook.GetCancelOrderCalldata(params.Order)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cancel order calldata: %v", err)
+	}
+
+	if s.client.needsSummary(params.SkipWarnings) {
+		summary, err := newLimitOrderSummary(ethClient, params.ChainId, &models.Order{OrderHash: params.OrderHash, Data: params.Order}, nil)
+		if err != nil {
+			return nil, err
+		}
+		summary.Kind = models.SummaryCancelOrder
+		summary.OrderHash = params.OrderHash
+		ok, err := s.client.confirm(ctx, summary, params.SkipWarnings)
+		if err != nil {
+			return nil, fmt.Errorf("failed to confirm cancellation: %w", err)
+		}
+		if !ok {
+			return nil, errors.New("user rejected cancellation")
+		}
+	}
+
+	return s.sendCancellation(ctx, ethClient, params.ChainId, params.PrivateKey, params.Maker, aggregationRouter, data, "Cancel Order", params.WaitOptions)
+}
+
+// CancelAllOrders cancels every open limit order of the maker at once by raising its series nonce on the series nonce
+// manager, which the predicate of every order posted by the SDK checks
+func (s *OrderbookService) CancelAllOrders(ctx context.Context, params models.CancelAllOrdersParams) (*models.CancelResult, error) {
+	err := params.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	if params.NonceIncrement == 0 {
+		params.NonceIncrement = 1
+	}
+
+	seriesNonceManager, err := contracts.GetSeriesNonceManagerFromChainId(params.ChainId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get series nonce manager address: %v", err)
+	}
+
+	ethClient, err := s.client.GetEthClient(params.ChainId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get eth client: %v", err)
+	}
+
+	nonce, err := onchain.GetTimeSeriesManagerNonce(ethClient, seriesNonceManager, params.Maker)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read series nonce: %v", err)
+	}
+
+	data, err := orderbook.GetAdvanceNonceCalldata(params.NonceIncrement)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get advance nonce calldata: %v", err)
+	}
+
+	if s.client.needsSummary(params.SkipWarnings) {
+		ok, err := s.client.confirm(ctx, &models.Summary{
+			Kind:    models.SummaryCancelAllOrders,
+			ChainId: params.ChainId,
+			Wallet:  params.Maker,
+		}, params.SkipWarnings)
+		if err != nil {
+			return nil, fmt.Errorf("failed to confirm cancellation: %w", err)
+		}
+		if !ok {
+			return nil, errors.New("user rejected cancellation")
+		}
+	}
+
+	result, err := s.sendCancellation(ctx, ethClient, params.ChainId, params.PrivateKey, params.Maker, seriesNonceManager, data, "Cancel All Orders", params.WaitOptions)
+	if err != nil {
+		return result, err
+	}
+	result.Nonce = new(big.Int).Add(nonce, big.NewInt(int64(params.NonceIncrement)))
+	return result, nil
+}
+
+// sendCancellation simulates a cancellation from the maker before sending it, so orders that are already filled or canceled
+// and keys that do not belong to the maker are caught without spending gas
+func (s *OrderbookService) sendCancellation(ctx context.Context, ethClient *ethclient.Client, chainId int, privateKey string, maker string, to string, data []byte, description string, waitOptions onchain.WaitOptions) (*models.CancelResult, error) {
+	key, err := crypto.HexToECDSA(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert private key: %v", err)
+	}
+	makerAddress := common.HexToAddress(maker)
+	if crypto.PubkeyToAddress(key.PublicKey) != makerAddress {
+		return nil, fmt.Errorf("maker does not match private key")
+	}
+
+	toAddress := common.HexToAddress(to)
+	_, err = ethClient.CallContract(ctx, ethereum.CallMsg{From: makerAddress, To: &toAddress, Data: data}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("cancellation would revert: %v", err)
+	}
+
+	receipt, err := onchain.ExecuteTransaction(ctx, onchain.TxConfig{
+		Description:   description,
+		PublicAddress: makerAddress,
+		PrivateKey:    privateKey,
+		ChainId:       big.NewInt(int64(chainId)),
+		Value:         big.NewInt(0),
+		To:            to,
+		Data:          data,
+		HeadWatcher:   s.client.getHeadWatcher(chainId),
+		Broadcaster:   s.client.getBroadcaster(chainId),
+		WaitOptions:   waitOptions,
+	}, ethClient, s.client.NonceCache)
+	if err != nil {
+		// The receipt of a reverted cancellation is still returned so its hash is not lost
+		var result *models.CancelResult
+		if receipt != nil {
+			result = &models.CancelResult{TxHash: receipt.TxHash.Hex(), Receipt: receipt}
+		}
+		return result, fmt.Errorf("failed to execute transaction: %w", err)
+	}
+	return &models.CancelResult{TxHash: receipt.TxHash.Hex(), Receipt: receipt}, nil
+}
+
+// FillOrder fills the limit order of another maker as the taker, paying the taker asset and receiving the maker asset
+// The router is allowed to spend the taker asset through a permit when the token supports one, or through an approval
+// otherwise. The fill is simulated before it is sent, and the result holds the amounts the simulation returned
+func (s *OrderbookService) FillOrder(ctx context.Context, params models.FillOrderParams) (*models.FillResult, error) {
+	err := params.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	aggregationRouter, err := contracts.Get1inchRouterFromChainId(params.ChainId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get 1inch router address: %v", err)
+	}
+	aggregationRouterAddress := common.HexToAddress(aggregationRouter)
+
+	ethClient, err := s.client.GetEthClient(params.ChainId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get eth client: %v", err)
+	}
+
+	privateKey, err := crypto.HexToECDSA(params.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert private key: %v", err)
+	}
+	takerAddress := common.HexToAddress(params.Taker)
+	if crypto.PubkeyToAddress(privateKey.PublicKey) != takerAddress {
+		return nil, fmt.Errorf("taker does not match private key")
+	}
+
+	config := orderbook.FillOrderConfig{
+		Order:     params.Order.Data,
+		Signature: params.Order.Signature,
+	}
+	config.Threshold, err = helpers.BigIntFromString(params.Threshold)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert threshold to big.Int: %v", err)
+	}
+	// The taker pays at most the threshold when filling a making amount
+	var requiredTakingAmount *big.Int
+	if params.MakingAmount != "" {
+		config.MakingAmount, err = helpers.BigIntFromString(params.MakingAmount)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert making amount to big.Int: %v", err)
+		}
+		requiredTakingAmount = config.Threshold
+	} else {
+		config.TakingAmount, err = helpers.BigIntFromString(params.TakingAmount)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert taking amount to big.Int: %v", err)
+		}
+		requiredTakingAmount = config.TakingAmount
+	}
+
+	// fillOrder always sends the maker asset to the taker, fillOrderTo is only needed for another receiver or a permit
+	target := takerAddress
+	if params.Receiver != "" {
+		target = common.HexToAddress(params.Receiver)
+	}
+	if target != takerAddress {
+		config.Target = target
+	}
+
+	approvalAmount, err := params.ApprovalPolicy.GetApprovalAmount(requiredTakingAmount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get approval amount: %v", err)
+	}
+
+	// The fill is checked against the policy at its worst price before the approval it may need is sent
+	if s.client.policy != nil {
+		minMakingAmount := config.MakingAmount
+		if minMakingAmount == nil {
+			minMakingAmount = config.Threshold
+		}
+		summary, err := newFillSummary(ethClient, params, minMakingAmount, requiredTakingAmount, "")
+		if err != nil {
+			return nil, err
+		}
+		err = s.client.checkPolicy(ctx, summary)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result := &models.FillResult{}
+	takerAsset := params.Order.Data.TakerAsset
+	takerAssetAddress := common.HexToAddress(takerAsset)
+
+	allowance, err := onchain.ReadContractAllowance(ethClient, takerAssetAddress, takerAddress, aggregationRouterAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read allowance: %v", err)
+	}
+	if allowance.Cmp(requiredTakingAmount) < 0 {
+		if params.ApprovalType == onchain.PermitAlways || (params.ApprovalType != onchain.ApprovalAlways && onchain.ShouldUsePermit(ethClient, params.ChainId, takerAsset)) {
+			permit, err := onchain.CreatePermit(&onchain.CreatePermitConfig{
+				EthClient:     ethClient,
+				MakerAsset:    takerAsset,
+				PublicAddress: takerAddress,
+				ChainId:       params.ChainId,
+				PrivateKey:    params.PrivateKey,
+				Deadline:      onchain.ResolveDeadline(time.Now(), params.PermitDeadline, s.client.permitDeadline),
+				Value:         approvalAmount,
+			})
+			if err != nil {
+				if params.ApprovalType == onchain.PermitAlways {
+					return nil, fmt.Errorf("failed to create permit: %v", err)
+				}
+				permit = "0x"
+			}
+			if permit != "0x" {
+				// The router reads the token the permit is for from its first 20 bytes
+				config.Permit = takerAsset + orderbook.Trim0x(permit)
+				config.Target = target
+				result.UsedPermit = true
+			}
+		}
+
+		if !result.UsedPermit {
+			if s.client.needsSummary(params.SkipWarnings) {
+				takerToken, err := readTokenInfo(ethClient, takerAsset)
+				if err != nil {
+					return nil, err
+				}
+				ok, err := s.client.confirm(ctx, &models.Summary{
+					Kind:        models.SummaryApproval,
+					ApprovalFor: models.SummaryFillOrder,
+					ChainId:     params.ChainId,
+					Wallet:      params.Taker,
+					Approval:    newApprovalSummaryAmount(takerToken, approvalAmount),
+				}, params.SkipWarnings)
+				if err != nil {
+					return nil, fmt.Errorf("failed to confirm approval: %w", err)
+				}
+				if !ok {
+					return nil, errors.New("user rejected approval")
+				}
+			}
+
+			receipt, err := onchain.ApproveTokenForRouter(ctx, ethClient, s.client.NonceCache, onchain.Erc20ApprovalConfig{
+				ChainId:        params.ChainId,
+				Key:            params.PrivateKey,
+				Erc20Address:   takerAssetAddress,
+				PublicAddress:  takerAddress,
+				SpenderAddress: aggregationRouterAddress,
+				Amount:         approvalAmount,
+				HeadWatcher:    s.client.getHeadWatcher(params.ChainId),
+				Broadcaster:    s.client.getBroadcaster(params.ChainId),
+				WaitOptions:    params.WaitOptions,
+			})
+			if receipt != nil {
+				result.ApprovalTxHash = receipt.TxHash.Hex()
+			}
+			if err != nil {
+				return result, fmt.Errorf("failed to approve token for router: %w", err)
+			}
+		}
+	}
+
+	method, data, err := orderbook.GetFillOrderCalldata(config)
+	if err != nil {
+		return result, fmt.Errorf("failed to get fill order calldata: %v", err)
+	}
+	result.Method = method
+
+	// The simulation catches orders that are filled, canceled, expired or priced beyond the threshold without spending gas
+	output, err := ethClient.CallContract(ctx, ethereum.CallMsg{From: takerAddress, To: &aggregationRouterAddress, Data: data}, nil)
+	if err != nil {
+		return result, fmt.Errorf("fill would revert: %v", err)
+	}
+	makingAmount, takingAmount, orderHash, err := orderbook.DecodeFillOrderResult(method, output)
+	if err != nil {
+		return result, err
+	}
+	result.MakingAmount = makingAmount
+	result.TakingAmount = takingAmount
+	result.OrderHash = orderHash.Hex()
+
+	var reservation *policy.Reservation
+	if s.client.needsSummary(params.SkipWarnings) {
+		summary, err := newFillSummary(ethClient, params, makingAmount, takingAmount, result.OrderHash)
+		if err != nil {
+			return result, err
+		}
+		var ok bool
+		reservation, ok, err = s.client.confirmTrade(ctx, summary, params.SkipWarnings)
+		if err != nil {
+			return result, fmt.Errorf("failed to confirm fill: %w", err)
+		}
+		if !ok {
+			return result, errors.New("user rejected fill")
+		}
+	}
+
+	receipt, err := onchain.ExecuteTransaction(ctx, onchain.TxConfig{
+		Description:   "Fill Order",
+		PublicAddress: takerAddress,
+		PrivateKey:    params.PrivateKey,
+		ChainId:       big.NewInt(int64(params.ChainId)),
+		Value:         big.NewInt(0),
+		To:            aggregationRouter,
+		Data:          data,
+		HeadWatcher:   s.client.getHeadWatcher(params.ChainId),
+		Broadcaster:   s.client.getBroadcaster(params.ChainId),
+		WaitOptions:   params.WaitOptions,
+	}, ethClient, s.client.NonceCache)
+	settleTrade(reservation, receipt, err)
+	if receipt != nil {
+		result.TxHash = receipt.TxHash.Hex()
+		result.Receipt = receipt
+	}
+	if err != nil {
+		return result, fmt.Errorf("failed to execute transaction: %w", err)
+	}
+	return result, nil
+}
+
 // TODO Reusing the same request/response objects due to bad swagger spec
 
 // GetOrdersByCreatorAddress returns all orders created by a given address in the Limit Order Protocol
@@ -338,3 +896,19 @@ func (s *OrderbookService) GetActiveOrdersWithPermit(ctx context.Context, params
 
 	return orders, res, nil
 }
+
+// newFillSummary summarizes the fill of an order from the side of the taker, who sells what the maker buys
+func newFillSummary(ethClient *ethclient.Client, params models.FillOrderParams, makingAmount *big.Int, takingAmount *big.Int, orderHash string) (*models.Summary, error) {
+	summary, err := newLimitOrderSummary(ethClient, params.ChainId, &models.Order{Data: params.Order.Data}, nil)
+	if err != nil {
+		return nil, err
+	}
+	summary.Kind = models.SummaryFillOrder
+	summary.Wallet = params.Taker
+	summary.Selling, summary.Buying = summary.Buying, summary.Selling
+	summary.Selling.Amount = takingAmount
+	summary.Buying.Amount = makingAmount
+	summary.Receiver = params.Receiver
+	summary.OrderHash = orderHash
+	return summary, nil
+}
@@ -1,19 +1,35 @@
 package client
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math/big"
 	"net/http"
+	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/1inch/1inch-sdk-go/client/models"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/1inch/1inch-sdk-go/helpers/consts/abis"
 	"github.com/1inch/1inch-sdk-go/helpers/consts/addresses"
+	"github.com/1inch/1inch-sdk-go/helpers/consts/amounts"
 	"github.com/1inch/1inch-sdk-go/helpers/consts/chains"
+	"github.com/1inch/1inch-sdk-go/helpers/consts/contracts"
 	"github.com/1inch/1inch-sdk-go/helpers/consts/tokens"
+	"github.com/1inch/1inch-sdk-go/internal/onchain"
+	"github.com/1inch/1inch-sdk-go/internal/orderbook"
 )
 
 func TestCreateOrder(t *testing.T) {
@@ -408,3 +424,400 @@ func TestGetEvents(t *testing.T) {
 //		})
 //	}
 //}
+
+// newOrderTestServer serves a Polygon node that answers eth_call with the call function, reverting when it returns an
+// error, and mines every transaction it receives in block 10
+func newOrderTestServer(t *testing.T, call func(input []byte) ([]byte, error), sentTx **types.Transaction) *httptest.Server {
+	header := &types.Header{Number: big.NewInt(10), Difficulty: big.NewInt(0)}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Id     json.RawMessage   `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+
+		response := map[string]interface{}{"jsonrpc": "2.0", "id": request.Id}
+		switch request.Method {
+		case "eth_chainId":
+			response["result"] = hexutil.Uint64(chains.Polygon)
+		case "eth_call":
+			var callMsg struct {
+				Input hexutil.Bytes `json:"input"`
+			}
+			require.NoError(t, json.Unmarshal(request.Params[0], &callMsg))
+			output, err := call(callMsg.Input)
+			if err != nil {
+				response["error"] = map[string]interface{}{"code": 3, "message": err.Error()}
+			} else {
+				response["result"] = hexutil.Bytes(output)
+			}
+		case "eth_getTransactionCount":
+			response["result"] = hexutil.Uint64(5)
+		case "eth_maxPriorityFeePerGas", "eth_gasPrice":
+			response["result"] = (*hexutil.Big)(big.NewInt(30e9))
+		case "eth_getBlockByNumber":
+			response["result"] = header
+		case "eth_blockNumber":
+			response["result"] = hexutil.Uint64(10)
+		case "eth_sendRawTransaction":
+			var rawTx hexutil.Bytes
+			require.NoError(t, json.Unmarshal(request.Params[0], &rawTx))
+			*sentTx = new(types.Transaction)
+			require.NoError(t, (*sentTx).UnmarshalBinary(rawTx))
+			response["result"] = (*sentTx).Hash()
+		case "eth_getTransactionReceipt":
+			response["result"] = &types.Receipt{
+				Status:      types.ReceiptStatusSuccessful,
+				TxHash:      (*sentTx).Hash(),
+				BlockNumber: header.Number,
+				BlockHash:   header.Hash(),
+				Logs:        []*types.Log{},
+			}
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(response))
+	}))
+}
+
+// seriesNonceCall answers the series nonce manager with a nonce of 3 and any other call with the fallback
+func seriesNonceCall(fallback func(input []byte) ([]byte, error)) func(input []byte) ([]byte, error) {
+	nonceSelector := crypto.Keccak256([]byte("nonce(uint256,address)"))[:4]
+	return func(input []byte) ([]byte, error) {
+		if bytes.HasPrefix(input, nonceSelector) {
+			return common.LeftPadBytes([]byte{3}, 32), nil
+		}
+		return fallback(input)
+	}
+}
+
+func TestCancelAllOrders(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	var sentTx *types.Transaction
+	server := newOrderTestServer(t, seriesNonceCall(func(input []byte) ([]byte, error) {
+		return nil, nil
+	}), &sentTx)
+	defer server.Close()
+
+	c, err := NewClient(models.ClientConfig{
+		DevPortalApiKey:   "abc123",
+		Web3HttpProviders: []models.Web3Provider{{ChainId: chains.Polygon, Url: server.URL}},
+	})
+	require.NoError(t, err)
+	defer c.Close()
+
+	result, err := c.OrderbookApi.CancelAllOrders(context.Background(), models.CancelAllOrdersParams{
+		ChainId:        chains.Polygon,
+		PrivateKey:     hexutil.Encode(crypto.FromECDSA(privateKey))[2:],
+		Maker:          crypto.PubkeyToAddress(privateKey.PublicKey).Hex(),
+		NonceIncrement: 2,
+		SkipWarnings:   true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, sentTx.Hash().Hex(), result.TxHash)
+	require.Equal(t, big.NewInt(5), result.Nonce)
+
+	expectedData, err := orderbook.GetAdvanceNonceCalldata(2)
+	require.NoError(t, err)
+	require.Equal(t, common.HexToAddress(contracts.SeriesNonceManagerPolygon), *sentTx.To())
+	require.Equal(t, expectedData, sentTx.Data())
+}
+
+func TestCancelOrderPreflight(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	maker := crypto.PubkeyToAddress(privateKey.PublicKey).Hex()
+
+	var sentTx *types.Transaction
+	server := newOrderTestServer(t, seriesNonceCall(func(input []byte) ([]byte, error) {
+		return nil, errors.New("execution reverted")
+	}), &sentTx)
+	defer server.Close()
+
+	c, err := NewClient(models.ClientConfig{
+		DevPortalApiKey:   "abc123",
+		Web3HttpProviders: []models.Web3Provider{{ChainId: chains.Polygon, Url: server.URL}},
+	})
+	require.NoError(t, err)
+	defer c.Close()
+
+	params := models.CancelOrderParams{
+		ChainId:    chains.Polygon,
+		PrivateKey: hexutil.Encode(crypto.FromECDSA(privateKey))[2:],
+		Maker:      maker,
+		Order: models.OrderData{
+			MakerAsset:    tokens.PolygonWeth,
+			TakerAsset:    tokens.PolygonDai,
+			MakingAmount:  "1000000000000000000",
+			TakingAmount:  "3000000000000000000000",
+			Salt:          "1700000000000",
+			Maker:         maker,
+			AllowedSender: addresses.Zero,
+			Receiver:      addresses.Zero,
+			Offsets:       "0",
+			Interactions:  "0x",
+		},
+		SkipWarnings: true,
+	}
+
+	_, err = c.OrderbookApi.CancelOrder(context.Background(), params)
+	require.EqualError(t, err, "cancellation would revert: execution reverted")
+	require.Nil(t, sentTx)
+
+	params.PrivateKey = "a0b1c2d3e4f5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b1"
+	_, err = c.OrderbookApi.CancelOrder(context.Background(), params)
+	require.EqualError(t, err, "maker does not match private key")
+}
+
+func TestFillOrder(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	taker := crypto.PubkeyToAddress(privateKey.PublicKey).Hex()
+	receiver := "0x2222222222222222222222222222222222222222"
+
+	parsedABI, err := abi.JSON(strings.NewReader(abis.AggregationRouterV5))
+	require.NoError(t, err)
+	allowanceSelector := crypto.Keccak256([]byte("allowance(address,address)"))[:4]
+	orderHash := common.HexToHash("0xabcdef")
+
+	order := models.OrderResponse{
+		Signature: "0x1234",
+		Data: models.OrderData{
+			MakerAsset:    tokens.PolygonWeth,
+			TakerAsset:    tokens.PolygonDai,
+			MakingAmount:  "1000000000000000000",
+			TakingAmount:  "3000000000000000000000",
+			Salt:          "1700000000000",
+			Maker:         "0x1111111111111111111111111111111111111111",
+			AllowedSender: addresses.Zero,
+			Receiver:      addresses.Zero,
+			Offsets:       "0",
+			Interactions:  "0x",
+		},
+	}
+
+	testcases := []struct {
+		description    string
+		fillReverts    bool
+		expectedMethod string
+		expectedError  string
+	}{
+		{
+			description:    "Fill for a receiver",
+			expectedMethod: "fillOrderTo",
+		},
+		{
+			description:   "Fill reverting in the simulation",
+			fillReverts:   true,
+			expectedError: "fill would revert: execution reverted",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.description, func(t *testing.T) {
+			var sentTx *types.Transaction
+			server := newOrderTestServer(t, func(input []byte) ([]byte, error) {
+				if bytes.HasPrefix(input, allowanceSelector) {
+					return common.LeftPadBytes(amounts.BigMaxUint256.Bytes(), 32), nil
+				}
+				if tc.fillReverts {
+					return nil, errors.New("execution reverted")
+				}
+				method, err := parsedABI.MethodById(input[:4])
+				require.NoError(t, err)
+				return method.Outputs.Pack(big.NewInt(5e17), new(big.Int).Mul(big.NewInt(1500), big.NewInt(1e18)), orderHash)
+			}, &sentTx)
+			defer server.Close()
+
+			c, err := NewClient(models.ClientConfig{
+				DevPortalApiKey:   "abc123",
+				Web3HttpProviders: []models.Web3Provider{{ChainId: chains.Polygon, Url: server.URL}},
+			})
+			require.NoError(t, err)
+			defer c.Close()
+
+			result, err := c.OrderbookApi.FillOrder(context.Background(), models.FillOrderParams{
+				ChainId:      chains.Polygon,
+				PrivateKey:   hexutil.Encode(crypto.FromECDSA(privateKey))[2:],
+				Taker:        taker,
+				Order:        order,
+				MakingAmount: "500000000000000000",
+				Threshold:    "1600000000000000000000",
+				Receiver:     receiver,
+				SkipWarnings: true,
+			})
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				require.Nil(t, sentTx)
+				return
+			}
+			require.NoError(t, err)
+
+			require.Equal(t, tc.expectedMethod, result.Method)
+			require.Empty(t, result.ApprovalTxHash)
+			require.False(t, result.UsedPermit)
+			require.Equal(t, big.NewInt(5e17), result.MakingAmount)
+			require.Equal(t, new(big.Int).Mul(big.NewInt(1500), big.NewInt(1e18)), result.TakingAmount)
+			require.Equal(t, orderHash.Hex(), result.OrderHash)
+			require.Equal(t, sentTx.Hash().Hex(), result.TxHash)
+
+			_, expectedData, err := orderbook.GetFillOrderCalldata(orderbook.FillOrderConfig{
+				Order:        order.Data,
+				Signature:    order.Signature,
+				MakingAmount: big.NewInt(5e17),
+				Threshold:    new(big.Int).Mul(big.NewInt(1600), big.NewInt(1e18)),
+				Target:       common.HexToAddress(receiver),
+			})
+			require.NoError(t, err)
+			require.Equal(t, expectedData, sentTx.Data())
+			require.Equal(t, common.HexToAddress(contracts.AggregationRouterV5), *sentTx.To())
+		})
+	}
+}
+
+// unapprovedTokenCall answers eth_call for tokens the wallet holds plenty of but has not approved the router for
+// Every other call, including the permit getters, reverts
+func unapprovedTokenCall(t *testing.T) func(input []byte) ([]byte, error) {
+	parsedABI, err := abi.JSON(strings.NewReader(abis.Erc20))
+	require.NoError(t, err)
+	return func(input []byte) ([]byte, error) {
+		method, err := parsedABI.MethodById(input[:4])
+		if err != nil {
+			return nil, errors.New("execution reverted")
+		}
+		switch method.Name {
+		case "allowance":
+			return method.Outputs.Pack(big.NewInt(0))
+		case "balanceOf":
+			return method.Outputs.Pack(new(big.Int).Mul(big.NewInt(1e18), big.NewInt(1e6)))
+		case "symbol":
+			return method.Outputs.Pack("TKN")
+		case "decimals":
+			return method.Outputs.Pack(uint8(18))
+		}
+		return nil, errors.New("execution reverted")
+	}
+}
+
+func TestCreateOrderPermitAlwaysWithoutPermitSupport(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	wallet := crypto.PubkeyToAddress(privateKey.PublicKey).Hex()
+
+	var sentTx *types.Transaction
+	server := newOrderTestServer(t, unapprovedTokenCall(t), &sentTx)
+	defer server.Close()
+
+	c, err := NewClient(models.ClientConfig{
+		DevPortalApiKey:   "abc123",
+		Web3HttpProviders: []models.Web3Provider{{ChainId: chains.Polygon, Url: server.URL}},
+	})
+	require.NoError(t, err)
+	defer c.Close()
+
+	// The token has no permit, so the order must not fall back to an approval
+	_, _, err = c.OrderbookApi.CreateOrder(context.Background(), models.CreateOrderParams{
+		ChainId:                        chains.Polygon,
+		PrivateKey:                     hexutil.Encode(crypto.FromECDSA(privateKey))[2:],
+		Maker:                          wallet,
+		MakerAsset:                     tokens.PolygonWeth,
+		TakerAsset:                     tokens.PolygonDai,
+		MakingAmount:                   "1000000000000000000",
+		TakingAmount:                   "3000000000000000000000",
+		ApprovalType:                   onchain.PermitAlways,
+		EnableOnchainApprovalsIfNeeded: true,
+		SkipWarnings:                   true,
+	})
+	require.ErrorContains(t, err, "failed to create permit: token does not support permits")
+	require.Nil(t, sentTx)
+}
+
+func TestPolicyDeniedOrderSendsNoTransactions(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	wallet := crypto.PubkeyToAddress(privateKey.PublicKey).Hex()
+	walletKey := hexutil.Encode(crypto.FromECDSA(privateKey))[2:]
+
+	call := unapprovedTokenCall(t)
+
+	testcases := []struct {
+		description string
+		action      func(c *Client) error
+	}{
+		{
+			description: "Limit order",
+			action: func(c *Client) error {
+				_, _, err := c.OrderbookApi.CreateOrder(context.Background(), models.CreateOrderParams{
+					ChainId:                        chains.Polygon,
+					PrivateKey:                     walletKey,
+					Maker:                          wallet,
+					MakerAsset:                     tokens.PolygonWeth,
+					TakerAsset:                     tokens.PolygonDai,
+					MakingAmount:                   "1000000000000000000",
+					TakingAmount:                   "3000000000000000000000",
+					ApprovalType:                   onchain.ApprovalAlways,
+					EnableOnchainApprovalsIfNeeded: true,
+					SkipWarnings:                   true,
+				})
+				return err
+			},
+		},
+		{
+			description: "Fill",
+			action: func(c *Client) error {
+				_, err := c.OrderbookApi.FillOrder(context.Background(), models.FillOrderParams{
+					ChainId:    chains.Polygon,
+					PrivateKey: walletKey,
+					Taker:      wallet,
+					Order: models.OrderResponse{
+						Signature: "0x1234",
+						Data: models.OrderData{
+							MakerAsset:    tokens.PolygonDai,
+							TakerAsset:    tokens.PolygonWeth,
+							MakingAmount:  "3000000000000000000000",
+							TakingAmount:  "1000000000000000000",
+							Salt:          "1700000000000",
+							Maker:         "0x1111111111111111111111111111111111111111",
+							AllowedSender: addresses.Zero,
+							Receiver:      addresses.Zero,
+							Offsets:       "0",
+							Interactions:  "0x",
+						},
+					},
+					MakingAmount: "3000000000000000000000",
+					Threshold:    "1000000000000000000",
+					ApprovalType: onchain.ApprovalAlways,
+					SkipWarnings: true,
+				})
+				return err
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.description, func(t *testing.T) {
+			var sentTx *types.Transaction
+			server := newOrderTestServer(t, call, &sentTx)
+			defer server.Close()
+
+			// Approving WETH is allowed, trading for DAI is not
+			c, err := NewClient(models.ClientConfig{
+				DevPortalApiKey:   "abc123",
+				Web3HttpProviders: []models.Web3Provider{{ChainId: chains.Polygon, Url: server.URL}},
+				Policy: &models.Policy{Chains: map[int]models.ChainPolicy{
+					chains.Polygon: {DeniedTokens: []string{tokens.PolygonDai}, AllowUnlimitedApprovals: true},
+				}},
+			})
+			require.NoError(t, err)
+			defer c.Close()
+
+			err = tc.action(c)
+			var policyError *models.PolicyError
+			require.ErrorAs(t, err, &policyError)
+			require.Equal(t, models.PolicyRuleDeniedTokens, policyError.Violations[0].Rule)
+			require.Nil(t, sentTx)
+		})
+	}
+}
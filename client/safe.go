@@ -0,0 +1,242 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/1inch/1inch-sdk-go/client/models"
+	"github.com/1inch/1inch-sdk-go/helpers"
+	"github.com/1inch/1inch-sdk-go/helpers/consts/amounts"
+	"github.com/1inch/1inch-sdk-go/helpers/consts/contracts"
+	"github.com/1inch/1inch-sdk-go/helpers/consts/tokens"
+	"github.com/1inch/1inch-sdk-go/internal/onchain"
+	"github.com/1inch/1inch-sdk-go/internal/safe"
+	"github.com/1inch/1inch-sdk-go/internal/swap"
+)
+
+// This file provides helper functions that emit transactions as Safe (Gnosis Safe) transactions instead of sending them from a wallet.
+
+type SafeService service
+
+// BuildTransaction returns a Safe transaction making the calls, batched through the MultiSendCallOnly contract when there are several
+// Any transaction, such as the approval of the maker asset of a limit order, can be turned into a Safe transaction this way
+func (s *SafeService) BuildTransaction(ctx context.Context, params models.BuildSafeTransactionParams) (*models.SafeTransaction, error) {
+	err := params.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.buildSafeTransaction(ctx, params.ChainId, params.Safe, params.Description, params.Calls, params.Nonce)
+}
+
+// BuildSwapTransaction returns the approval (when the router lacks allowance), the swap and the revocation (when the approval
+// policy asks for one) of a swap generated by GetSwap as a single batched Safe transaction
+// The swap has to be generated with the Safe as the sender
+func (s *SafeService) BuildSwapTransaction(ctx context.Context, params models.BuildSafeSwapTransactionParams) (*models.SafeTransaction, error) {
+	err := params.Validate()
+	if err != nil {
+		return nil, err
+	}
+	config := params.Swap
+
+	if config.IsPermitSwap {
+		return nil, fmt.Errorf("safes cannot sign permits, the swap must use an approval")
+	}
+
+	ethClient, err := s.client.GetEthClient(config.ChainId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get eth client: %v", err)
+	}
+
+	aggregationRouter, err := contracts.Get1inchRouterFromChainId(config.ChainId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get 1inch router address: %v", err)
+	}
+
+	// Verify the swap data matches the requested swap before anything is handed over for signing
+	_, err = swap.GuardSwapTransaction(config, aggregationRouter)
+	if err != nil {
+		return nil, fmt.Errorf("swap transaction rejected: %w", err)
+	}
+
+	// Gas is paid by whoever executes the Safe transaction, so only the balance of the Safe itself is checked
+	err = s.checkSafeSwapFunds(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	swapCalls, err := (*SwapService)(s).getSwapCalls(config, ethClient, aggregationRouter)
+	if err != nil {
+		return nil, err
+	}
+
+	calls := make([]models.SafeCall, 0, len(swapCalls))
+	for _, call := range swapCalls {
+		calls = append(calls, models.SafeCall{
+			Description: call.description,
+			To:          call.to,
+			Value:       call.value.String(),
+			Data:        hexutil.Encode(call.data),
+		})
+	}
+
+	description := fmt.Sprintf("Swap %s for %s", config.FromToken.Symbol, config.ToToken.Symbol)
+	return s.buildSafeTransaction(ctx, config.ChainId, config.PublicAddress, description, calls, params.Nonce)
+}
+
+// BuildApprovalTransaction returns an approval as a Safe transaction
+// The 1inch router is the default spender, it is used by both swaps and limit orders
+func (s *SafeService) BuildApprovalTransaction(ctx context.Context, params models.BuildSafeApprovalTransactionParams) (*models.SafeTransaction, error) {
+	err := params.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	spender := params.Spender
+	if spender == "" {
+		spender, err = contracts.Get1inchRouterFromChainId(params.ChainId)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get 1inch router address: %v", err)
+		}
+	}
+
+	amount := amounts.BigMaxUint256
+	if params.Amount != "" {
+		amount, err = helpers.BigIntFromString(params.Amount)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse amount: %v", err)
+		}
+	}
+
+	data, err := onchain.GetApproveCalldata(common.HexToAddress(spender), amount)
+	if err != nil {
+		return nil, err
+	}
+
+	calls := []models.SafeCall{{
+		Description: "Approval",
+		To:          params.Token,
+		Data:        hexutil.Encode(data),
+	}}
+	return s.buildSafeTransaction(ctx, params.ChainId, params.Safe, "Approval", calls, params.Nonce)
+}
+
+// ProposeTransaction signs a Safe transaction as one of the owners and posts it to a Safe Transaction Service,
+// where the other owners can confirm and execute it
+func (s *SafeService) ProposeTransaction(ctx context.Context, params models.ProposeSafeTransactionParams) error {
+	err := params.Validate()
+	if err != nil {
+		return err
+	}
+
+	ownerKey, err := crypto.HexToECDSA(params.OwnerKey)
+	if err != nil {
+		return fmt.Errorf("failed to convert private key: %v", err)
+	}
+
+	signature, err := safe.SignSafeTxHash(params.Transaction.SafeTxHash, params.OwnerKey)
+	if err != nil {
+		return err
+	}
+
+	request := safe.NewProposeTransactionRequest(params.Transaction.SafeTx, params.Transaction.SafeTxHash, crypto.PubkeyToAddress(ownerKey.PublicKey), signature, params.Origin)
+	err = safe.ProposeTransaction(ctx, s.client.httpClient, params.TransactionServiceUrl, params.Transaction.Safe, request)
+	if err != nil {
+		return fmt.Errorf("failed to propose safe transaction: %v", err)
+	}
+	return nil
+}
+
+// buildSafeTransaction batches the calls into a Safe transaction and computes its safeTxHash
+// The current nonce of the Safe is read onchain unless one is given
+func (s *SafeService) buildSafeTransaction(ctx context.Context, chainId int, safeAddress string, description string, calls []models.SafeCall, nonceParam string) (*models.SafeTransaction, error) {
+	safeCalls, err := models.ToSafeCalls(calls)
+	if err != nil {
+		return nil, err
+	}
+
+	var nonce *big.Int
+	if nonceParam != "" {
+		nonce, err = helpers.BigIntFromString(nonceParam)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse nonce: %v", err)
+		}
+	} else {
+		ethClient, err := s.client.GetEthClient(chainId)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get eth client: %v", err)
+		}
+		nonce, err = safe.ReadSafeNonce(ctx, ethClient, common.HexToAddress(safeAddress))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read safe nonce: %v", err)
+		}
+	}
+
+	multiSendCallOnly, err := contracts.GetMultiSendCallOnlyFromChainId(chainId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get multisend address: %v", err)
+	}
+
+	safeTx, err := safe.NewSafeTx(safeCalls, nonce, common.HexToAddress(multiSendCallOnly))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create safe transaction: %v", err)
+	}
+
+	if description == "" {
+		description = calls[0].Description
+	}
+
+	safeTransaction := &models.SafeTransaction{
+		Description: description,
+		ChainId:     big.NewInt(int64(chainId)),
+		Safe:        common.HexToAddress(safeAddress),
+		Calls:       calls,
+		SafeTx:      safeTx,
+	}
+	safeTransaction.SafeTxHash = safeTx.Hash(safeTransaction.ChainId, safeTransaction.Safe)
+	return safeTransaction, nil
+}
+
+// checkSafeSwapFunds makes sure the Safe holds the amount being swapped
+func (s *SafeService) checkSafeSwapFunds(ctx context.Context, config *models.ExecuteSwapConfig) error {
+	ethClient, err := s.client.GetEthClient(config.ChainId)
+	if err != nil {
+		return fmt.Errorf("failed to get eth client: %v", err)
+	}
+
+	amount, err := helpers.BigIntFromString(config.Amount)
+	if err != nil {
+		return fmt.Errorf("failed to convert amount to big.Int: %v", err)
+	}
+
+	safeAddress := common.HexToAddress(config.PublicAddress)
+	if config.FromToken.Address != tokens.NativeToken {
+		return onchain.CheckFunds(ctx, ethClient, onchain.PreflightConfig{
+			PublicAddress: safeAddress,
+			Token:         common.HexToAddress(config.FromToken.Address),
+			TokenSymbol:   config.FromToken.Symbol,
+			TokenDecimals: uint8(config.FromToken.Decimals),
+			Amount:        amount,
+		})
+	}
+
+	balance, err := ethClient.BalanceAt(ctx, safeAddress, nil)
+	if err != nil {
+		return fmt.Errorf("failed to read balance of %s: %v", config.FromToken.Symbol, err)
+	}
+	if balance.Cmp(amount) < 0 {
+		return &onchain.InsufficientFundsError{
+			Token:     tokens.NativeToken,
+			Symbol:    config.FromToken.Symbol,
+			Decimals:  uint8(config.FromToken.Decimals),
+			Required:  amount,
+			Available: balance,
+		}
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/1inch/1inch-sdk-go/client/models"
+	"github.com/1inch/1inch-sdk-go/helpers/consts/chains"
+	"github.com/1inch/1inch-sdk-go/helpers/consts/contracts"
+	"github.com/1inch/1inch-sdk-go/helpers/consts/tokens"
+	"github.com/1inch/1inch-sdk-go/internal/safe"
+)
+
+func TestSafeTransactionProposal(t *testing.T) {
+	ownerKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	owner := crypto.PubkeyToAddress(ownerKey.PublicKey)
+	safeAddress := common.HexToAddress("0x2a5f0c2c4d3c38e0b0d2b3f8b0d4d8a0f1e6c9b7")
+
+	// The stub provider is a Polygon node where the Safe is about to execute its transaction with nonce 9
+	rpcServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Id     json.RawMessage   `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+
+		response := map[string]interface{}{"jsonrpc": "2.0", "id": request.Id}
+		switch request.Method {
+		case "eth_chainId":
+			response["result"] = hexutil.Uint64(chains.Polygon)
+		case "eth_call":
+			var call struct {
+				To common.Address `json:"to"`
+			}
+			require.NoError(t, json.Unmarshal(request.Params[0], &call))
+			require.Equal(t, safeAddress, call.To)
+			response["result"] = hexutil.Encode(common.LeftPadBytes([]byte{9}, 32))
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(response))
+	}))
+	defer rpcServer.Close()
+
+	var proposal safe.ProposeTransactionRequest
+	serviceServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v1/safes/"+safeAddress.Hex()+"/multisig-transactions/", r.URL.Path)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&proposal))
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer serviceServer.Close()
+
+	c, err := NewClient(models.ClientConfig{
+		DevPortalApiKey:   "abc123",
+		Web3HttpProviders: []models.Web3Provider{{ChainId: chains.Polygon, Url: rpcServer.URL}},
+	})
+	require.NoError(t, err)
+	defer c.Close()
+
+	approval, err := c.Safe.BuildApprovalTransaction(context.Background(), models.BuildSafeApprovalTransactionParams{
+		ChainId: chains.Polygon,
+		Safe:    safeAddress.Hex(),
+		Token:   tokens.PolygonDai,
+		Amount:  "1000",
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(9), approval.SafeTx.Nonce.Int64())
+	require.Equal(t, common.HexToAddress(tokens.PolygonDai), approval.SafeTx.To)
+	require.Equal(t, safe.Call, approval.SafeTx.Operation)
+
+	batch, err := c.Safe.BuildTransaction(context.Background(), models.BuildSafeTransactionParams{
+		ChainId:     chains.Polygon,
+		Safe:        safeAddress.Hex(),
+		Description: "Approve and fund",
+		Calls: append(approval.Calls, models.SafeCall{
+			Description: "Transfer",
+			To:          owner.Hex(),
+			Value:       "5",
+		}),
+		Nonce: "12",
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(12), batch.SafeTx.Nonce.Int64())
+	require.Equal(t, common.HexToAddress(contracts.MultiSendCallOnly), batch.SafeTx.To)
+	require.Equal(t, safe.DelegateCall, batch.SafeTx.Operation)
+	require.Equal(t, batch.SafeTx.Hash(big.NewInt(chains.Polygon), safeAddress), batch.SafeTxHash)
+
+	builderJson, err := batch.TransactionBuilderJson()
+	require.NoError(t, err)
+	var builderBatch safe.TransactionBuilderBatch
+	require.NoError(t, json.Unmarshal(builderJson, &builderBatch))
+	require.Equal(t, "137", builderBatch.ChainId)
+	require.Equal(t, "Approve and fund", builderBatch.Meta.Name)
+	require.Len(t, builderBatch.Transactions, 2)
+	require.Equal(t, "5", builderBatch.Transactions[1].Value)
+
+	err = c.Safe.ProposeTransaction(context.Background(), models.ProposeSafeTransactionParams{
+		TransactionServiceUrl: serviceServer.URL,
+		Transaction:           batch,
+		OwnerKey:              hexutil.Encode(crypto.FromECDSA(ownerKey))[2:],
+		Origin:                "1inch SDK",
+	})
+	require.NoError(t, err)
+	require.Equal(t, batch.SafeTxHash.Hex(), proposal.ContractTransactionHash)
+	require.Equal(t, owner.Hex(), proposal.Sender)
+	require.Equal(t, "12", proposal.Nonce)
+	require.Equal(t, uint8(safe.DelegateCall), proposal.Operation)
+
+	// The signature has to recover to the owner proposing the transaction
+	signature := hexutil.MustDecode(proposal.Signature)
+	signature[64] -= 27
+	signer, err := crypto.SigToPub(batch.SafeTxHash.Bytes(), signature)
+	require.NoError(t, err)
+	require.Equal(t, owner, crypto.PubkeyToAddress(*signer))
+}
@@ -12,15 +12,18 @@ import (
 
 	"github.com/1inch/1inch-sdk-go/client/models"
 	"github.com/1inch/1inch-sdk-go/helpers"
-	"github.com/1inch/1inch-sdk-go/helpers/consts/amounts"
+	"github.com/1inch/1inch-sdk-go/helpers/consts/addresses"
 	"github.com/1inch/1inch-sdk-go/helpers/consts/chains"
 	"github.com/1inch/1inch-sdk-go/helpers/consts/contracts"
 	"github.com/1inch/1inch-sdk-go/helpers/consts/tokens"
 	"github.com/1inch/1inch-sdk-go/internal/onchain"
+	"github.com/1inch/1inch-sdk-go/internal/policy"
 	"github.com/1inch/1inch-sdk-go/internal/swap"
 	"github.com/1inch/1inch-sdk-go/internal/tenderly"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
 )
@@ -29,11 +32,18 @@ import (
 
 type ActionService service
 
-// swapTokens is a helper function that executes swaps onchain from within the SDK
+// priceImpactReferenceDivisor sets the size of the quote the rate of a swap is compared with to get its price impact
+const priceImpactReferenceDivisor = 1000
+
+// SwapTokens quotes, approves or permits, and executes a swap onchain in a single call
+// The swap is aborted before anything is signed when its estimated gas cost or price impact exceeds the limits of the params,
+// both are also shown in the confirmation summary
 // If you would like to manage this transaction data yourself, please use the GetSwap method on the main Swap service instead
-//
-// NOTE: due to high gas costs, this method has been temporarily made private until gas configurations and summaries are put in place to protect large unexpected transaction fees
-func (s *ActionService) swapTokens(ctx context.Context, params models.SwapTokensParams) error {
+func (s *ActionService) SwapTokens(ctx context.Context, params models.SwapTokensParams) (*models.ExecutionResult, error) {
+	err := params.Validate()
+	if err != nil {
+		return nil, err
+	}
 
 	// Always disable estimate so we can do onchain approvals for the swaps right before we execute
 	params.DisableEstimate = true
@@ -41,39 +51,46 @@ func (s *ActionService) swapTokens(ctx context.Context, params models.SwapTokens
 	// TODO find a better way of managing the matching between public and private keys
 	privateKey, err := crypto.HexToECDSA(params.WalletKey)
 	if err != nil {
-		return fmt.Errorf("failed to convert private key: %v", err)
+		return nil, fmt.Errorf("failed to convert private key: %v", err)
 	}
 
 	publicKey := privateKey.Public()
 	publicKeyECDSA, ok := publicKey.(*ecdsa.PublicKey)
 	if !ok {
-		return fmt.Errorf("could not cast public key to ECDSA")
+		return nil, fmt.Errorf("could not cast public key to ECDSA")
 	}
 
 	derivedPublicAddress := crypto.PubkeyToAddress(*publicKeyECDSA)
 
 	if strings.ToLower(derivedPublicAddress.Hex()) != strings.ToLower(params.PublicAddress) {
-		return fmt.Errorf("public address does not match private key")
+		return nil, fmt.Errorf("public address does not match private key")
 	}
 
 	if params.WalletKey == "" {
-		return fmt.Errorf("wallet key must be provided")
+		return nil, fmt.Errorf("wallet key must be provided")
 	}
 
 	ethClient, err := s.client.GetEthClient(params.ChainId)
 	if err != nil {
-		return fmt.Errorf("failed to get eth client: %v", err)
+		return nil, fmt.Errorf("failed to get eth client: %v", err)
 	}
 
-	deadline := time.Now().Add(1 * time.Minute).Unix() // TODO make this configurable
+	deadline := onchain.ResolveDeadline(time.Now(), params.PermitDeadline, s.client.permitDeadline)
 
 	executeSwapConfig := &models.ExecuteSwapConfig{
-		WalletKey:     params.WalletKey,
-		ChainId:       params.ChainId,
-		PublicAddress: params.PublicAddress,
-		Amount:        params.Amount,
-		Slippage:      params.Slippage,
-		SkipWarnings:  params.SkipWarnings,
+		WalletKey:      params.WalletKey,
+		ChainId:        params.ChainId,
+		PublicAddress:  params.PublicAddress,
+		Amount:         params.Amount,
+		Slippage:       params.Slippage,
+		ApprovalPolicy: params.ApprovalPolicy,
+		WaitOptions:    params.WaitOptions,
+		SkipWarnings:   params.SkipWarnings,
+	}
+
+	// A receiver set on the swap request is the only address other than the wallet allowed to receive the swap output
+	if params.Receiver != "" {
+		executeSwapConfig.AllowedReceivers = []string{params.Receiver}
 	}
 
 	var usePermit bool
@@ -82,50 +99,36 @@ func (s *ActionService) swapTokens(ctx context.Context, params models.SwapTokens
 	}
 
 	if usePermit || params.ApprovalType == onchain.PermitAlways {
-		name, err := onchain.ReadContractName(ethClient, common.HexToAddress(params.Src))
-		if err != nil {
-			return fmt.Errorf("failed to read contract name: %v", err)
-		}
-
-		version, err := onchain.ReadContractVersion(ethClient, common.HexToAddress(params.Src))
-		if err != nil {
-			return fmt.Errorf("failed to read contract version: %v", err)
-		}
-
-		nonce, err := onchain.ReadContractNonce(ethClient, derivedPublicAddress, common.HexToAddress(params.Src))
-		if err != nil {
-			return fmt.Errorf("failed to read contract nonce: %v", err)
+		var permitValue *big.Int
+		if !params.ApprovalPolicy.IsUnlimited() {
+			amountBig, err := helpers.BigIntFromString(params.Amount)
+			if err != nil {
+				return nil, fmt.Errorf("failed to convert amount to big.Int: %v", err)
+			}
+			permitValue, err = params.ApprovalPolicy.GetApprovalAmount(amountBig)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get approval amount: %v", err)
+			}
 		}
 
-		sig, err := onchain.CreatePermitSignature(&onchain.PermitSignatureConfig{
-			FromToken:     params.Src,
-			Version:       version,
-			Name:          name,
-			PublicAddress: params.PublicAddress,
+		permitParams, err := onchain.CreatePermit(&onchain.CreatePermitConfig{
+			EthClient:     ethClient,
+			MakerAsset:    params.Src,
+			PublicAddress: derivedPublicAddress,
 			ChainId:       params.ChainId,
-			Key:           params.WalletKey,
-			Nonce:         nonce,
+			PrivateKey:    params.WalletKey,
 			Deadline:      deadline,
+			Value:         permitValue,
 		})
-		if err != nil {
-			return fmt.Errorf("failed to create permit signature: %v", err)
+		switch {
+		case errors.Is(err, onchain.ErrDaiPermitIsUnlimited) && params.ApprovalType == onchain.PermitIfPossible:
+			// The approval policy cannot be honored by this token's permit, so an approval is used instead
+		case err != nil:
+			return nil, fmt.Errorf("failed to create permit: %v", err)
+		default:
+			executeSwapConfig.IsPermitSwap = true
+			params.Permit = permitParams
 		}
-
-		aggregationRouter, err := contracts.Get1inchRouterFromChainId(params.ChainId)
-		if err != nil {
-			return fmt.Errorf("failed to get 1inch router address: %v", err)
-		}
-
-		permitParams := onchain.CreatePermitParams(&onchain.PermitParamsConfig{
-			Owner:     strings.ToLower(params.PublicAddress), // TODO remove ToLower and see if it still works
-			Spender:   aggregationRouter,
-			Value:     amounts.BigMaxUint256,
-			Deadline:  deadline,
-			Signature: sig,
-		})
-
-		executeSwapConfig.IsPermitSwap = true
-		params.Permit = permitParams
 	}
 
 	// Execute swap request
@@ -136,11 +139,14 @@ func (s *ActionService) swapTokens(ctx context.Context, params models.SwapTokens
 		AggregationControllerGetSwapParams: params.AggregationControllerGetSwapParams,
 	})
 	if err != nil {
-		return fmt.Errorf("failed to get swap: %v", err)
+		return nil, fmt.Errorf("failed to get swap: %v", err)
 	}
 
 	executeSwapConfig.TransactionData = swapResponse.Tx.Data
+	executeSwapConfig.TransactionTo = swapResponse.Tx.To
+	executeSwapConfig.TransactionValue = swapResponse.Tx.Value
 	executeSwapConfig.EstimatedAmountOut = swapResponse.ToAmount
+	executeSwapConfig.EstimatedGas = uint64(swapResponse.Tx.Gas)
 	executeSwapConfig.ToToken = swapResponse.ToToken
 
 	// We will use static data for native token details since they are not ERC20s
@@ -150,52 +156,589 @@ func (s *ActionService) swapTokens(ctx context.Context, params models.SwapTokens
 		executeSwapConfig.FromToken = swapResponse.FromToken
 	}
 
-	err = s.client.SwapApi.ExecuteSwap(ctx, executeSwapConfig)
+	// The limits are checked against the swap data that is about to be executed
+	costEstimate, err := s.estimateSwapCost(ctx, executeSwapConfig, ethClient, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate swap cost: %v", err)
+	}
+	err = swap.CheckSwapLimits(params.Limits, costEstimate)
 	if err != nil {
-		return fmt.Errorf("failed to execute swap: %v", err)
+		return nil, fmt.Errorf("swap aborted: %w", err)
+	}
+	executeSwapConfig.CostEstimate = costEstimate
+	executeSwapConfig.MaxFeeNative, err = helpers.BigIntFromString(params.Limits.MaxFeeNative)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert max native fee to big.Int: %v", err)
 	}
 
-	return nil
+	result, err := s.client.SwapApi.ExecuteSwap(ctx, executeSwapConfig)
+	if err != nil {
+		return result, fmt.Errorf("failed to execute swap: %w", err)
+	}
+
+	return result, nil
+}
+
+// estimateSwapCost returns the gas cost of every transaction of a swap, in native tokens and in the quote token of the limits,
+// and the price impact of the swap
+func (s *ActionService) estimateSwapCost(ctx context.Context, config *models.ExecuteSwapConfig, ethClient *ethclient.Client, params models.SwapTokensParams) (*models.SwapCostEstimate, error) {
+	aggregationRouter, err := contracts.Get1inchRouterFromChainId(config.ChainId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get 1inch router address: %v", err)
+	}
+
+	gasCost, err := (*SwapService)(s).estimateSwapGasCost(ctx, config, ethClient, aggregationRouter)
+	if err != nil {
+		return nil, err
+	}
+
+	nativeToken := getNativeTokenDetails(config.ChainId)
+	estimate := &models.SwapCostEstimate{
+		GasCost:      gasCost,
+		NativeToken:  nativeToken,
+		GasCostQuote: gasCost,
+		QuoteToken:   nativeToken,
+	}
+
+	// The gas cost is priced by quoting a swap of it from the native token into the quote token
+	if !strings.EqualFold(params.Limits.QuoteToken, tokens.NativeToken) {
+		feeQuote, _, err := s.client.SwapApi.GetQuote(ctx, models.GetQuoteParams{
+			ChainId: config.ChainId,
+			AggregationControllerGetQuoteParams: models.AggregationControllerGetQuoteParams{
+				Src:    tokens.NativeToken,
+				Dst:    params.Limits.QuoteToken,
+				Amount: gasCost.String(),
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get quote of the gas cost: %v", err)
+		}
+		estimate.GasCostQuote, err = helpers.BigIntFromString(feeQuote.ToAmount)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert quote of the gas cost to big.Int: %v", err)
+		}
+		estimate.QuoteToken = feeQuote.ToToken
+	}
+
+	// The price impact compares the rate of the swap with the rate quoted for a small fraction of its amount
+	amount, err := helpers.BigIntFromString(config.Amount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert amount to big.Int: %v", err)
+	}
+	// Swaps smaller than the divisor are compared with a quote for a single unit of the token instead
+	referenceAmount := new(big.Int).Div(amount, big.NewInt(priceImpactReferenceDivisor))
+	if referenceAmount.Sign() == 0 {
+		referenceAmount = big.NewInt(1)
+	}
+	referenceQuote, _, err := s.client.SwapApi.GetQuote(ctx, models.GetQuoteParams{
+		ChainId: config.ChainId,
+		AggregationControllerGetQuoteParams: models.AggregationControllerGetQuoteParams{
+			Src:    params.Src,
+			Dst:    params.Dst,
+			Amount: referenceAmount.String(),
+			Fee:    params.Fee,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reference quote: %v", err)
+	}
+	referenceAmountOut, err := helpers.BigIntFromString(referenceQuote.ToAmount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert reference quote amount to big.Int: %v", err)
+	}
+	if referenceAmountOut.Sign() == 0 {
+		return nil, fmt.Errorf("failed to measure price impact: reference quote of %s returned nothing", referenceAmount)
+	}
+	amountOut, err := helpers.BigIntFromString(config.EstimatedAmountOut)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert estimated amount out to big.Int: %v", err)
+	}
+	estimate.PriceImpact = swap.GetPriceImpact(amount, amountOut, referenceAmount, referenceAmountOut)
+
+	return estimate, nil
 }
 
 // ExecuteSwap executes a swap on the Ethereum blockchain using swap data generated by GetSwap
-func (s *SwapService) ExecuteSwap(ctx context.Context, config *models.ExecuteSwapConfig) error {
+// The result is also returned with the errors raised once a transaction was sent, so the hashes of the transactions that went
+// through are not lost. Nothing is sent during a Tenderly simulation, so its result is left empty
+func (s *SwapService) ExecuteSwap(ctx context.Context, config *models.ExecuteSwapConfig) (*models.ExecutionResult, error) {
 
 	if config.WalletKey == "" {
-		return fmt.Errorf("wallet key must be set in the client config")
+		return nil, fmt.Errorf("wallet key must be set in the client config")
 	}
 
 	ethClient, err := s.client.GetEthClient(config.ChainId)
 	if err != nil {
-		return fmt.Errorf("failed to get eth client: %v", err)
+		return nil, fmt.Errorf("failed to get eth client: %v", err)
+	}
+
+	aggregationRouter, err := contracts.Get1inchRouterFromChainId(config.ChainId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get 1inch router address: %v", err)
+	}
+
+	// Verify the swap data matches the requested swap before anything is signed
+	decodedSwap, err := swap.GuardSwapTransaction(config, aggregationRouter)
+	if err != nil {
+		return nil, fmt.Errorf("swap transaction rejected: %w", err)
 	}
 
-	if !config.SkipWarnings {
-		ok, err := swap.ConfirmExecuteSwapWithUser(config)
+	// Balances are overridden during a Tenderly simulation, so they are only checked for real swaps
+	if _, ok := ctx.Value(tenderly.SwapConfigKey).(tenderly.SimulationConfig); !ok {
+		err = s.checkSwapFunds(ctx, config, ethClient, aggregationRouter)
 		if err != nil {
-			return fmt.Errorf("failed to confirm swap: %v", err)
+			return nil, err
+		}
+	}
+
+	var reservation *policy.Reservation
+	if s.client.needsSummary(config.SkipWarnings) {
+		summary, err := s.newSwapSummary(ctx, config, ethClient, aggregationRouter, decodedSwap.Receiver)
+		if err != nil {
+			return nil, err
+		}
+		var ok bool
+		reservation, ok, err = s.client.confirmTrade(ctx, summary, config.SkipWarnings)
+		if err != nil {
+			return nil, fmt.Errorf("failed to confirm swap: %w", err)
 		}
 		if !ok {
-			return errors.New("user rejected trade")
+			return nil, errors.New("user rejected trade")
 		}
 	}
 
+	result := &models.ExecutionResult{
+		UsedPermit: config.IsPermitSwap,
+	}
+	budget := newFeeBudget(config.MaxFeeNative)
+
 	if !config.IsPermitSwap {
-		err = s.executeSwapWithApproval(ctx, config, ethClient)
+		err = s.executeSwapWithApproval(ctx, config, ethClient, result, budget)
+		settleTrade(reservation, result.Receipt, err)
 		if err != nil {
-			return fmt.Errorf("failed to execute swap with approval: %v", err)
+			return result, fmt.Errorf("failed to execute swap with approval: %w", err)
 		}
 	} else {
-		err = s.executeSwapWithPermit(ctx, config, ethClient)
+		err = s.executeSwapWithPermit(ctx, config, ethClient, result, budget)
+		settleTrade(reservation, result.Receipt, err)
+		if err != nil {
+			return result, fmt.Errorf("failed to execute swap with permit: %w", err)
+		}
+	}
+
+	if result.Receipt != nil {
+		err = s.readSwapOutput(ctx, config, ethClient, decodedSwap, result)
+		if err != nil {
+			return result, fmt.Errorf("failed to read swap output: %v", err)
+		}
+	}
+
+	// Only allowances granted by this swap are revoked, allowances the wallet already had are left untouched
+	if config.ApprovalPolicy.RevokeAfterSwap && (result.ApprovalTxHash != "" || result.UsedPermit) {
+		err = s.revokeRouterAllowance(ctx, config, ethClient, result, budget)
+		if err != nil {
+			return result, fmt.Errorf("failed to revoke allowance after swap: %w", err)
+		}
+	}
+
+	return result, nil
+}
+
+// estimateSwapGasCost returns the gas cost of every transaction of a swap at the current gas price
+func (s *SwapService) estimateSwapGasCost(ctx context.Context, config *models.ExecuteSwapConfig, ethClient *ethclient.Client, aggregationRouter string) (*big.Int, error) {
+	calls, err := s.getSwapCalls(config, ethClient, aggregationRouter)
+	if err != nil {
+		return nil, err
+	}
+
+	transactions := make([]ethereum.CallMsg, 0, len(calls))
+	for _, call := range calls {
+		to := common.HexToAddress(call.to)
+		transactions = append(transactions, ethereum.CallMsg{
+			To:    &to,
+			Value: call.value,
+			Data:  call.data,
+			Gas:   call.fallbackGas,
+		})
+	}
+
+	return onchain.EstimateGasCost(ctx, ethClient, common.HexToAddress(config.PublicAddress), transactions)
+}
+
+// newSwapSummary summarizes a swap for its confirmation
+// Swaps made by SwapTokens come with their cost estimated, the gas cost of other swaps is only shown when it can be estimated
+func (s *SwapService) newSwapSummary(ctx context.Context, config *models.ExecuteSwapConfig, ethClient *ethclient.Client, aggregationRouter string, receiver string) (*models.Summary, error) {
+	amount, err := helpers.BigIntFromString(config.Amount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert amount to big.Int: %v", err)
+	}
+	estimatedAmountOut, err := helpers.BigIntFromString(config.EstimatedAmountOut)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert estimated amount out to big.Int: %v", err)
+	}
+
+	approvalType := "Contract approval"
+	if config.IsPermitSwap {
+		approvalType = "Permit1"
+	}
+
+	summary := &models.Summary{
+		Kind:         models.SummarySwap,
+		ChainId:      config.ChainId,
+		Wallet:       config.PublicAddress,
+		Selling:      &models.SummaryAmount{Token: config.FromToken, Amount: amount},
+		Buying:       &models.SummaryAmount{Token: config.ToToken, Amount: estimatedAmountOut},
+		Receiver:     receiver,
+		ApprovalType: approvalType,
+		Slippage:     config.Slippage,
+	}
+
+	if estimate := config.CostEstimate; estimate != nil {
+		summary.GasCost = &models.SummaryAmount{Token: estimate.NativeToken, Amount: estimate.GasCost}
+		if !strings.EqualFold(estimate.QuoteToken.Address, tokens.NativeToken) {
+			summary.GasCostQuote = &models.SummaryAmount{Token: estimate.QuoteToken, Amount: estimate.GasCostQuote}
+		}
+		priceImpact := estimate.PriceImpact
+		summary.PriceImpact = &priceImpact
+	} else if gasCost, err := s.estimateSwapGasCost(ctx, config, ethClient, aggregationRouter); err == nil {
+		summary.GasCost = &models.SummaryAmount{Token: getNativeTokenDetails(config.ChainId), Amount: gasCost}
+	}
+
+	return summary, nil
+}
+
+// readSwapOutput fills the amount received by the receiver of a swap and the slippage it realized
+func (s *SwapService) readSwapOutput(ctx context.Context, config *models.ExecuteSwapConfig, ethClient *ethclient.Client, decodedSwap *models.DecodedSwap, result *models.ExecutionResult) error {
+	publicAddress := common.HexToAddress(config.PublicAddress)
+	receiver := publicAddress
+	if decodedSwap.Receiver != "" && !strings.EqualFold(decodedSwap.Receiver, addresses.Zero) {
+		receiver = common.HexToAddress(decodedSwap.Receiver)
+	}
+
+	if strings.EqualFold(config.ToToken.Address, tokens.NativeToken) {
+		amountReceived, err := onchain.ReadNativeBalanceDelta(ctx, ethClient, result.Receipt, receiver, publicAddress)
 		if err != nil {
-			return fmt.Errorf("failed to execute swap with permit: %v", err)
+			return err
 		}
+		result.AmountReceived = amountReceived
+	} else {
+		result.AmountReceived = onchain.ReadTransferredAmount(result.Receipt, common.HexToAddress(config.ToToken.Address), receiver)
 	}
 
+	if config.EstimatedAmountOut != "" {
+		estimatedAmountOut, err := helpers.BigIntFromString(config.EstimatedAmountOut)
+		if err != nil {
+			return fmt.Errorf("failed to convert estimated amount out to big.Int: %v", err)
+		}
+		result.RealizedSlippage = swap.GetRealizedSlippage(estimatedAmountOut, result.AmountReceived)
+	}
 	return nil
 }
 
-func (s *SwapService) executeSwapWithApproval(ctx context.Context, config *models.ExecuteSwapConfig, ethClient *ethclient.Client) error {
+// setSwapReceipt records the swap transaction and its gas in the result
+func setSwapReceipt(result *models.ExecutionResult, receipt *types.Receipt) {
+	if receipt == nil {
+		return
+	}
+	result.SwapTxHash = receipt.TxHash.Hex()
+	result.Receipt = receipt
+	result.GasUsed = receipt.GasUsed
+	result.EffectiveGasPrice = receipt.EffectiveGasPrice
+	result.GasCost = onchain.GetGasCost(receipt)
+}
+
+// BuildSwapTransactions returns the unsigned transactions of a swap generated by GetSwap instead of broadcasting them
+// An approval comes first when the router lacks allowance and a revocation comes last when the approval policy asks for one
+// The transactions use consecutive nonces starting at the pending nonce of the wallet, they can be signed by any external
+// signer and sent with Broadcast
+func (s *SwapService) BuildSwapTransactions(ctx context.Context, config *models.ExecuteSwapConfig) ([]models.UnsignedTransaction, error) {
+	ethClient, err := s.client.GetEthClient(config.ChainId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get eth client: %v", err)
+	}
+
+	aggregationRouter, err := contracts.Get1inchRouterFromChainId(config.ChainId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get 1inch router address: %v", err)
+	}
+
+	// Verify the swap data matches the requested swap before anything is handed over for signing
+	_, err = swap.GuardSwapTransaction(config, aggregationRouter)
+	if err != nil {
+		return nil, fmt.Errorf("swap transaction rejected: %w", err)
+	}
+
+	err = s.checkSwapFunds(ctx, config, ethClient, aggregationRouter)
+	if err != nil {
+		return nil, err
+	}
+
+	calls, err := s.getSwapCalls(config, ethClient, aggregationRouter)
+	if err != nil {
+		return nil, err
+	}
+
+	publicAddress := common.HexToAddress(config.PublicAddress)
+	chainId := big.NewInt(int64(config.ChainId))
+
+	nonce, err := ethClient.PendingNonceAt(ctx, publicAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get nonce: %v", err)
+	}
+
+	budget := newFeeBudget(config.MaxFeeNative)
+	var unsignedTransactions []models.UnsignedTransaction
+	for _, call := range calls {
+		tx, err := onchain.BuildTransaction(ctx, ethClient, nonce, onchain.TxConfig{
+			Description:   call.description,
+			PublicAddress: publicAddress,
+			ChainId:       chainId,
+			Value:         call.value,
+			To:            call.to,
+			Data:          call.data,
+			MaxFee:        budget.left(),
+		}, call.fallbackGas)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build %s transaction: %w", strings.ToLower(call.description), err)
+		}
+		// Transactions that are not sent yet are budgeted at the most they can cost
+		budget.reserve(tx)
+		unsignedTransactions = append(unsignedTransactions, models.UnsignedTransaction{
+			Description: call.description,
+			From:        publicAddress,
+			ChainId:     chainId,
+			Transaction: tx,
+		})
+		nonce++
+	}
+
+	return unsignedTransactions, nil
+}
+
+// swapCall is a transaction of a swap before it is given a sender, a nonce and gas
+type swapCall struct {
+	description string
+	to          string
+	value       *big.Int
+	data        []byte
+	fallbackGas uint64
+}
+
+// getSwapCalls returns the calls making up a swap generated by GetSwap
+// An approval comes first when the router lacks allowance and a revocation comes last when the approval policy asks for one
+func (s *SwapService) getSwapCalls(config *models.ExecuteSwapConfig, ethClient *ethclient.Client, aggregationRouter string) ([]swapCall, error) {
+	amount, err := helpers.BigIntFromString(config.Amount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert amount to big.Int: %v", err)
+	}
+
+	swapData, err := hex.DecodeString(onchain.Remove0xPrefix(config.TransactionData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode swap data: %v", err)
+	}
+
+	publicAddress := common.HexToAddress(config.PublicAddress)
+	routerAddress := common.HexToAddress(aggregationRouter)
+
+	var calls []swapCall
+	value := big.NewInt(0)
+	grantsAllowance := config.IsPermitSwap
+	if config.FromToken.Address == tokens.NativeToken {
+		value = amount
+	} else if !config.IsPermitSwap {
+		allowance, err := onchain.ReadContractAllowance(ethClient, common.HexToAddress(config.FromToken.Address), publicAddress, routerAddress)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read allowance: %v", err)
+		}
+		if allowance.Cmp(amount) < 0 {
+			approvalAmount, err := config.ApprovalPolicy.GetApprovalAmount(amount)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get approval amount: %v", err)
+			}
+			approvalData, err := onchain.GetApproveCalldata(routerAddress, approvalAmount)
+			if err != nil {
+				return nil, err
+			}
+			calls = append(calls, swapCall{
+				description: "Approval",
+				to:          config.FromToken.Address,
+				value:       big.NewInt(0),
+				data:        approvalData,
+				fallbackGas: onchain.ApprovalGasFallback,
+			})
+			grantsAllowance = true
+		}
+	}
+
+	calls = append(calls, swapCall{
+		description: "Swap",
+		to:          aggregationRouter,
+		value:       value,
+		data:        swapData,
+		fallbackGas: getSwapFallbackGas(config),
+	})
+
+	if config.ApprovalPolicy.RevokeAfterSwap && grantsAllowance {
+		revokeData, err := onchain.GetApproveCalldata(routerAddress, big.NewInt(0))
+		if err != nil {
+			return nil, err
+		}
+		calls = append(calls, swapCall{
+			description: "Revoke Approval",
+			to:          config.FromToken.Address,
+			value:       big.NewInt(0),
+			data:        revokeData,
+			fallbackGas: onchain.ApprovalGasFallback,
+		})
+	}
+
+	return calls, nil
+}
+
+// checkSwapFunds makes sure the wallet holds the amount being swapped along with enough native tokens for the
+// value and the gas of the swap, and of the approval sent before it when one is needed
+func (s *SwapService) checkSwapFunds(ctx context.Context, config *models.ExecuteSwapConfig, ethClient *ethclient.Client, aggregationRouter string) error {
+	amount, err := helpers.BigIntFromString(config.Amount)
+	if err != nil {
+		return fmt.Errorf("failed to convert amount to big.Int: %v", err)
+	}
+
+	data, err := hex.DecodeString(onchain.Remove0xPrefix(config.TransactionData))
+	if err != nil {
+		return fmt.Errorf("failed to decode swap data: %v", err)
+	}
+
+	publicAddress := common.HexToAddress(config.PublicAddress)
+	routerAddress := common.HexToAddress(aggregationRouter)
+	preflightConfig := onchain.PreflightConfig{
+		PublicAddress: publicAddress,
+		NativeSymbol:  getNativeTokenDetails(config.ChainId).Symbol,
+	}
+
+	swapMsg := ethereum.CallMsg{
+		To:    &routerAddress,
+		Value: big.NewInt(0),
+		Data:  data,
+		Gas:   getSwapFallbackGas(config),
+	}
+	if config.FromToken.Address == tokens.NativeToken {
+		swapMsg.Value = amount
+	} else {
+		fromTokenAddress := common.HexToAddress(config.FromToken.Address)
+		preflightConfig.Token = fromTokenAddress
+		preflightConfig.TokenSymbol = config.FromToken.Symbol
+		preflightConfig.TokenDecimals = uint8(config.FromToken.Decimals)
+		preflightConfig.Amount = amount
+
+		if !config.IsPermitSwap {
+			allowance, err := onchain.ReadContractAllowance(ethClient, fromTokenAddress, publicAddress, routerAddress)
+			if err != nil {
+				return fmt.Errorf("failed to read allowance: %v", err)
+			}
+			if allowance.Cmp(amount) < 0 {
+				approvalData, err := onchain.GetApproveCalldata(routerAddress, amount)
+				if err != nil {
+					return err
+				}
+				preflightConfig.Transactions = append(preflightConfig.Transactions, ethereum.CallMsg{
+					To:   &fromTokenAddress,
+					Data: approvalData,
+					Gas:  onchain.ApprovalGasFallback,
+				})
+			}
+		}
+	}
+	preflightConfig.Transactions = append(preflightConfig.Transactions, swapMsg)
+
+	return onchain.CheckFunds(ctx, ethClient, preflightConfig)
+}
+
+// feeBudget is what is left of the maximum native fee of a swap once its transactions are paid for
+// A nil budget is unlimited
+type feeBudget struct {
+	remaining *big.Int
+}
+
+func newFeeBudget(maxFee *big.Int) *feeBudget {
+	if maxFee == nil {
+		return nil
+	}
+	return &feeBudget{remaining: new(big.Int).Set(maxFee)}
+}
+
+// left returns the most the next transaction may cost, nil when the budget is unlimited
+func (b *feeBudget) left() *big.Int {
+	if b == nil {
+		return nil
+	}
+	return new(big.Int).Set(b.remaining)
+}
+
+// spend takes the gas cost of a mined transaction out of the budget
+func (b *feeBudget) spend(receipt *types.Receipt) {
+	if b == nil || receipt == nil {
+		return
+	}
+	b.remaining.Sub(b.remaining, onchain.GetGasCost(receipt))
+}
+
+// reserve takes the most a transaction can cost out of the budget
+func (b *feeBudget) reserve(tx *types.Transaction) {
+	if b == nil {
+		return
+	}
+	b.remaining.Sub(b.remaining, onchain.GetMaxGasCost(tx))
+}
+
+// getSwapFallbackGas returns the gas assumed for a swap that cannot be estimated, the estimate of the API when there is one
+func getSwapFallbackGas(config *models.ExecuteSwapConfig) uint64 {
+	if config.EstimatedGas != 0 {
+		return config.EstimatedGas
+	}
+	return onchain.SwapGasFallback
+}
+
+// revokeRouterAllowance resets the allowance the swap granted to the router back to zero once the swap is done
+func (s *SwapService) revokeRouterAllowance(ctx context.Context, config *models.ExecuteSwapConfig, ethClient *ethclient.Client, result *models.ExecutionResult, budget *feeBudget) error {
+
+	// Nothing was posted onchain during a Tenderly simulation
+	if _, ok := ctx.Value(tenderly.SwapConfigKey).(tenderly.SimulationConfig); ok {
+		return nil
+	}
+
+	aggregationRouter, err := contracts.Get1inchRouterFromChainId(config.ChainId)
+	if err != nil {
+		return fmt.Errorf("failed to get 1inch router address: %v", err)
+	}
+
+	allowance, err := onchain.ReadContractAllowance(ethClient, common.HexToAddress(config.FromToken.Address), common.HexToAddress(config.PublicAddress), common.HexToAddress(aggregationRouter))
+	if err != nil {
+		return fmt.Errorf("failed to read allowance: %v", err)
+	}
+	if allowance.Sign() == 0 {
+		return nil
+	}
+
+	receipt, err := onchain.ApproveTokenForRouter(ctx, ethClient, s.client.NonceCache, onchain.Erc20ApprovalConfig{
+		ChainId:        config.ChainId,
+		Key:            config.WalletKey,
+		Erc20Address:   common.HexToAddress(config.FromToken.Address),
+		PublicAddress:  common.HexToAddress(config.PublicAddress),
+		SpenderAddress: common.HexToAddress(aggregationRouter),
+		Amount:         big.NewInt(0),
+		MaxFee:         budget.left(),
+		HeadWatcher:    s.client.getHeadWatcher(config.ChainId),
+		Broadcaster:    s.client.getBroadcaster(config.ChainId),
+		WaitOptions:    config.WaitOptions,
+	})
+	if receipt != nil {
+		result.RevokeTxHash = receipt.TxHash.Hex()
+	}
+	budget.spend(receipt)
+	return err
+}
+
+func (s *SwapService) executeSwapWithApproval(ctx context.Context, config *models.ExecuteSwapConfig, ethClient *ethclient.Client, result *models.ExecutionResult, budget *feeBudget) error {
 
 	aggregationRouter, err := contracts.Get1inchRouterFromChainId(config.ChainId)
 	if err != nil {
@@ -204,6 +747,7 @@ func (s *SwapService) executeSwapWithApproval(ctx context.Context, config *model
 
 	var value *big.Int
 	var approveFirst bool
+	var approvalAmount *big.Int
 	if config.FromToken.Address != tokens.NativeToken {
 		// When swapping erc20 tokens, the value set on the transaction will be 0
 		value = big.NewInt(0)
@@ -217,11 +761,22 @@ func (s *SwapService) executeSwapWithApproval(ctx context.Context, config *model
 		if err != nil {
 			return fmt.Errorf("failed to convert amount to big.Int: %v", err)
 		}
-		if allowance.Cmp(amountBig) <= 0 {
-			if !config.SkipWarnings {
-				ok, err := swap.ConfirmApprovalWithUser(ethClient, config.PublicAddress, config.FromToken.Address)
+		if allowance.Cmp(amountBig) < 0 {
+			approvalAmount, err = config.ApprovalPolicy.GetApprovalAmount(amountBig)
+			if err != nil {
+				return fmt.Errorf("failed to get approval amount: %v", err)
+			}
+
+			if s.client.needsSummary(config.SkipWarnings) {
+				ok, err := s.client.confirm(ctx, &models.Summary{
+					Kind:        models.SummaryApproval,
+					ApprovalFor: models.SummarySwap,
+					ChainId:     config.ChainId,
+					Wallet:      config.PublicAddress,
+					Approval:    newApprovalSummaryAmount(config.FromToken, approvalAmount),
+				}, config.SkipWarnings)
 				if err != nil {
-					return fmt.Errorf("failed to confirm approval: %v", err)
+					return fmt.Errorf("failed to confirm approval: %w", err)
 				}
 				if !ok {
 					return errors.New("user rejected approval")
@@ -238,10 +793,19 @@ func (s *SwapService) executeSwapWithApproval(ctx context.Context, config *model
 					Erc20Address:   common.HexToAddress(config.FromToken.Address),
 					PublicAddress:  common.HexToAddress(config.PublicAddress),
 					SpenderAddress: common.HexToAddress(aggregationRouter),
+					Amount:         approvalAmount,
+					MaxFee:         budget.left(),
+					HeadWatcher:    s.client.getHeadWatcher(config.ChainId),
+					Broadcaster:    s.client.getBroadcaster(config.ChainId),
+					WaitOptions:    config.WaitOptions,
 				}
-				err = onchain.ApproveTokenForRouter(ctx, ethClient, s.client.NonceCache, erc20Config)
+				receipt, err := onchain.ApproveTokenForRouter(ctx, ethClient, s.client.NonceCache, erc20Config)
+				if receipt != nil {
+					result.ApprovalTxHash = receipt.TxHash.Hex()
+				}
+				budget.spend(receipt)
 				if err != nil {
-					return fmt.Errorf("failed to approve token for router: %v", err)
+					return fmt.Errorf("failed to approve token for router: %w", err)
 				}
 				helpers.Sleep()
 			}
@@ -267,6 +831,10 @@ func (s *SwapService) executeSwapWithApproval(ctx context.Context, config *model
 		Value:         value,
 		To:            aggregationRouter,
 		Data:          hexData,
+		MaxFee:        budget.left(),
+		HeadWatcher:   s.client.getHeadWatcher(config.ChainId),
+		Broadcaster:   s.client.getBroadcaster(config.ChainId),
+		WaitOptions:   config.WaitOptions,
 	}
 
 	// Check for injected Tenderly data
@@ -281,21 +849,28 @@ func (s *SwapService) executeSwapWithApproval(ctx context.Context, config *model
 			ToTokenSymbol:   config.ToToken.Symbol,
 			TransactionData: config.TransactionData,
 			ApproveFirst:    approveFirst,
+			ApprovalAmount:  approvalAmount,
 			Value:           value.String(),
 		})
 		if err != nil {
 			return fmt.Errorf("failed to execute tenderly simulation: %v", err)
 		}
 	} else {
-		err = onchain.ExecuteTransaction(ctx, txConfig, ethClient, s.client.NonceCache)
+		txConfig.Gas, err = onchain.EstimateGasLimit(ctx, ethClient, txConfig, getSwapFallbackGas(config))
+		if err != nil {
+			return err
+		}
+		receipt, err := onchain.ExecuteTransaction(ctx, txConfig, ethClient, s.client.NonceCache)
+		setSwapReceipt(result, receipt)
+		budget.spend(receipt)
 		if err != nil {
-			return fmt.Errorf("failed to execute transaction: %v", err)
+			return fmt.Errorf("failed to execute transaction: %w", err)
 		}
 	}
 	return nil
 }
 
-func (s *SwapService) executeSwapWithPermit(ctx context.Context, config *models.ExecuteSwapConfig, ethClient *ethclient.Client) error {
+func (s *SwapService) executeSwapWithPermit(ctx context.Context, config *models.ExecuteSwapConfig, ethClient *ethclient.Client, result *models.ExecutionResult, budget *feeBudget) error {
 
 	hexData, err := hex.DecodeString(config.TransactionData[2:])
 	if err != nil {
@@ -315,6 +890,10 @@ func (s *SwapService) executeSwapWithPermit(ctx context.Context, config *models.
 		Value:         big.NewInt(0),
 		To:            aggregationRouter,
 		Data:          hexData,
+		MaxFee:        budget.left(),
+		HeadWatcher:   s.client.getHeadWatcher(config.ChainId),
+		Broadcaster:   s.client.getBroadcaster(config.ChainId),
+		WaitOptions:   config.WaitOptions,
 	}
 
 	// Check for injected Tenderly data
@@ -334,14 +913,35 @@ func (s *SwapService) executeSwapWithPermit(ctx context.Context, config *models.
 			return fmt.Errorf("failed to execute tenderly simulation: %v", err)
 		}
 	} else {
-		err = onchain.ExecuteTransaction(ctx, txConfig, ethClient, s.client.NonceCache)
+		txConfig.Gas, err = onchain.EstimateGasLimit(ctx, ethClient, txConfig, getSwapFallbackGas(config))
+		if err != nil {
+			return err
+		}
+		receipt, err := onchain.ExecuteTransaction(ctx, txConfig, ethClient, s.client.NonceCache)
+		setSwapReceipt(result, receipt)
+		budget.spend(receipt)
 		if err != nil {
-			return fmt.Errorf("failed to execute transaction: %v", err)
+			return fmt.Errorf("failed to execute transaction: %w", err)
 		}
 	}
 	return nil
 }
 
+// DecodeSwapData parses the transaction data returned by GetSwap into its router method and arguments so it can be audited before signing
+func (s *SwapService) DecodeSwapData(transactionData string) (*models.DecodedSwap, error) {
+	hexData, err := hex.DecodeString(onchain.Remove0xPrefix(transactionData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode swap data: %v", err)
+	}
+
+	decodedSwap, err := swap.DecodeSwapCalldata(hexData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode swap calldata: %v", err)
+	}
+
+	return decodedSwap, nil
+}
+
 func getNativeTokenDetails(chainId int) *models.TokenInfo {
 	var tokenSymbol string
 	switch chainId {
@@ -358,8 +358,16 @@ func TestSwapTokensTenderlyE2E(t *testing.T) {
 				})
 			}
 
+			// The limits are generous since the swaps run on a fork, they are priced in the native token to avoid an extra quote
+			tc.swapParams.Limits = models.SwapLimits{
+				MaxFeeNative:   amounts.Ten18,
+				MaxFeeQuote:    amounts.Ten18,
+				QuoteToken:     tokens.NativeToken,
+				MaxPriceImpact: 5,
+			}
+
 			// Swap tokens
-			err = c.Actions.swapTokens(ctx, tc.swapParams)
+			_, err = c.Actions.SwapTokens(ctx, tc.swapParams)
 			if err != nil {
 				log.Fatalf("Failed to swap tokens: %v", err)
 			}
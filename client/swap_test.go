@@ -1,19 +1,34 @@
 package client
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"math/big"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
 	"github.com/1inch/1inch-sdk-go/client/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/1inch/1inch-sdk-go/helpers/consts/abis"
 	"github.com/1inch/1inch-sdk-go/helpers/consts/addresses"
 	"github.com/1inch/1inch-sdk-go/helpers/consts/amounts"
 	"github.com/1inch/1inch-sdk-go/helpers/consts/chains"
+	"github.com/1inch/1inch-sdk-go/helpers/consts/contracts"
 	"github.com/1inch/1inch-sdk-go/helpers/consts/tokens"
+	"github.com/1inch/1inch-sdk-go/internal/onchain"
 )
 
 func TestApproveAllowance(t *testing.T) {
@@ -263,3 +278,280 @@ func TestGetSwap(t *testing.T) {
 		})
 	}
 }
+
+// newSwapTestServer serves a Polygon node for a wallet that holds plenty of every token but has not approved the router
+// The router cannot estimate a swap before its approval is sent, so estimating any call to it reverts
+func newSwapTestServer(t *testing.T) *httptest.Server {
+	balanceOfSelector := crypto.Keccak256([]byte("balanceOf(address)"))[:4]
+	allowanceSelector := crypto.Keccak256([]byte("allowance(address,address)"))[:4]
+	router := common.HexToAddress(contracts.AggregationRouterV5)
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Id     json.RawMessage   `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+
+		var callMsg struct {
+			To    common.Address `json:"to"`
+			Input hexutil.Bytes  `json:"input"`
+		}
+		if request.Method == "eth_call" || request.Method == "eth_estimateGas" {
+			require.NoError(t, json.Unmarshal(request.Params[0], &callMsg))
+		}
+
+		response := map[string]interface{}{"jsonrpc": "2.0", "id": request.Id}
+		switch request.Method {
+		case "eth_chainId":
+			response["result"] = hexutil.Uint64(chains.Polygon)
+		case "eth_call":
+			switch {
+			case bytes.HasPrefix(callMsg.Input, balanceOfSelector):
+				response["result"] = hexutil.Bytes(common.LeftPadBytes(big.NewInt(1e18).Bytes(), 32))
+			case bytes.HasPrefix(callMsg.Input, allowanceSelector):
+				response["result"] = hexutil.Bytes(make([]byte, 32))
+			default:
+				response["error"] = map[string]interface{}{"code": 3, "message": "execution reverted"}
+			}
+		case "eth_estimateGas":
+			if callMsg.To == router {
+				response["error"] = map[string]interface{}{"code": 3, "message": "execution reverted: insufficient allowance"}
+			} else {
+				response["result"] = hexutil.Uint64(46000)
+			}
+		case "eth_getBalance":
+			response["result"] = (*hexutil.Big)(big.NewInt(1e18))
+		case "eth_getTransactionCount":
+			response["result"] = hexutil.Uint64(5)
+		case "eth_maxPriorityFeePerGas", "eth_gasPrice":
+			response["result"] = (*hexutil.Big)(big.NewInt(30e9))
+		case "eth_getBlockByNumber":
+			response["result"] = &types.Header{Number: big.NewInt(10), Difficulty: big.NewInt(0), BaseFee: big.NewInt(30e9)}
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(response))
+	}))
+}
+
+// newTestSwapConfig returns the config of a DAI swap on Polygon as built from GetSwap, which leaves EstimatedGas unset
+func newTestSwapConfig(t *testing.T, wallet string) *models.ExecuteSwapConfig {
+	parsedABI, err := abi.JSON(strings.NewReader(abis.AggregationRouterV5))
+	require.NoError(t, err)
+
+	executor := common.HexToAddress("0xe37e799d5077682fa0a244d46e5649f71457bd09")
+	data, err := parsedABI.Pack("swap", executor, struct {
+		SrcToken        common.Address
+		DstToken        common.Address
+		SrcReceiver     common.Address
+		DstReceiver     common.Address
+		Amount          *big.Int
+		MinReturnAmount *big.Int
+		Flags           *big.Int
+	}{
+		SrcToken:        common.HexToAddress(tokens.PolygonDai),
+		DstToken:        common.HexToAddress(tokens.PolygonUsdc),
+		SrcReceiver:     executor,
+		DstReceiver:     common.HexToAddress(wallet),
+		Amount:          big.NewInt(1000),
+		MinReturnAmount: big.NewInt(990),
+		Flags:           big.NewInt(0),
+	}, []byte{}, []byte{})
+	require.NoError(t, err)
+
+	return &models.ExecuteSwapConfig{
+		ChainId:            chains.Polygon,
+		PublicAddress:      wallet,
+		FromToken:          &models.TokenInfo{Address: tokens.PolygonDai, Symbol: "DAI", Decimals: 18},
+		ToToken:            &models.TokenInfo{Address: tokens.PolygonUsdc, Symbol: "USDC", Decimals: 6},
+		Amount:             "1000",
+		Slippage:           1,
+		EstimatedAmountOut: "1000",
+		TransactionData:    hexutil.Encode(data),
+		TransactionTo:      contracts.AggregationRouterV5,
+		TransactionValue:   "0",
+		SkipWarnings:       true,
+	}
+}
+
+func TestCheckSwapFundsWithoutEstimatedGas(t *testing.T) {
+	server := newSwapTestServer(t)
+	defer server.Close()
+
+	c, err := NewClient(models.ClientConfig{
+		DevPortalApiKey:   "abc123",
+		Web3HttpProviders: []models.Web3Provider{{ChainId: chains.Polygon, Url: server.URL}},
+	})
+	require.NoError(t, err)
+	defer c.Close()
+
+	ethClient, err := c.GetEthClient(chains.Polygon)
+	require.NoError(t, err)
+
+	// The swap cannot be estimated until the approval is sent, so its gas falls back to a default
+	config := newTestSwapConfig(t, "0x2a250893f86Dc8497E131508f680338ac647B498")
+	require.NoError(t, c.SwapApi.checkSwapFunds(context.Background(), config, ethClient, contracts.AggregationRouterV5))
+}
+
+func TestBuildSwapTransactionsWithApproval(t *testing.T) {
+	server := newSwapTestServer(t)
+	defer server.Close()
+
+	c, err := NewClient(models.ClientConfig{
+		DevPortalApiKey:   "abc123",
+		Web3HttpProviders: []models.Web3Provider{{ChainId: chains.Polygon, Url: server.URL}},
+	})
+	require.NoError(t, err)
+	defer c.Close()
+
+	config := newTestSwapConfig(t, "0x2a250893f86Dc8497E131508f680338ac647B498")
+	transactions, err := c.SwapApi.BuildSwapTransactions(context.Background(), config)
+	require.NoError(t, err)
+	require.Len(t, transactions, 2)
+
+	approval, swap := transactions[0], transactions[1]
+	require.Equal(t, "Approval", approval.Description)
+	require.Equal(t, common.HexToAddress(tokens.PolygonDai), *approval.Transaction.To())
+	require.Equal(t, uint64(5), approval.Transaction.Nonce())
+	// The estimate of the approval is raised by 25%
+	require.Equal(t, uint64(57500), approval.Transaction.Gas())
+
+	// The swap waits on the approval so it cannot be estimated and gets the default gas
+	require.Equal(t, "Swap", swap.Description)
+	require.Equal(t, common.HexToAddress(contracts.AggregationRouterV5), *swap.Transaction.To())
+	require.Equal(t, uint64(6), swap.Transaction.Nonce())
+	require.Equal(t, onchain.SwapGasFallback, swap.Transaction.Gas())
+	require.Equal(t, hexutil.MustDecode(config.TransactionData), swap.Transaction.Data())
+}
+
+func TestBuildSwapTransactionsMaxFee(t *testing.T) {
+	server := newSwapTestServer(t)
+	defer server.Close()
+
+	c, err := NewClient(models.ClientConfig{
+		DevPortalApiKey:   "abc123",
+		Web3HttpProviders: []models.Web3Provider{{ChainId: chains.Polygon, Url: server.URL}},
+	})
+	require.NoError(t, err)
+	defer c.Close()
+
+	// The approval can cost up to 57500 gas and the swap up to its default gas, both at a fee cap of 45 gwei
+	maxCost := new(big.Int).Mul(big.NewInt(45e9), new(big.Int).SetUint64(57500+onchain.SwapGasFallback))
+
+	testcases := []struct {
+		description   string
+		maxFeeNative  *big.Int
+		expectedError error
+	}{
+		{
+			description:  "Budget covers every transaction",
+			maxFeeNative: maxCost,
+		},
+		{
+			description:   "Error - swap could take the gas cost over the budget",
+			maxFeeNative:  new(big.Int).Sub(maxCost, big.NewInt(1)),
+			expectedError: onchain.ErrMaxFeeExceeded,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.description, func(t *testing.T) {
+			config := newTestSwapConfig(t, "0x2a250893f86Dc8497E131508f680338ac647B498")
+			config.MaxFeeNative = tc.maxFeeNative
+
+			transactions, err := c.SwapApi.BuildSwapTransactions(context.Background(), config)
+			if tc.expectedError != nil {
+				require.ErrorIs(t, err, tc.expectedError)
+				require.ErrorContains(t, err, "failed to build swap transaction")
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, transactions, 2)
+		})
+	}
+}
+
+func TestEstimateSwapCostPriceImpact(t *testing.T) {
+	server := newSwapTestServer(t)
+	defer server.Close()
+
+	testcases := []struct {
+		description             string
+		amount                  string
+		estimatedAmountOut      string
+		referenceAmountOut      string
+		expectedReferenceAmount string
+		expectedPriceImpact     float64
+		expectedError           string
+	}{
+		{
+			description:             "Reference quote for a fraction of the amount",
+			amount:                  "1000000",
+			estimatedAmountOut:      "990000",
+			referenceAmountOut:      "1000",
+			expectedReferenceAmount: "1000",
+			expectedPriceImpact:     1,
+		},
+		{
+			description:             "Reference quote for a single unit when the amount is smaller than the divisor",
+			amount:                  "500",
+			estimatedAmountOut:      "450",
+			referenceAmountOut:      "1",
+			expectedReferenceAmount: "1",
+			expectedPriceImpact:     10,
+		},
+		{
+			description:             "Error - reference quote returns nothing",
+			amount:                  "500",
+			estimatedAmountOut:      "450",
+			referenceAmountOut:      "0",
+			expectedReferenceAmount: "1",
+			expectedError:           "failed to measure price impact: reference quote of 1 returned nothing",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.description, func(t *testing.T) {
+			var referenceAmount string
+			quoteServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, "/swap/v5.2/137/quote", r.URL.Path)
+				referenceAmount = r.URL.Query().Get("amount")
+				require.NoError(t, json.NewEncoder(w).Encode(models.QuoteResponse{ToAmount: tc.referenceAmountOut}))
+			}))
+			defer quoteServer.Close()
+
+			c, err := NewClient(models.ClientConfig{
+				DevPortalApiKey:   "abc123",
+				Web3HttpProviders: []models.Web3Provider{{ChainId: chains.Polygon, Url: server.URL}},
+			})
+			require.NoError(t, err)
+			defer c.Close()
+			c.ApiBaseURL, err = url.Parse(quoteServer.URL)
+			require.NoError(t, err)
+
+			ethClient, err := c.GetEthClient(chains.Polygon)
+			require.NoError(t, err)
+
+			config := newTestSwapConfig(t, "0x2a250893f86Dc8497E131508f680338ac647B498")
+			config.Amount = tc.amount
+			config.EstimatedAmountOut = tc.estimatedAmountOut
+			params := models.SwapTokensParams{
+				ChainId: chains.Polygon,
+				Limits:  models.SwapLimits{QuoteToken: tokens.NativeToken},
+				AggregationControllerGetSwapParams: models.AggregationControllerGetSwapParams{
+					Src: tokens.PolygonDai,
+					Dst: tokens.PolygonUsdc,
+				},
+			}
+
+			estimate, err := c.Actions.estimateSwapCost(context.Background(), config, ethClient, params)
+			require.Equal(t, tc.expectedReferenceAmount, referenceAmount)
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.InDelta(t, tc.expectedPriceImpact, estimate.PriceImpact, 1e-9)
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package client
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/1inch/1inch-sdk-go/client/models"
+	"github.com/1inch/1inch-sdk-go/helpers"
+	"github.com/1inch/1inch-sdk-go/helpers/consts/tokens"
+	"github.com/1inch/1inch-sdk-go/internal/onchain"
+)
+
+// This file provides helper functions that wrap and unwrap the native gas token of a chain onchain.
+
+// WrapNative deposits native gas tokens into the wrapped native token contract of the chain (e.g. ETH to WETH)
+func (s *ActionService) WrapNative(ctx context.Context, params models.WrapNativeParams) error {
+	err := params.Validate()
+	if err != nil {
+		return err
+	}
+
+	amount, err := helpers.BigIntFromString(params.Amount)
+	if err != nil {
+		return fmt.Errorf("failed to parse amount: %v", err)
+	}
+
+	return s.executeWrap(ctx, params.ChainId, params.PublicAddress, params.WalletKey, amount, params.WaitOptions, params.SkipWarnings, false)
+}
+
+// UnwrapNative withdraws native gas tokens from the wrapped native token contract of the chain (e.g. WETH to ETH)
+func (s *ActionService) UnwrapNative(ctx context.Context, params models.UnwrapNativeParams) error {
+	err := params.Validate()
+	if err != nil {
+		return err
+	}
+
+	amount, err := helpers.BigIntFromString(params.Amount)
+	if err != nil {
+		return fmt.Errorf("failed to parse amount: %v", err)
+	}
+
+	return s.executeWrap(ctx, params.ChainId, params.PublicAddress, params.WalletKey, amount, params.WaitOptions, params.SkipWarnings, true)
+}
+
+// executeWrap runs a deposit into, or a withdrawal from, the wrapped native token contract after confirming it with the user
+func (s *ActionService) executeWrap(ctx context.Context, chainId int, publicAddress string, walletKey string, amount *big.Int, waitOptions onchain.WaitOptions, skipWarnings bool, unwrap bool) error {
+	privateKey, err := crypto.HexToECDSA(walletKey)
+	if err != nil {
+		return fmt.Errorf("failed to convert private key: %v", err)
+	}
+
+	publicKeyECDSA, ok := privateKey.Public().(*ecdsa.PublicKey)
+	if !ok {
+		return fmt.Errorf("could not cast public key to ECDSA")
+	}
+
+	if !strings.EqualFold(crypto.PubkeyToAddress(*publicKeyECDSA).Hex(), publicAddress) {
+		return fmt.Errorf("public address does not match private key")
+	}
+
+	wrappedNativeToken, err := tokens.GetWrappedNativeTokenFromChainId(chainId)
+	if err != nil {
+		return fmt.Errorf("failed to get wrapped native token address: %v", err)
+	}
+
+	ethClient, err := s.client.GetEthClient(chainId)
+	if err != nil {
+		return fmt.Errorf("failed to get eth client: %v", err)
+	}
+
+	if s.client.needsSummary(skipWarnings) {
+		wrappedNativeSymbol, err := onchain.ReadContractSymbol(ethClient, common.HexToAddress(wrappedNativeToken))
+		if err != nil {
+			return fmt.Errorf("failed to read wrapped native token symbol: %v", err)
+		}
+
+		// Every supported chain uses 18 decimals for its native gas token and the wrapped version of it
+		native := &models.SummaryAmount{Token: getNativeTokenDetails(chainId), Amount: amount}
+		wrapped := &models.SummaryAmount{Token: &models.TokenInfo{Address: wrappedNativeToken, Symbol: wrappedNativeSymbol, Decimals: 18}, Amount: amount}
+		summary := &models.Summary{Kind: models.SummaryWrap, ChainId: chainId, Wallet: publicAddress, Selling: native, Buying: wrapped}
+		if unwrap {
+			summary = &models.Summary{Kind: models.SummaryUnwrap, ChainId: chainId, Wallet: publicAddress, Selling: wrapped, Buying: native}
+		}
+		ok, err := s.client.confirm(ctx, summary, skipWarnings)
+		if err != nil {
+			return fmt.Errorf("failed to confirm wrap: %w", err)
+		}
+		if !ok {
+			return errors.New("user rejected wrap")
+		}
+	}
+
+	config := onchain.WrapNativeConfig{
+		ChainId:              chainId,
+		Key:                  walletKey,
+		WrappedNativeAddress: common.HexToAddress(wrappedNativeToken),
+		PublicAddress:        common.HexToAddress(publicAddress),
+		Amount:               amount,
+		HeadWatcher:          s.client.getHeadWatcher(chainId),
+		Broadcaster:          s.client.getBroadcaster(chainId),
+		WaitOptions:          waitOptions,
+	}
+	if unwrap {
+		err = onchain.UnwrapNativeToken(ctx, ethClient, s.client.NonceCache, config)
+		if err != nil {
+			return fmt.Errorf("failed to unwrap native token: %w", err)
+		}
+		return nil
+	}
+
+	err = onchain.WrapNativeToken(ctx, ethClient, s.client.NonceCache, config)
+	if err != nil {
+		return fmt.Errorf("failed to wrap native token: %w", err)
+	}
+	return nil
+}
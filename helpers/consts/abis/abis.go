@@ -10,3 +10,21 @@ var SeriesNonceManager string
 
 //go:embed aggregationRouterV5.abi.json
 var AggregationRouterV5 string
+
+//go:embed weth.abi.json
+var Weth string
+
+//go:embed erc1271.abi.json
+var Erc1271 string
+
+//go:embed safe.abi.json
+var Safe string
+
+//go:embed multiSend.abi.json
+var MultiSend string
+
+//go:embed dutchAuctionCalculator.abi.json
+var DutchAuctionCalculator string
+
+//go:embed rangeAmountCalculator.abi.json
+var RangeAmountCalculator string
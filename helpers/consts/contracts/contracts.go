@@ -12,6 +12,15 @@ const AggregationV5RouterZkSyncEra = "0x6e2B76966cbD9cF4cC2Fa0D76d24d5241E0ABC2F
 const AggregationRouterV5Name = "1inch Aggregation Router"
 const AggregationRouterV5VersionNumber = "5"
 
+// Previous generations of the 1inch router that wallets may still have allowances for
+const AggregationRouterV3 = "0x11111112542d85b3ef69ae05771c2dccff4faa26" // Not deployed on Aurora, Base, Klaytn, Optimism and zkSync
+const AggregationRouterV4 = "0x1111111254fb6c44bac0bed2854e76f90643097d" // Not deployed on Base and zkSync, Optimism uses a different address
+const AggregationRouterV4Optimism = "0x1111111254760f7ab3f16433eea9304126dcd199"
+
+// MultiSendCallOnly contract addresses are taken from safe-global/safe-deployments (v1.3.0)
+const MultiSendCallOnly = "0x40A2aCCbd92BCA938b02010E17A5b8929b49130D" // Contract address is identical for all chains except zkSync
+const MultiSendCallOnlyZkSyncEra = "0xf220D3b4DFb23C4ade8C88E526C1353AbAcbC38F"
+
 // Series Nonce Manager contract addresses are taken from limit-order-protocol/deployments
 
 const SeriesNonceManagerArbitrum = "0xD7936052D1e096d48C81Ef3918F9Fd6384108480"
@@ -38,6 +47,24 @@ func Get1inchRouterFromChainId(chainId int) (string, error) {
 	}
 }
 
+// Get1inchRoutersFromChainId returns every known 1inch router address on a chain, starting with the current one
+func Get1inchRoutersFromChainId(chainId int) ([]string, error) {
+	aggregationRouter, err := Get1inchRouterFromChainId(chainId)
+	if err != nil {
+		return nil, err
+	}
+	switch chainId {
+	case chains.Arbitrum, chains.Avalanche, chains.Bsc, chains.Ethereum, chains.Fantom, chains.Gnosis, chains.Polygon:
+		return []string{aggregationRouter, AggregationRouterV4, AggregationRouterV3}, nil
+	case chains.Aurora, chains.Klaytn:
+		return []string{aggregationRouter, AggregationRouterV4}, nil
+	case chains.Optimism:
+		return []string{aggregationRouter, AggregationRouterV4Optimism}, nil
+	default:
+		return []string{aggregationRouter}, nil
+	}
+}
+
 func GetSeriesNonceManagerFromChainId(chainId int) (string, error) {
 	switch chainId {
 	case chains.Arbitrum:
@@ -68,3 +95,15 @@ func GetSeriesNonceManagerFromChainId(chainId int) (string, error) {
 		return "", fmt.Errorf("unrecognized chain id: %d", chainId)
 	}
 }
+
+func GetMultiSendCallOnlyFromChainId(chainId int) (string, error) {
+	if helpers.Contains(chainId, chains.ValidChainIds) {
+		if chainId == chains.ZkSyncEra {
+			return MultiSendCallOnlyZkSyncEra, nil
+		} else {
+			return MultiSendCallOnly, nil
+		}
+	} else {
+		return "", fmt.Errorf("unrecognized chain id: %d", chainId)
+	}
+}
@@ -1,5 +1,11 @@
 package tokens
 
+import (
+	"fmt"
+
+	"github.com/1inch/1inch-sdk-go/helpers/consts/chains"
+)
+
 const (
 	NativeToken = "0xEeeeeEeeeEeEeeEeEeEeeEEEeeeeEeeeeeeeEEeE"
 
@@ -25,3 +31,61 @@ const (
 	ArbitrumDai  = "0xda10009cbd5d07dd0cecc66161fc93d7c9000da1"
 	ArbitrumFrax = "0x17fc002b466eec40dae837fc4be5c67993ddbd6f"
 )
+
+// Wrapped native token addresses, the canonical WETH9-style contract of each chain
+
+const (
+	ArbitrumWeth   = "0x82aF49447D8a07e3bd95BD0d56f35241523fBab1"
+	AvalancheWavax = "0xB31f66AA3C1e785363F0875A1B74E27b85FD66c7"
+	BaseWeth       = "0x4200000000000000000000000000000000000006"
+	BscWbnb        = "0xbb4CdB9CBd36B01bD1cBaEBF2De08d9173bc095c"
+	FantomWftm     = "0x21be370D5312f44cB42ce377BC9b8a0cEF1A4C83"
+	GnosisWxdai    = "0xe91D153E0b41518A2Ce8Dd3D7944Fa863463a97d"
+	OptimismWeth   = "0x4200000000000000000000000000000000000006"
+	PolygonWmatic  = "0x0d500B1d8E8eF31E21C99d1Db9A6444d3ADf1270"
+	ZkSyncEraWeth  = "0x5AEa5775959fBC2557Cc8789bC1bf90A239D9a91"
+)
+
+// GetWrappedNativeTokenFromChainId returns the address of the wrapped native token of a chain
+func GetWrappedNativeTokenFromChainId(chainId int) (string, error) {
+	switch chainId {
+	case chains.Arbitrum:
+		return ArbitrumWeth, nil
+	case chains.Avalanche:
+		return AvalancheWavax, nil
+	case chains.Base:
+		return BaseWeth, nil
+	case chains.Bsc:
+		return BscWbnb, nil
+	case chains.Ethereum:
+		return EthereumWeth, nil
+	case chains.Fantom:
+		return FantomWftm, nil
+	case chains.Gnosis:
+		return GnosisWxdai, nil
+	case chains.Optimism:
+		return OptimismWeth, nil
+	case chains.Polygon:
+		return PolygonWmatic, nil
+	case chains.ZkSyncEra:
+		return ZkSyncEraWeth, nil
+	default:
+		return "", fmt.Errorf("no wrapped native token known for chain id: %d", chainId)
+	}
+}
+
+// GetUsdcFromChainId returns the address of the native USDC of a chain, which is used to price amounts in USD
+func GetUsdcFromChainId(chainId int) (string, error) {
+	switch chainId {
+	case chains.Arbitrum:
+		return ArbitrumUsdc, nil
+	case chains.Bsc:
+		return BscUsdc, nil
+	case chains.Ethereum:
+		return EthereumUsdc, nil
+	case chains.Polygon:
+		return PolygonUsdc, nil
+	default:
+		return "", fmt.Errorf("no USDC known for chain id: %d", chainId)
+	}
+}
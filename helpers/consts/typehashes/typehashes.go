@@ -1,3 +1,5 @@
 package typehashes
 
 var Permit1 = `6e71edae12b1b97f4d1f60370fef10105fa2faae0126114a169c64845d6126c9`
+
+var DaiPermit = `ea2aa0a1be11a07ed86d755c93467f4f82362b452371d1ba94d1715123511acb`
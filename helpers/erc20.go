@@ -3,18 +3,24 @@ package helpers
 import "strings"
 
 func SimplifyValue(input string, decimalPlaces int) string {
+	if decimalPlaces <= 0 {
+		return input
+	}
+
 	// Padding with zeros if necessary
 	for len(input) < decimalPlaces {
 		input = "0" + input
 	}
 
-	// Inserting decimal point
+	// Split around the decimal point and trim trailing zeros after it
 	pointIndex := len(input) - decimalPlaces
-	if pointIndex == 0 {
-		return "0." + strings.TrimRight(input, "0")
+	integerPart := input[:pointIndex]
+	if integerPart == "" {
+		integerPart = "0"
 	}
-
-	// Form the result and trim trailing zeros after the decimal point
-	result := input[:pointIndex] + "." + input[pointIndex:]
-	return strings.TrimRight(strings.TrimRight(result, "0"), ".")
+	fractionalPart := strings.TrimRight(input[pointIndex:], "0")
+	if fractionalPart == "" {
+		return integerPart
+	}
+	return integerPart + "." + fractionalPart
 }
@@ -37,6 +37,18 @@ func TestSimplifyValue(t *testing.T) {
 			decimalPlaces:  18,
 			expectedResult: "10.00000000000100001",
 		},
+		{
+			description:    "Zero",
+			input:          "0",
+			decimalPlaces:  18,
+			expectedResult: "0",
+		},
+		{
+			description:    "No decimals",
+			input:          "100",
+			decimalPlaces:  0,
+			expectedResult: "100",
+		},
 	}
 
 	for _, tc := range testCases {
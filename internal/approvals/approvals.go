@@ -0,0 +1,68 @@
+package approvals
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/1inch/1inch-sdk-go/client/models"
+	"github.com/1inch/1inch-sdk-go/helpers"
+	"github.com/1inch/1inch-sdk-go/internal/onchain"
+)
+
+// GetLatestApprovals keeps the most recent Approval log of every token and spender pair
+// The results are ordered by token, then by spender
+func GetLatestApprovals(approvalLogs []onchain.ApprovalLog) []onchain.ApprovalLog {
+	latest := make(map[string]onchain.ApprovalLog)
+	for _, approvalLog := range approvalLogs {
+		key := approvalLog.Token.Hex() + approvalLog.Spender.Hex()
+		existing, ok := latest[key]
+		if !ok || approvalLog.BlockNumber >= existing.BlockNumber {
+			latest[key] = approvalLog
+		}
+	}
+
+	result := make([]onchain.ApprovalLog, 0, len(latest))
+	for _, approvalLog := range latest {
+		result = append(result, approvalLog)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Token != result[j].Token {
+			return bytes.Compare(result[i].Token.Bytes(), result[j].Token.Bytes()) < 0
+		}
+		return bytes.Compare(result[i].Spender.Bytes(), result[j].Spender.Bytes()) < 0
+	})
+	return result
+}
+
+// ConfirmRevokeApprovalsWithUser prints the approvals about to be revoked and asks the user to confirm it in the terminal
+func ConfirmRevokeApprovalsWithUser(summary *models.Summary) (bool, error) {
+	stdOut := helpers.StdOutPrinter{}
+	return confirmRevokeApprovalsWithUser(summary, os.Stdin, stdOut)
+}
+
+func confirmRevokeApprovalsWithUser(summary *models.Summary, reader io.Reader, writer helpers.Printer) (bool, error) {
+	revokeTransactions := summary.Revocations
+	writer.Printf("Revoke summary:\n")
+	writer.Printf("    %-30s %s\n", "Wallet:", summary.Wallet)
+	for _, revokeTransaction := range revokeTransactions {
+		writer.Printf("    %-30s %s (%s)\n", revokeTransaction.Token, revokeTransaction.Spender, revokeTransaction.Method)
+	}
+	writer.Printf("\n")
+	writer.Printf("WARNING: %d revoke transactions will be executed onchain next, each one costs gas\n", len(revokeTransactions))
+	writer.Printf("Would you like to revoke these approvals onchain now? [y/N]: ")
+
+	inputReader := bufio.NewReader(reader)
+	input, _ := inputReader.ReadString('\n')
+	input = strings.ToLower(strings.TrimSpace(input))
+
+	switch input {
+	case "y":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
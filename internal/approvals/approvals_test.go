@@ -0,0 +1,130 @@
+package approvals
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/1inch/1inch-sdk-go/client/models"
+	"github.com/1inch/1inch-sdk-go/helpers"
+	"github.com/1inch/1inch-sdk-go/helpers/consts/addresses"
+	"github.com/1inch/1inch-sdk-go/helpers/consts/contracts"
+	"github.com/1inch/1inch-sdk-go/helpers/consts/tokens"
+	"github.com/1inch/1inch-sdk-go/internal/onchain"
+)
+
+func TestGetLatestApprovals(t *testing.T) {
+	usdc := common.HexToAddress(tokens.EthereumUsdc)
+	dai := common.HexToAddress(tokens.EthereumDai)
+	routerV5 := common.HexToAddress(contracts.AggregationRouterV5)
+	routerV4 := common.HexToAddress(contracts.AggregationRouterV4)
+
+	approvalLog := func(token common.Address, spender common.Address, blockNumber uint64, value int64) onchain.ApprovalLog {
+		return onchain.ApprovalLog{
+			Token:       token,
+			Spender:     spender,
+			Value:       big.NewInt(value),
+			BlockNumber: blockNumber,
+		}
+	}
+
+	testcases := []struct {
+		description string
+		logs        []onchain.ApprovalLog
+		expected    []onchain.ApprovalLog
+	}{
+		{
+			description: "No logs",
+			logs:        nil,
+			expected:    []onchain.ApprovalLog{},
+		},
+		{
+			description: "Later approvals replace earlier ones",
+			logs: []onchain.ApprovalLog{
+				approvalLog(usdc, routerV5, 300, 0),
+				approvalLog(usdc, routerV5, 100, 1000),
+				approvalLog(usdc, routerV5, 200, 500),
+			},
+			expected: []onchain.ApprovalLog{
+				approvalLog(usdc, routerV5, 300, 0),
+			},
+		},
+		{
+			description: "Pairs are kept apart and sorted by token then spender",
+			logs: []onchain.ApprovalLog{
+				approvalLog(usdc, routerV5, 100, 1000),
+				approvalLog(dai, routerV5, 200, 2000),
+				approvalLog(usdc, routerV4, 150, 3000),
+			},
+			expected: []onchain.ApprovalLog{
+				approvalLog(dai, routerV5, 200, 2000),
+				approvalLog(usdc, routerV5, 100, 1000),
+				approvalLog(usdc, routerV4, 150, 3000),
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.description, func(t *testing.T) {
+			require.Equal(t, tc.expected, GetLatestApprovals(tc.logs))
+		})
+	}
+}
+
+func TestConfirmRevokeApprovalsWithUser(t *testing.T) {
+	revokeTransactions := []models.RevokeTransaction{
+		{
+			Token:   tokens.EthereumUsdc,
+			Spender: contracts.AggregationRouterV5,
+			To:      tokens.EthereumUsdc,
+			Data:    "0x",
+			Method:  "approve",
+		},
+	}
+
+	tests := []struct {
+		name           string
+		userInput      string
+		expectedResult bool
+	}{
+		{
+			name:           "User inputs 'y'",
+			userInput:      "y\n",
+			expectedResult: true,
+		},
+		{
+			name:           "User inputs 'Y'",
+			userInput:      "Y\n",
+			expectedResult: true,
+		},
+		{
+			name:           "User inputs 'n'",
+			userInput:      "n\n",
+			expectedResult: false,
+		},
+		{
+			name:           "User inputs nothing",
+			userInput:      "\n",
+			expectedResult: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			reader := bytes.NewBufferString(tc.userInput)
+			writer := helpers.NoOpPrinter{}
+			result, err := confirmRevokeApprovalsWithUser(&models.Summary{
+				Kind:        models.SummaryRevoke,
+				Wallet:      addresses.Vitalik,
+				Revocations: revokeTransactions,
+			}, reader, writer)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
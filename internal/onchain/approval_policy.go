@@ -0,0 +1,87 @@
+package onchain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/1inch/1inch-sdk-go/helpers/consts/amounts"
+)
+
+type ApprovalAmountType int
+
+const (
+	ApprovalUnlimited ApprovalAmountType = iota
+	ApprovalExact
+	ApprovalMultiplier
+	ApprovalCap
+)
+
+// ApprovalPolicy controls how much allowance the SDK grants to the 1inch router through approvals and permits
+// The zero value grants an unlimited allowance
+type ApprovalPolicy struct {
+	Type ApprovalAmountType
+	// Multiplier is applied to the required amount when using ApprovalMultiplier (must be at least 1)
+	Multiplier float64
+	// Cap is the allowance granted when using ApprovalCap, it must cover the required amount
+	Cap *big.Int
+	// RevokeAfterSwap resets the router allowance to zero once a swap completes, but only when that swap granted the
+	// allowance through an approval or a permit. Allowances the wallet already had are left untouched.
+	RevokeAfterSwap bool
+}
+
+// Validate checks that the fields required by the policy type are set
+func (policy ApprovalPolicy) Validate() error {
+	switch policy.Type {
+	case ApprovalUnlimited, ApprovalExact:
+		return nil
+	case ApprovalMultiplier:
+		if math.IsNaN(policy.Multiplier) || math.IsInf(policy.Multiplier, 0) || policy.Multiplier < 1 {
+			return fmt.Errorf("approval multiplier must be at least 1, got %v", policy.Multiplier)
+		}
+		return nil
+	case ApprovalCap:
+		if policy.Cap == nil || policy.Cap.Sign() <= 0 {
+			return errors.New("approval cap must be a positive amount")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown approval amount type: %d", policy.Type)
+	}
+}
+
+// IsUnlimited returns true when the policy grants the maximum possible allowance
+func (policy ApprovalPolicy) IsUnlimited() bool {
+	return policy.Type == ApprovalUnlimited
+}
+
+// GetApprovalAmount returns the allowance to grant so that the required amount can be spent
+func (policy ApprovalPolicy) GetApprovalAmount(requiredAmount *big.Int) (*big.Int, error) {
+	err := policy.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	switch policy.Type {
+	case ApprovalExact:
+		return new(big.Int).Set(requiredAmount), nil
+	case ApprovalMultiplier:
+		scaled, _ := new(big.Float).Mul(new(big.Float).SetInt(requiredAmount), big.NewFloat(policy.Multiplier)).Int(nil)
+		if scaled.Cmp(amounts.BigMaxUint256) > 0 {
+			return new(big.Int).Set(amounts.BigMaxUint256), nil
+		}
+		// Float rounding must never leave the allowance below what the trade needs
+		if scaled.Cmp(requiredAmount) < 0 {
+			scaled.Set(requiredAmount)
+		}
+		return scaled, nil
+	case ApprovalCap:
+		if policy.Cap.Cmp(requiredAmount) < 0 {
+			return nil, fmt.Errorf("approval cap %s is below the required amount %s", policy.Cap, requiredAmount)
+		}
+		return new(big.Int).Set(policy.Cap), nil
+	default:
+		return new(big.Int).Set(amounts.BigMaxUint256), nil
+	}
+}
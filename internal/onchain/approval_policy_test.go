@@ -0,0 +1,100 @@
+package onchain
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/1inch/1inch-sdk-go/helpers/consts/amounts"
+)
+
+func TestGetApprovalAmount(t *testing.T) {
+	testcases := []struct {
+		description          string
+		policy               ApprovalPolicy
+		requiredAmount       *big.Int
+		expectedAmount       *big.Int
+		expectedErrorMessage string
+	}{
+		{
+			description:    "Default policy is unlimited",
+			policy:         ApprovalPolicy{},
+			requiredAmount: big.NewInt(1000),
+			expectedAmount: amounts.BigMaxUint256,
+		},
+		{
+			description:    "Exact amount",
+			policy:         ApprovalPolicy{Type: ApprovalExact},
+			requiredAmount: big.NewInt(1000),
+			expectedAmount: big.NewInt(1000),
+		},
+		{
+			description:    "Multiplier",
+			policy:         ApprovalPolicy{Type: ApprovalMultiplier, Multiplier: 1.5},
+			requiredAmount: big.NewInt(1000),
+			expectedAmount: big.NewInt(1500),
+		},
+		{
+			description:    "Multiplier is limited to the max uint256",
+			policy:         ApprovalPolicy{Type: ApprovalMultiplier, Multiplier: 3},
+			requiredAmount: new(big.Int).Rsh(amounts.BigMaxUint256, 1),
+			expectedAmount: amounts.BigMaxUint256,
+		},
+		{
+			description:    "Cap above the required amount",
+			policy:         ApprovalPolicy{Type: ApprovalCap, Cap: big.NewInt(5000)},
+			requiredAmount: big.NewInt(1000),
+			expectedAmount: big.NewInt(5000),
+		},
+		{
+			description:          "Error - cap below the required amount",
+			policy:               ApprovalPolicy{Type: ApprovalCap, Cap: big.NewInt(500)},
+			requiredAmount:       big.NewInt(1000),
+			expectedErrorMessage: "approval cap 500 is below the required amount 1000",
+		},
+		{
+			description:          "Error - missing cap",
+			policy:               ApprovalPolicy{Type: ApprovalCap},
+			requiredAmount:       big.NewInt(1000),
+			expectedErrorMessage: "approval cap must be a positive amount",
+		},
+		{
+			description:          "Error - multiplier below one",
+			policy:               ApprovalPolicy{Type: ApprovalMultiplier, Multiplier: 0.5},
+			requiredAmount:       big.NewInt(1000),
+			expectedErrorMessage: "approval multiplier must be at least 1",
+		},
+		{
+			description:          "Error - NaN multiplier",
+			policy:               ApprovalPolicy{Type: ApprovalMultiplier, Multiplier: math.NaN()},
+			requiredAmount:       big.NewInt(1000),
+			expectedErrorMessage: "approval multiplier must be at least 1",
+		},
+		{
+			description:          "Error - infinite multiplier",
+			policy:               ApprovalPolicy{Type: ApprovalMultiplier, Multiplier: math.Inf(1)},
+			requiredAmount:       big.NewInt(1000),
+			expectedErrorMessage: "approval multiplier must be at least 1",
+		},
+		{
+			description:          "Error - unknown policy type",
+			policy:               ApprovalPolicy{Type: 10},
+			requiredAmount:       big.NewInt(1000),
+			expectedErrorMessage: "unknown approval amount type: 10",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.description, func(t *testing.T) {
+			amount, err := tc.policy.GetApprovalAmount(tc.requiredAmount)
+			if tc.expectedErrorMessage != "" {
+				require.ErrorContains(t, err, tc.expectedErrorMessage)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, 0, tc.expectedAmount.Cmp(amount), "expected %s, got %s", tc.expectedAmount, amount)
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package onchain
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	"github.com/1inch/1inch-sdk-go/helpers/consts/abis"
+)
+
+var approvalEventTopic = crypto.Keccak256Hash([]byte("Approval(address,address,uint256)"))
+
+// GetApprovalLogs returns the ERC20 Approval events emitted for an owner, optionally restricted to a set of spenders
+// When chunkSize is set, the block range is queried in chunks of that size to stay within the limits of RPC providers
+func GetApprovalLogs(ctx context.Context, client *ethclient.Client, config ApprovalLogsConfig) ([]ApprovalLog, error) {
+	toBlock := config.ToBlock
+	if toBlock == 0 {
+		latestBlock, err := client.BlockNumber(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get latest block: %v", err)
+		}
+		toBlock = latestBlock
+	}
+	if config.FromBlock > toBlock {
+		return nil, fmt.Errorf("from block %d is after to block %d", config.FromBlock, toBlock)
+	}
+
+	var spenderTopics []common.Hash
+	for _, spender := range config.Spenders {
+		spenderTopics = append(spenderTopics, common.BytesToHash(spender.Bytes()))
+	}
+	topics := [][]common.Hash{
+		{approvalEventTopic},
+		{common.BytesToHash(config.Owner.Bytes())},
+		spenderTopics,
+	}
+
+	chunkSize := config.ChunkSize
+	if chunkSize == 0 {
+		chunkSize = toBlock - config.FromBlock + 1
+	}
+
+	var approvalLogs []ApprovalLog
+	for start := config.FromBlock; start <= toBlock; start += chunkSize {
+		end := start + chunkSize - 1
+		if end > toBlock {
+			end = toBlock
+		}
+
+		logs, err := client.FilterLogs(ctx, ethereum.FilterQuery{
+			FromBlock: new(big.Int).SetUint64(start),
+			ToBlock:   new(big.Int).SetUint64(end),
+			Topics:    topics,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get approval logs for blocks %d to %d: %v", start, end, err)
+		}
+
+		for _, log := range logs {
+			// ERC721 Approval events share the same signature but index the token id as well
+			if len(log.Topics) != 3 || len(log.Data) != 32 {
+				continue
+			}
+			approvalLogs = append(approvalLogs, ApprovalLog{
+				Token:       log.Address,
+				Owner:       common.BytesToAddress(log.Topics[1].Bytes()),
+				Spender:     common.BytesToAddress(log.Topics[2].Bytes()),
+				Value:       new(big.Int).SetBytes(log.Data),
+				BlockNumber: log.BlockNumber,
+				TxHash:      log.TxHash,
+			})
+		}
+
+		// Guard against overflow when the range ends at the maximum block number
+		if end == toBlock {
+			break
+		}
+	}
+
+	return approvalLogs, nil
+}
+
+// GetRevokeApprovalCalldata returns the calldata that resets the allowance of a spender to zero along with the method it uses
+// approve(spender, 0) is preferred, tokens that revert on it are revoked through decreaseAllowance instead
+func GetRevokeApprovalCalldata(client *ethclient.Client, tokenAddress common.Address, publicAddress common.Address, spenderAddress common.Address, allowance *big.Int) ([]byte, string, error) {
+	parsedABI, err := abi.JSON(strings.NewReader(abis.Erc20))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to parse ABI: %v", err)
+	}
+
+	data, err := parsedABI.Pack("approve", spenderAddress, big.NewInt(0))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to pack data for approve: %v", err)
+	}
+
+	_, err = client.CallContract(context.Background(), ethereum.CallMsg{
+		From: publicAddress,
+		To:   &tokenAddress,
+		Data: data,
+	}, nil)
+	if err == nil {
+		return data, "approve", nil
+	}
+	approveErr := err
+
+	data, err = parsedABI.Pack("decreaseAllowance", spenderAddress, allowance)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to pack data for decreaseAllowance: %v", err)
+	}
+
+	_, err = client.CallContract(context.Background(), ethereum.CallMsg{
+		From: publicAddress,
+		To:   &tokenAddress,
+		Data: data,
+	}, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("token rejected both approve (%v) and decreaseAllowance (%v)", approveErr, err)
+	}
+
+	return data, "decreaseAllowance", nil
+}
@@ -0,0 +1,149 @@
+package onchain
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/1inch/1inch-sdk-go/helpers/consts/addresses"
+	"github.com/1inch/1inch-sdk-go/helpers/consts/contracts"
+	"github.com/1inch/1inch-sdk-go/helpers/consts/tokens"
+)
+
+func TestGetApprovalLogs(t *testing.T) {
+	owner := common.HexToAddress(addresses.Vitalik)
+	router := common.HexToAddress(contracts.AggregationRouterV5)
+
+	approvalLog := func(token string, blockNumber uint64, value int64) *types.Log {
+		return &types.Log{
+			Address:     common.HexToAddress(token),
+			Topics:      []common.Hash{approvalEventTopic, common.BytesToHash(owner.Bytes()), common.BytesToHash(router.Bytes())},
+			Data:        common.BigToHash(big.NewInt(value)).Bytes(),
+			BlockNumber: blockNumber,
+		}
+	}
+	// ERC721 approvals index the token id and carry no data
+	nftApprovalLog := &types.Log{
+		Address:     common.HexToAddress("0xbc4ca0eda7647a8ab7c2061c2e118a18a936f13d"),
+		Topics:      []common.Hash{approvalEventTopic, common.BytesToHash(owner.Bytes()), common.BytesToHash(router.Bytes()), common.BigToHash(big.NewInt(1))},
+		BlockNumber: 150,
+	}
+	chainLogs := []*types.Log{
+		approvalLog(tokens.EthereumUsdc, 100, 1000),
+		nftApprovalLog,
+		approvalLog(tokens.EthereumDai, 250, 2000),
+	}
+
+	testcases := []struct {
+		description        string
+		config             ApprovalLogsConfig
+		expectedRanges     [][2]uint64
+		expectedLogsCount  int
+		expectedFirstValue int64
+	}{
+		{
+			description:        "Single query up to the latest block",
+			config:             ApprovalLogsConfig{Owner: owner, Spenders: []common.Address{router}},
+			expectedRanges:     [][2]uint64{{0, 300}},
+			expectedLogsCount:  2,
+			expectedFirstValue: 1000,
+		},
+		{
+			description:        "Chunked query",
+			config:             ApprovalLogsConfig{Owner: owner, FromBlock: 100, ToBlock: 300, ChunkSize: 100},
+			expectedRanges:     [][2]uint64{{100, 199}, {200, 299}, {300, 300}},
+			expectedLogsCount:  2,
+			expectedFirstValue: 1000,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.description, func(t *testing.T) {
+			var ranges [][2]uint64
+			client := setupRpc(t, map[string]rpcHandler{
+				"eth_blockNumber": func(params []json.RawMessage) (interface{}, error) {
+					return hexutil.Uint64(300), nil
+				},
+				"eth_getLogs": func(params []json.RawMessage) (interface{}, error) {
+					var filter struct {
+						FromBlock hexutil.Uint64  `json:"fromBlock"`
+						ToBlock   hexutil.Uint64  `json:"toBlock"`
+						Topics    [][]common.Hash `json:"topics"`
+					}
+					require.NoError(t, json.Unmarshal(params[0], &filter))
+					require.Equal(t, approvalEventTopic, filter.Topics[0][0])
+					require.Equal(t, common.BytesToHash(owner.Bytes()), filter.Topics[1][0])
+					ranges = append(ranges, [2]uint64{uint64(filter.FromBlock), uint64(filter.ToBlock)})
+
+					result := []*types.Log{}
+					for _, log := range chainLogs {
+						if log.BlockNumber >= uint64(filter.FromBlock) && log.BlockNumber <= uint64(filter.ToBlock) {
+							result = append(result, log)
+						}
+					}
+					return result, nil
+				},
+			})
+
+			approvalLogs, err := GetApprovalLogs(context.Background(), client, tc.config)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedRanges, ranges)
+			require.Len(t, approvalLogs, tc.expectedLogsCount)
+			require.Equal(t, router, approvalLogs[0].Spender)
+			require.Equal(t, owner, approvalLogs[0].Owner)
+			require.Equal(t, big.NewInt(tc.expectedFirstValue), approvalLogs[0].Value)
+		})
+	}
+}
+
+func TestGetRevokeApprovalCalldata(t *testing.T) {
+	token := common.HexToAddress(tokens.EthereumUsdc)
+	owner := common.HexToAddress(addresses.Vitalik)
+	router := common.HexToAddress(contracts.AggregationRouterV5)
+
+	testcases := []struct {
+		description          string
+		results              map[string][]byte
+		expectedMethod       string
+		expectedErrorMessage string
+	}{
+		{
+			description:    "Token accepts a zero approval",
+			results:        map[string][]byte{"approve": common.BigToHash(big.NewInt(1)).Bytes()},
+			expectedMethod: "approve",
+		},
+		{
+			description:    "Token rejects a zero approval",
+			results:        map[string][]byte{"decreaseAllowance": common.BigToHash(big.NewInt(1)).Bytes()},
+			expectedMethod: "decreaseAllowance",
+		},
+		{
+			description:          "Error - token rejects both",
+			results:              map[string][]byte{},
+			expectedErrorMessage: "token rejected both approve",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.description, func(t *testing.T) {
+			client := setupRpc(t, map[string]rpcHandler{
+				"eth_call": erc20CallHandler(t, tc.results),
+			})
+
+			data, method, err := GetRevokeApprovalCalldata(client, token, owner, router, big.NewInt(500))
+			if tc.expectedErrorMessage != "" {
+				require.ErrorContains(t, err, tc.expectedErrorMessage)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedMethod, method)
+			require.Len(t, data, 4+32+32)
+		})
+	}
+}
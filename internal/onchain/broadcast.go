@@ -0,0 +1,254 @@
+package onchain
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// defaultRelayBlockRange is the number of blocks a private relay may include a transaction in before it is dropped
+const defaultRelayBlockRange = 25
+
+// Broadcaster sends signed transactions to the network
+type Broadcaster interface {
+	Broadcast(ctx context.Context, tx *types.Transaction) (Submission, error)
+}
+
+// Submission describes how a transaction was sent
+type Submission struct {
+	// Private is set when the transaction skipped the public mempool, public nodes do not know about it until it is mined
+	Private bool
+	// LastBlock is the last block a private transaction can be included in, it is dropped after that
+	LastBlock uint64
+}
+
+// PublicBroadcaster sends transactions to the public mempool through an RPC provider
+type PublicBroadcaster struct {
+	client *ethclient.Client
+}
+
+func NewPublicBroadcaster(client *ethclient.Client) *PublicBroadcaster {
+	return &PublicBroadcaster{client: client}
+}
+
+// Broadcast sends the transaction to the provider of the client
+// A provider pool retries a send that failed on one provider with the next one, so a transaction the first provider accepted
+// before failing can be reported as already known by the next. It is in the mempool either way, so that counts as sent
+func (b *PublicBroadcaster) Broadcast(ctx context.Context, tx *types.Transaction) (Submission, error) {
+	err := b.client.SendTransaction(ctx, tx)
+	if err != nil && !isAlreadyKnown(err) {
+		return Submission{}, err
+	}
+	return Submission{}, nil
+}
+
+// PrivateRelayConfig describes a relay that keeps transactions out of the public mempool until they are mined
+type PrivateRelayConfig struct {
+	Url string
+	// AuthKey signs the X-Flashbots-Signature header of every request, it identifies the sender to the relay and must not hold funds
+	// Requests are not signed when nil
+	AuthKey *ecdsa.PrivateKey
+	// UseBundles sends each transaction as a single transaction bundle with eth_sendBundle instead of eth_sendPrivateTransaction
+	UseBundles bool
+	// BlockRange is the number of blocks the relay may include the transaction in (defaults to 25)
+	BlockRange uint64
+	// HttpClient is optional, defaults to http.DefaultClient
+	HttpClient *http.Client
+}
+
+// PrivateRelayBroadcaster sends transactions to a Flashbots-style private relay
+// The client is only used to read the current block number, transactions are never sent to it
+type PrivateRelayBroadcaster struct {
+	client *ethclient.Client
+	config PrivateRelayConfig
+}
+
+func NewPrivateRelayBroadcaster(client *ethclient.Client, config PrivateRelayConfig) *PrivateRelayBroadcaster {
+	if config.BlockRange == 0 {
+		config.BlockRange = defaultRelayBlockRange
+	}
+	if config.HttpClient == nil {
+		config.HttpClient = http.DefaultClient
+	}
+	return &PrivateRelayBroadcaster{client: client, config: config}
+}
+
+func (b *PrivateRelayBroadcaster) Broadcast(ctx context.Context, tx *types.Transaction) (Submission, error) {
+	raw, err := tx.MarshalBinary()
+	if err != nil {
+		return Submission{}, fmt.Errorf("failed to encode transaction: %v", err)
+	}
+
+	latestBlock, err := b.client.BlockNumber(ctx)
+	if err != nil {
+		return Submission{}, fmt.Errorf("failed to get latest block: %v", err)
+	}
+	lastBlock := latestBlock + b.config.BlockRange
+
+	if !b.config.UseBundles {
+		params := map[string]interface{}{
+			"tx":             hexutil.Encode(raw),
+			"maxBlockNumber": hexutil.Uint64(lastBlock),
+		}
+		err = b.call(ctx, "eth_sendPrivateTransaction", params)
+		if err != nil {
+			return Submission{}, err
+		}
+		return Submission{Private: true, LastBlock: lastBlock}, nil
+	}
+
+	// A bundle targets a single block, so the same bundle is sent for every block of the range
+	for block := latestBlock + 1; block <= lastBlock; block++ {
+		params := map[string]interface{}{
+			"txs":         []string{hexutil.Encode(raw)},
+			"blockNumber": hexutil.Uint64(block),
+		}
+		err = b.call(ctx, "eth_sendBundle", params)
+		if err != nil {
+			return Submission{}, err
+		}
+	}
+	return Submission{Private: true, LastBlock: lastBlock}, nil
+}
+
+// call sends a JSON-RPC request to the relay, signed with the Flashbots signature scheme when an auth key is set
+func (b *PrivateRelayBroadcaster) call(ctx context.Context, method string, params interface{}) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  method,
+		"params":  []interface{}{params},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s request: %v", method, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.config.Url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	if b.config.AuthKey != nil {
+		signature, err := SignFlashbotsPayload(body, b.config.AuthKey)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("X-Flashbots-Signature", signature)
+	}
+
+	res, err := b.config.HttpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send %s request: %v", method, err)
+	}
+	defer res.Body.Close()
+
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %v", err)
+	}
+
+	var response struct {
+		Error *struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	err = json.Unmarshal(data, &response)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal response body - response code: %d - raw response body: %s", res.StatusCode, strings.TrimSpace(string(data)))
+	}
+	if response.Error != nil {
+		return fmt.Errorf("relay rejected %s: %s", method, response.Error.Message)
+	}
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("relay rejected %s - response code: %d", method, res.StatusCode)
+	}
+	return nil
+}
+
+// SignFlashbotsPayload returns the X-Flashbots-Signature header of a request body
+// The header is the signer address and its EIP-191 signature of the hex encoded keccak256 hash of the body
+func SignFlashbotsPayload(body []byte, key *ecdsa.PrivateKey) (string, error) {
+	bodyHash := hexutil.Encode(crypto.Keccak256(body))
+	signature, err := crypto.Sign(accounts.TextHash([]byte(bodyHash)), key)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign relay request: %v", err)
+	}
+	signature[64] += 27
+	return fmt.Sprintf("%s:%s", crypto.PubkeyToAddress(key.PublicKey).Hex(), hexutil.Encode(signature)), nil
+}
+
+// MultiBroadcaster sends every transaction through several broadcasters at once
+// Sending succeeds as soon as one of them accepts the transaction
+type MultiBroadcaster struct {
+	broadcasters []Broadcaster
+}
+
+func NewMultiBroadcaster(broadcasters ...Broadcaster) *MultiBroadcaster {
+	return &MultiBroadcaster{broadcasters: broadcasters}
+}
+
+func (b *MultiBroadcaster) Broadcast(ctx context.Context, tx *types.Transaction) (Submission, error) {
+	type result struct {
+		submission Submission
+		err        error
+	}
+	results := make([]result, len(b.broadcasters))
+
+	var wg sync.WaitGroup
+	for i, broadcaster := range b.broadcasters {
+		wg.Add(1)
+		go func(i int, broadcaster Broadcaster) {
+			defer wg.Done()
+			submission, err := broadcaster.Broadcast(ctx, tx)
+			results[i] = result{submission: submission, err: err}
+		}(i, broadcaster)
+	}
+	wg.Wait()
+
+	// The transaction is only private when no broadcaster leaked it to the public mempool
+	var accepted bool
+	var errs []error
+	merged := Submission{Private: true}
+	for i, r := range results {
+		if r.err != nil && !isAlreadyKnown(r.err) {
+			errs = append(errs, fmt.Errorf("broadcaster %d: %v", i+1, r.err))
+			continue
+		}
+		accepted = true
+		merged.Private = merged.Private && r.submission.Private
+		if r.submission.LastBlock > merged.LastBlock {
+			merged.LastBlock = r.submission.LastBlock
+		}
+	}
+	if !accepted {
+		return Submission{}, fmt.Errorf("no broadcaster accepted the transaction: %v", errors.Join(errs...))
+	}
+	for _, err := range errs {
+		fmt.Printf("Failed to broadcast transaction: %v\n", err)
+	}
+	if !merged.Private {
+		merged.LastBlock = 0
+	}
+	return merged, nil
+}
+
+// isAlreadyKnown returns true for the errors of nodes that already received the transaction from another broadcaster
+func isAlreadyKnown(err error) bool {
+	message := strings.ToLower(err.Error())
+	return strings.Contains(message, "already known") || strings.Contains(message, "known transaction")
+}
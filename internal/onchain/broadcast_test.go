@@ -0,0 +1,289 @@
+package onchain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/1inch/1inch-sdk-go/internal/web3"
+)
+
+func testSignedTx(t *testing.T) *types.Transaction {
+	privateKey, err := crypto.HexToECDSA(testKey)
+	require.NoError(t, err)
+	to := crypto.PubkeyToAddress(privateKey.PublicKey)
+	tx, err := types.SignTx(types.NewTx(&types.DynamicFeeTx{
+		ChainID:   big.NewInt(1),
+		Nonce:     7,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(1),
+		Gas:       21000,
+		To:        &to,
+		Value:     big.NewInt(0),
+	}), types.LatestSignerForChainID(big.NewInt(1)), privateKey)
+	require.NoError(t, err)
+	return tx
+}
+
+func TestPrivateRelayBroadcaster(t *testing.T) {
+	tx := testSignedTx(t)
+	raw, err := tx.MarshalBinary()
+	require.NoError(t, err)
+
+	authKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	testcases := []struct {
+		description       string
+		useBundles        bool
+		relayError        string
+		expectedMethod    string
+		expectedRequests  int
+		expectedLastBlock uint64
+		expectedError     string
+	}{
+		{
+			description:       "Private transaction",
+			expectedMethod:    "eth_sendPrivateTransaction",
+			expectedRequests:  1,
+			expectedLastBlock: 103,
+		},
+		{
+			description:       "Bundle sent for every block of the range",
+			useBundles:        true,
+			expectedMethod:    "eth_sendBundle",
+			expectedRequests:  3,
+			expectedLastBlock: 103,
+		},
+		{
+			description:    "Error - relay rejects the transaction",
+			relayError:     "nonce too low",
+			expectedMethod: "eth_sendPrivateTransaction",
+			expectedError:  "relay rejected eth_sendPrivateTransaction: nonce too low",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.description, func(t *testing.T) {
+			var requests []map[string]interface{}
+			relay := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+
+				// The signature header has to recover to the auth key
+				header := strings.Split(r.Header.Get("X-Flashbots-Signature"), ":")
+				require.Len(t, header, 2)
+				signature := hexutil.MustDecode(header[1])
+				signature[64] -= 27
+				signer, err := crypto.SigToPub(accounts.TextHash([]byte(hexutil.Encode(crypto.Keccak256(body)))), signature)
+				require.NoError(t, err)
+				require.Equal(t, crypto.PubkeyToAddress(authKey.PublicKey).Hex(), header[0])
+				require.Equal(t, crypto.PubkeyToAddress(authKey.PublicKey), crypto.PubkeyToAddress(*signer))
+
+				var request struct {
+					Method string                   `json:"method"`
+					Params []map[string]interface{} `json:"params"`
+				}
+				require.NoError(t, json.Unmarshal(body, &request))
+				require.Equal(t, tc.expectedMethod, request.Method)
+				requests = append(requests, request.Params[0])
+
+				response := map[string]interface{}{"jsonrpc": "2.0", "id": 1, "result": tx.Hash()}
+				if tc.relayError != "" {
+					response = map[string]interface{}{"jsonrpc": "2.0", "id": 1, "error": map[string]interface{}{"code": -32000, "message": tc.relayError}}
+				}
+				require.NoError(t, json.NewEncoder(w).Encode(response))
+			}))
+			defer relay.Close()
+
+			client := setupRpc(t, map[string]rpcHandler{
+				"eth_blockNumber": func(params []json.RawMessage) (interface{}, error) {
+					return hexutil.Uint64(100), nil
+				},
+			})
+
+			broadcaster := NewPrivateRelayBroadcaster(client, PrivateRelayConfig{
+				Url:        relay.URL,
+				AuthKey:    authKey,
+				UseBundles: tc.useBundles,
+				BlockRange: 3,
+			})
+			submission, err := broadcaster.Broadcast(context.Background(), tx)
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.True(t, submission.Private)
+			require.Equal(t, tc.expectedLastBlock, submission.LastBlock)
+			require.Len(t, requests, tc.expectedRequests)
+
+			if tc.useBundles {
+				for i, request := range requests {
+					require.Equal(t, []interface{}{hexutil.Encode(raw)}, request["txs"])
+					require.Equal(t, hexutil.EncodeUint64(uint64(101+i)), request["blockNumber"])
+				}
+			} else {
+				require.Equal(t, hexutil.Encode(raw), requests[0]["tx"])
+				require.Equal(t, hexutil.EncodeUint64(103), requests[0]["maxBlockNumber"])
+			}
+		})
+	}
+}
+
+type stubBroadcaster struct {
+	submission Submission
+	err        error
+
+	mu    sync.Mutex
+	calls int
+}
+
+func (b *stubBroadcaster) Broadcast(ctx context.Context, tx *types.Transaction) (Submission, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.calls++
+	return b.submission, b.err
+}
+
+func TestMultiBroadcaster(t *testing.T) {
+	testcases := []struct {
+		description        string
+		broadcasters       []*stubBroadcaster
+		expectedSubmission Submission
+		expectedError      string
+	}{
+		{
+			description: "Accepted when one broadcaster succeeds",
+			broadcasters: []*stubBroadcaster{
+				{err: errors.New("connection refused")},
+				{},
+			},
+		},
+		{
+			description: "Transactions already known by a node count as accepted",
+			broadcasters: []*stubBroadcaster{
+				{err: errors.New("already known")},
+				{err: errors.New("connection refused")},
+			},
+		},
+		{
+			description: "Private when every relay accepted it",
+			broadcasters: []*stubBroadcaster{
+				{submission: Submission{Private: true, LastBlock: 10}},
+				{submission: Submission{Private: true, LastBlock: 12}},
+			},
+			expectedSubmission: Submission{Private: true, LastBlock: 12},
+		},
+		{
+			description: "Public when any broadcaster sent it to the mempool",
+			broadcasters: []*stubBroadcaster{
+				{submission: Submission{Private: true, LastBlock: 10}},
+				{},
+			},
+		},
+		{
+			description: "Error - no broadcaster accepted the transaction",
+			broadcasters: []*stubBroadcaster{
+				{err: errors.New("connection refused")},
+				{err: errors.New("nonce too low")},
+			},
+			expectedError: "no broadcaster accepted the transaction: broadcaster 1: connection refused\nbroadcaster 2: nonce too low",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.description, func(t *testing.T) {
+			var broadcasters []Broadcaster
+			for _, broadcaster := range tc.broadcasters {
+				broadcasters = append(broadcasters, broadcaster)
+			}
+
+			submission, err := NewMultiBroadcaster(broadcasters...).Broadcast(context.Background(), testSignedTx(t))
+			for _, broadcaster := range tc.broadcasters {
+				require.Equal(t, 1, broadcaster.calls)
+			}
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedSubmission, submission)
+		})
+	}
+}
+
+func TestPublicBroadcasterAfterFailover(t *testing.T) {
+	// The first provider accepts the transaction but fails to answer, so the pool sends it to the second one as well
+	provider := func(sendRawTransaction func(w http.ResponseWriter, id json.RawMessage)) *httptest.Server {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var request struct {
+				Id     json.RawMessage `json:"id"`
+				Method string          `json:"method"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+			if request.Method == "eth_sendRawTransaction" {
+				sendRawTransaction(w, request.Id)
+				return
+			}
+			require.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": request.Id, "result": hexutil.Uint64(1)}))
+		}))
+		t.Cleanup(server.Close)
+		return server
+	}
+	rpcError := func(message string) func(w http.ResponseWriter, id json.RawMessage) {
+		return func(w http.ResponseWriter, id json.RawMessage) {
+			require.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": id, "error": map[string]interface{}{"code": -32000, "message": message}}))
+		}
+	}
+	badGateway := func(w http.ResponseWriter, id json.RawMessage) {
+		w.WriteHeader(http.StatusBadGateway)
+	}
+
+	testcases := []struct {
+		description   string
+		fallback      func(w http.ResponseWriter, id json.RawMessage)
+		expectedError string
+	}{
+		{
+			description: "Already known by the next provider",
+			fallback:    rpcError("already known"),
+		},
+		{
+			description:   "Error - rejected by the next provider",
+			fallback:      rpcError("nonce too low"),
+			expectedError: "nonce too low",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.description, func(t *testing.T) {
+			pool, err := web3.NewPool(1, []web3.ProviderConfig{
+				{Url: provider(badGateway).URL, Priority: 0},
+				{Url: provider(tc.fallback).URL, Priority: 1},
+			}, web3.HealthCheckConfig{Disabled: true})
+			require.NoError(t, err)
+			t.Cleanup(pool.Close)
+
+			_, err = NewPublicBroadcaster(pool.EthClient()).Broadcast(context.Background(), testSignedTx(t))
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package onchain
+
+import (
+	"errors"
+	"time"
+)
+
+// DefaultDeadline is used for permits and orders when neither their params nor the client set a deadline
+const DefaultDeadline = time.Minute
+
+// Deadline is a point in time given either as a duration from when it is used or as an absolute time
+// The zero value is unset, in which case the default of the client is used
+type Deadline struct {
+	// After is the time left from the moment the deadline is resolved
+	After time.Duration
+	// At is an absolute deadline, it cannot be combined with After
+	At time.Time
+}
+
+// DeadlineAfter returns a deadline the duration after it is resolved
+func DeadlineAfter(duration time.Duration) Deadline {
+	return Deadline{After: duration}
+}
+
+// DeadlineAt returns an absolute deadline
+func DeadlineAt(at time.Time) Deadline {
+	return Deadline{At: at}
+}
+
+// IsZero returns true when the deadline is unset
+func (d Deadline) IsZero() bool {
+	return d.After == 0 && d.At.IsZero()
+}
+
+// Validate checks that the deadline is either a positive duration or a future time
+func (d Deadline) Validate() error {
+	if d.After != 0 && !d.At.IsZero() {
+		return errors.New("deadline cannot have both a duration and an absolute time")
+	}
+	if d.After < 0 {
+		return errors.New("deadline duration cannot be negative")
+	}
+	if !d.At.IsZero() && !d.At.After(time.Now()) {
+		return errors.New("deadline must be in the future")
+	}
+	return nil
+}
+
+// Unix returns the deadline as a unix timestamp in seconds, durations are counted from now
+func (d Deadline) Unix(now time.Time) int64 {
+	if !d.At.IsZero() {
+		return d.At.Unix()
+	}
+	return now.Add(d.After).Unix()
+}
+
+// ResolveDeadline returns the first deadline that is set as a unix timestamp in seconds, or DefaultDeadline from now
+func ResolveDeadline(now time.Time, deadlines ...Deadline) int64 {
+	for _, deadline := range deadlines {
+		if !deadline.IsZero() {
+			return deadline.Unix(now)
+		}
+	}
+	return now.Add(DefaultDeadline).Unix()
+}
@@ -0,0 +1,95 @@
+package onchain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveDeadline(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	testcases := []struct {
+		description string
+		deadlines   []Deadline
+		expected    int64
+	}{
+		{
+			description: "Defaults to one minute",
+			expected:    1700000060,
+		},
+		{
+			description: "Unset deadlines fall through to the default",
+			deadlines:   []Deadline{{}, {}},
+			expected:    1700000060,
+		},
+		{
+			description: "Duration",
+			deadlines:   []Deadline{DeadlineAfter(time.Hour)},
+			expected:    1700003600,
+		},
+		{
+			description: "Absolute time",
+			deadlines:   []Deadline{DeadlineAt(time.Unix(1800000000, 0))},
+			expected:    1800000000,
+		},
+		{
+			description: "First deadline that is set wins",
+			deadlines:   []Deadline{{}, DeadlineAfter(10 * time.Minute), DeadlineAfter(time.Hour)},
+			expected:    1700000600,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.description, func(t *testing.T) {
+			require.Equal(t, tc.expected, ResolveDeadline(now, tc.deadlines...))
+		})
+	}
+}
+
+func TestDeadlineValidate(t *testing.T) {
+	testcases := []struct {
+		description   string
+		deadline      Deadline
+		expectedError string
+	}{
+		{
+			description: "Unset",
+		},
+		{
+			description: "Duration",
+			deadline:    DeadlineAfter(time.Hour),
+		},
+		{
+			description: "Future time",
+			deadline:    DeadlineAt(time.Now().Add(time.Hour)),
+		},
+		{
+			description:   "Error - negative duration",
+			deadline:      DeadlineAfter(-time.Minute),
+			expectedError: "deadline duration cannot be negative",
+		},
+		{
+			description:   "Error - past time",
+			deadline:      DeadlineAt(time.Now().Add(-time.Minute)),
+			expectedError: "deadline must be in the future",
+		},
+		{
+			description:   "Error - duration and absolute time",
+			deadline:      Deadline{After: time.Minute, At: time.Now().Add(time.Hour)},
+			expectedError: "deadline cannot have both a duration and an absolute time",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := tc.deadline.Validate()
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
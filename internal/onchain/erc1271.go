@@ -0,0 +1,67 @@
+package onchain
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/1inch/1inch-sdk-go/helpers/consts/abis"
+)
+
+// Erc1271MagicValue is returned by isValidSignature when a contract accepts a signature
+var Erc1271MagicValue = [4]byte{0x16, 0x26, 0xba, 0x7e}
+
+// IsValidSignature asks a smart contract wallet whether it accepts a signature of a hash, as defined by EIP-1271
+// A call that reverts or returns anything but the magic value means the signature is rejected
+func IsValidSignature(ctx context.Context, client *ethclient.Client, contractAddress common.Address, hash common.Hash, signature []byte) (bool, error) {
+	code, err := client.CodeAt(ctx, contractAddress, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to get code of %s: %v", contractAddress.Hex(), err)
+	}
+	if len(code) == 0 {
+		return false, fmt.Errorf("%s is not a contract", contractAddress.Hex())
+	}
+
+	parsedABI, err := abi.JSON(strings.NewReader(abis.Erc1271))
+	if err != nil {
+		return false, fmt.Errorf("failed to parse ABI: %v", err)
+	}
+
+	data, err := parsedABI.Pack("isValidSignature", hash, signature)
+	if err != nil {
+		return false, fmt.Errorf("failed to pack data for isValidSignature: %v", err)
+	}
+
+	result, err := client.CallContract(ctx, ethereum.CallMsg{To: &contractAddress, Data: data}, nil)
+	if err != nil {
+		// Wallets commonly revert on signatures they do not recognize instead of returning a failure value
+		if isExecutionReverted(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to call isValidSignature: %v", err)
+	}
+
+	return len(result) >= 4 && bytes.Equal(result[:4], Erc1271MagicValue[:]), nil
+}
+
+// executionRevertedErrorCode is the JSON-RPC error code nodes answer an eth_call that reverted with
+const executionRevertedErrorCode = 3
+
+// isExecutionReverted reports whether the node rejected a call because the contract reverted, rather than because the
+// request itself failed. Reverts carry the revert data along with error code 3.
+func isExecutionReverted(err error) bool {
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) && rpcErr.ErrorCode() == executionRevertedErrorCode {
+		return true
+	}
+	var dataErr rpc.DataError
+	return errors.As(err, &dataErr) && dataErr.ErrorData() != nil
+}
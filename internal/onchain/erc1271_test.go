@@ -0,0 +1,72 @@
+package onchain
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsValidSignature(t *testing.T) {
+	wallet := common.HexToAddress("0x2c9b2DBdbA8A9c969Ac24153f5C1c23CB0e63914")
+	magicValue := common.RightPadBytes(Erc1271MagicValue[:], 32)
+
+	testcases := []struct {
+		description          string
+		code                 hexutil.Bytes
+		call                 rpcHandler
+		expectedValid        bool
+		expectedErrorMessage string
+	}{
+		{
+			description:   "Signature accepted",
+			code:          hexutil.Bytes{0x60, 0x80},
+			call:          func(params []json.RawMessage) (interface{}, error) { return hexutil.Bytes(magicValue), nil },
+			expectedValid: true,
+		},
+		{
+			description: "Signature rejected with another value",
+			code:        hexutil.Bytes{0x60, 0x80},
+			call: func(params []json.RawMessage) (interface{}, error) {
+				return hexutil.Bytes(make([]byte, 32)), nil
+			},
+		},
+		{
+			description: "Signature rejected with a revert",
+			code:        hexutil.Bytes{0x60, 0x80},
+			call:        func(params []json.RawMessage) (interface{}, error) { return nil, errExecutionReverted },
+		},
+		{
+			description:          "Error - call fails for another reason",
+			code:                 hexutil.Bytes{0x60, 0x80},
+			expectedErrorMessage: "failed to call isValidSignature: method not found",
+		},
+		{
+			description:          "Error - wallet is not a contract",
+			expectedErrorMessage: "is not a contract",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.description, func(t *testing.T) {
+			handlers := map[string]rpcHandler{
+				"eth_getCode": func(params []json.RawMessage) (interface{}, error) { return tc.code, nil },
+			}
+			if tc.call != nil {
+				handlers["eth_call"] = tc.call
+			}
+			client := setupRpc(t, handlers)
+
+			valid, err := IsValidSignature(context.Background(), client, wallet, common.HexToHash("0x01"), []byte{0xab})
+			if tc.expectedErrorMessage != "" {
+				require.ErrorContains(t, err, tc.expectedErrorMessage)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedValid, valid)
+		})
+	}
+}
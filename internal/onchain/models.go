@@ -3,7 +3,10 @@ package onchain
 import (
 	"math/big"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/1inch/1inch-sdk-go/internal/web3"
 )
 
 type TxConfig struct {
@@ -14,6 +17,11 @@ type TxConfig struct {
 	Value         *big.Int
 	To            string
 	Data          []byte
+	Gas           uint64            // Optional, defaults to the estimate raised by 25%, or a limit high enough for any swap when it cannot be estimated
+	MaxFee        *big.Int          // Optional, the transaction is not sent when its gas limit at its fee cap could cost more
+	HeadWatcher   *web3.HeadWatcher // Optional, the receipt is polled every second when nil
+	WaitOptions   WaitOptions       // Optional, defaults to waiting for the first receipt
+	Broadcaster   Broadcaster       // Optional, defaults to sending the transaction through the eth client
 }
 
 type Erc20ApprovalConfig struct {
@@ -22,6 +30,11 @@ type Erc20ApprovalConfig struct {
 	Erc20Address   common.Address
 	PublicAddress  common.Address
 	SpenderAddress common.Address
+	Amount         *big.Int          // Optional, defaults to an unlimited approval
+	MaxFee         *big.Int          // Optional, the approval is not sent when its gas limit at its fee cap could cost more
+	HeadWatcher    *web3.HeadWatcher // Optional, the receipt is polled every second when nil
+	WaitOptions    WaitOptions       // Optional, defaults to waiting for the first receipt
+	Broadcaster    Broadcaster       // Optional, defaults to sending the transaction through the eth client
 }
 
 type Erc20RevokeConfig struct {
@@ -31,6 +44,30 @@ type Erc20RevokeConfig struct {
 	PublicAddress           common.Address
 	SpenderAddress          common.Address
 	AllowanceDecreaseAmount *big.Int
+	HeadWatcher             *web3.HeadWatcher // Optional, the receipt is polled every second when nil
+	WaitOptions             WaitOptions       // Optional, defaults to waiting for the first receipt
+	Broadcaster             Broadcaster       // Optional, defaults to sending the transaction through the eth client
+}
+
+type WrapNativeConfig struct {
+	ChainId              int
+	Key                  string
+	WrappedNativeAddress common.Address
+	PublicAddress        common.Address
+	Amount               *big.Int
+	HeadWatcher          *web3.HeadWatcher // Optional, the receipt is polled every second when nil
+	WaitOptions          WaitOptions       // Optional, defaults to waiting for the first receipt
+	Broadcaster          Broadcaster       // Optional, defaults to sending the transaction through the eth client
+}
+
+type PreflightConfig struct {
+	PublicAddress common.Address
+	Token         common.Address // Optional, the ERC20 token spent by the transactions
+	TokenSymbol   string
+	TokenDecimals uint8
+	Amount        *big.Int // Optional, the amount of the ERC20 token spent
+	NativeSymbol  string
+	Transactions  []ethereum.CallMsg // Transactions sent by the wallet, Gas is only used when they cannot be estimated
 }
 
 type PermitSignatureConfig struct {
@@ -42,6 +79,9 @@ type PermitSignatureConfig struct {
 	Key           string
 	Nonce         int64
 	Deadline      int64
+	Value         *big.Int // Optional, defaults to an unlimited allowance
+	Type          PermitType
+	Domain        *PermitDomain // Optional, defaults to a name/version/chainId/verifyingContract domain
 }
 
 type PermitParamsConfig struct {
@@ -50,8 +90,44 @@ type PermitParamsConfig struct {
 	Value     *big.Int
 	Deadline  int64
 	Signature string
+	Type      PermitType
+	Nonce     int64 // Only used by DAI-style permits
 }
 
+type PermitType int
+
+const (
+	PermitTypeEip2612 PermitType = iota
+	PermitTypeDai
+)
+
+// EIP-5267 bits describing which fields are part of an EIP-712 domain
+const (
+	DomainFieldName              byte = 0x01
+	DomainFieldVersion           byte = 0x02
+	DomainFieldChainId           byte = 0x04
+	DomainFieldVerifyingContract byte = 0x08
+	DomainFieldSalt              byte = 0x10
+)
+
+type PermitDomain struct {
+	Fields            byte
+	Name              string
+	Version           string
+	ChainId           *big.Int
+	VerifyingContract common.Address
+	Salt              common.Hash
+}
+
+type PermitInfo struct {
+	Type   PermitType
+	Domain PermitDomain
+}
+
+// ApprovalType selects how the router is allowed to spend a token. Permits are passed to AggregationRouterV5 as
+// the arguments of an EIP-2612 (224 bytes) or DAI-style (256 bytes) permit call on the token itself, which is the only
+// format its SafeERC20.safePermit accepts. The router never calls Permit2, so there is no Permit2 approval type and
+// tokens without a permit fall back to an onchain approval of the router.
 type ApprovalType int
 
 const (
@@ -59,3 +135,20 @@ const (
 	PermitAlways
 	ApprovalAlways
 )
+
+type ApprovalLogsConfig struct {
+	Owner     common.Address
+	Spenders  []common.Address // Optional, defaults to all spenders
+	FromBlock uint64
+	ToBlock   uint64 // Optional, defaults to the latest block
+	ChunkSize uint64 // Optional, defaults to querying the whole range at once
+}
+
+type ApprovalLog struct {
+	Token       common.Address
+	Owner       common.Address
+	Spender     common.Address
+	Value       *big.Int
+	BlockNumber uint64
+	TxHash      common.Hash
+}
@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"math/big"
 	"strings"
-	"time"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
@@ -21,7 +20,12 @@ import (
 	"github.com/1inch/1inch-sdk-go/helpers/consts/chains"
 )
 
-const gasLimit = uint64(21000000) // TODO make sure this value more dynamic
+// gasLimit is the gas limit of transactions that cannot be estimated and were not given one
+const gasLimit = uint64(21000000)
+
+// ErrMaxFeeExceeded is wrapped in the error returned for a transaction whose gas limit at its fee cap could cost more
+// than the maximum fee it was given
+var ErrMaxFeeExceeded = errors.New("transaction could cost more than the maximum fee")
 
 // TODO: this nonce value will compete with any pending transactions on the wallet. The user should be able to set this if they want
 
@@ -38,54 +42,133 @@ func GetNonce(ethClient *ethclient.Client, key string, publicAddress common.Addr
 	return nonce, nil
 }
 
-func ExecuteTransaction(ctx context.Context, txConfig TxConfig, ethClient *ethclient.Client, nonceCache map[string]uint64) error {
+// ExecuteTransaction signs and sends a transaction, then waits for its receipt
+// The receipt of a reverted transaction is returned along with the error
+func ExecuteTransaction(ctx context.Context, txConfig TxConfig, ethClient *ethclient.Client, nonceCache map[string]uint64) (*types.Receipt, error) {
 
 	nonceCacheKey := fmt.Sprintf("%s+%d", txConfig.PublicAddress, txConfig.ChainId.Int64())
 	nonce, err := GetNonce(ethClient, nonceCacheKey, txConfig.PublicAddress, nonceCache)
+	if err != nil {
+		return nil, err
+	}
+
+	if txConfig.Gas == 0 {
+		txConfig.Gas, err = EstimateGasLimit(ctx, ethClient, txConfig, gasLimit)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	swapTx, err := GetTx(ethClient, nonce, txConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build transaction: %w", err)
+	}
 
 	signingKey, err := crypto.HexToECDSA(txConfig.PrivateKey)
 	if err != nil {
-		return fmt.Errorf("failed to convert private key: %v", err)
+		return nil, fmt.Errorf("failed to convert private key: %v", err)
 	}
 
 	// Sign the transaction
 	swapTxSigned, err := types.SignTx(swapTx, types.LatestSignerForChainID(txConfig.ChainId), signingKey)
 	if err != nil {
-		return fmt.Errorf("failed to sign transaction: %v", err)
+		return nil, fmt.Errorf("failed to sign transaction: %v", err)
 	}
 
 	// Send the transaction
-	err = ethClient.SendTransaction(context.Background(), swapTxSigned)
+	broadcaster := txConfig.Broadcaster
+	if broadcaster == nil {
+		broadcaster = NewPublicBroadcaster(ethClient)
+	}
+	submission, err := broadcaster.Broadcast(ctx, swapTxSigned)
 	if err != nil {
-		return fmt.Errorf("failed to send transaction: %v", err)
+		return nil, fmt.Errorf("failed to send transaction: %v", err)
 	}
 
-	fmt.Printf("Transaction sent! (%s)\n", txConfig.Description)
+	if submission.Private {
+		fmt.Printf("Transaction sent privately! (%s)\n", txConfig.Description)
+	} else {
+		fmt.Printf("Transaction sent! (%s)\n", txConfig.Description)
+	}
 	helpers.PrintBlockExplorerTxLink(int(txConfig.ChainId.Int64()), swapTxSigned.Hash().String())
 
-	_, err = WaitForTransaction(ctx, ethClient, swapTxSigned.Hash())
+	receipt, err := WaitForSubmission(ctx, ethClient, swapTxSigned, txConfig.PublicAddress, txConfig.HeadWatcher, txConfig.WaitOptions, submission)
+
+	// Update cache to avoid RPC nonce desync, the nonce is used up by reverted and replaced transactions as well
+	if err == nil || errors.Is(err, ErrTransactionReverted) || errors.Is(err, ErrTransactionReplaced) {
+		nonceCache[nonceCacheKey] = nonce + 1
+	}
 	if err != nil {
-		return fmt.Errorf("failed to get transaction receipt: %v", err)
+		return receipt, fmt.Errorf("failed to get transaction receipt: %w", err)
 	}
 
-	// Update cache to avoid RPC nonce desync
-	nonceCache[nonceCacheKey] = nonce + 1
-
-	return nil
+	return receipt, nil
 }
 
 func GetTx(client *ethclient.Client, nonce uint64, config TxConfig) (*types.Transaction, error) {
+	gas := config.Gas
+	if gas == 0 {
+		gas = gasLimit
+	}
+	var tx *types.Transaction
+	var err error
 	chainIdInt := int(config.ChainId.Int64())
 	if chainIdInt == chains.Ethereum || chainIdInt == chains.Polygon {
-		return GetDynamicFeeTx(client, nonce, config.ChainId, config.To, config.Value, config.Data)
+		tx, err = GetDynamicFeeTx(client, nonce, config.ChainId, config.To, config.Value, config.Data, gas)
 	} else {
-		return GetLegacyTx(client, nonce, config.To, config.Value, config.Data)
+		tx, err = GetLegacyTx(client, nonce, config.To, config.Value, config.Data, gas)
+	}
+	if err != nil {
+		return nil, err
 	}
+
+	if config.MaxFee != nil {
+		maxCost := GetMaxGasCost(tx)
+		if maxCost.Cmp(config.MaxFee) > 0 {
+			return nil, fmt.Errorf("%w: gas limit %d at a fee cap of %s wei can cost %s wei, the maximum is %s wei", ErrMaxFeeExceeded, tx.Gas(), tx.GasFeeCap(), maxCost, config.MaxFee)
+		}
+	}
+	return tx, nil
 }
 
-func GetDynamicFeeTx(client *ethclient.Client, nonce uint64, chainID *big.Int, to string, value *big.Int, data []byte) (*types.Transaction, error) {
+// GetMaxGasCost returns the most a transaction can cost in gas, its gas limit at its fee cap
+func GetMaxGasCost(tx *types.Transaction) *big.Int {
+	return new(big.Int).Mul(tx.GasFeeCap(), new(big.Int).SetUint64(tx.Gas()))
+}
+
+// EstimateGasLimit returns the estimated gas of a transaction raised by 25% to absorb state changes between the estimate
+// and the signature. The fallback gas is used for transactions that cannot be estimated yet, such as a swap waiting on
+// the approval sent before it
+func EstimateGasLimit(ctx context.Context, client *ethclient.Client, config TxConfig, fallbackGas uint64) (uint64, error) {
+	to := common.HexToAddress(config.To)
+	gas, err := client.EstimateGas(ctx, ethereum.CallMsg{
+		From:  config.PublicAddress,
+		To:    &to,
+		Value: config.Value,
+		Data:  config.Data,
+	})
+	if err != nil {
+		if fallbackGas == 0 {
+			return 0, fmt.Errorf("failed to estimate gas: %v", err)
+		}
+		return fallbackGas, nil
+	}
+	return gas * 125 / 100, nil
+}
+
+// BuildTransaction returns a fully populated unsigned transaction with an estimated gas limit
+// The fallback gas is used for transactions that cannot be estimated yet, such as a swap waiting on the approval built before it
+func BuildTransaction(ctx context.Context, client *ethclient.Client, nonce uint64, config TxConfig, fallbackGas uint64) (*types.Transaction, error) {
+	gas, err := EstimateGasLimit(ctx, client, config, fallbackGas)
+	if err != nil {
+		return nil, err
+	}
+
+	config.Gas = gas
+	return GetTx(client, nonce, config)
+}
+
+func GetDynamicFeeTx(client *ethclient.Client, nonce uint64, chainID *big.Int, to string, value *big.Int, data []byte, gas uint64) (*types.Transaction, error) {
 
 	gasTipCap, err := client.SuggestGasTipCap(context.Background())
 	if err != nil {
@@ -111,14 +194,14 @@ func GetDynamicFeeTx(client *ethclient.Client, nonce uint64, chainID *big.Int, t
 		Nonce:     nonce,
 		GasFeeCap: gasFeeCap,
 		GasTipCap: gasTipCap,
-		Gas:       gasLimit,
+		Gas:       gas,
 		To:        &toAddress,
 		Value:     value,
 		Data:      data,
 	}), nil
 }
 
-func GetLegacyTx(client *ethclient.Client, nonce uint64, to string, value *big.Int, data []byte) (*types.Transaction, error) {
+func GetLegacyTx(client *ethclient.Client, nonce uint64, to string, value *big.Int, data []byte, gas uint64) (*types.Transaction, error) {
 
 	gasPrice, err := client.SuggestGasPrice(context.Background())
 	if err != nil {
@@ -134,7 +217,7 @@ func GetLegacyTx(client *ethclient.Client, nonce uint64, to string, value *big.I
 	return types.NewTx(&types.LegacyTx{
 		Nonce:    nonce,
 		GasPrice: gasPrice,
-		Gas:      gasLimit,
+		Gas:      gas,
 		To:       &toAddress,
 		Value:    value,
 		Data:     data,
@@ -324,6 +407,37 @@ func ReadContractAllowance(client *ethclient.Client, erc20Address common.Address
 	return allowance, nil
 }
 
+// ReadContractBalance reads the token balance of a wallet.
+func ReadContractBalance(client *ethclient.Client, erc20Address common.Address, publicAddress common.Address) (*big.Int, error) {
+	parsedABI, err := abi.JSON(strings.NewReader(abis.Erc20))
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := parsedABI.Pack("balanceOf", publicAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := ethereum.CallMsg{
+		To:   &erc20Address,
+		Data: data,
+	}
+
+	result, err := client.CallContract(context.Background(), msg, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var balance *big.Int
+	err = parsedABI.UnpackIntoInterface(&balance, "balanceOf", result)
+	if err != nil {
+		return nil, err
+	}
+
+	return balance, nil
+}
+
 func GetTypeHash(client *ethclient.Client, addressAsString string) (string, error) { // Pack the call to get the PERMIT_TYPEHASH constant
 
 	// Parse the ABI
@@ -366,17 +480,113 @@ func GetTypeHash(client *ethclient.Client, addressAsString string) (string, erro
 	return resultAsString, nil
 }
 
-func ApproveTokenForRouter(ctx context.Context, client *ethclient.Client, nonceCache map[string]uint64, config Erc20ApprovalConfig) error {
-	// Parse the USDC contract ABI to get the 'Approve' function signature
+// ReadDomainSeparator reads the 'DOMAIN_SEPARATOR' public variable from a contract.
+func ReadDomainSeparator(client *ethclient.Client, contractAddress common.Address) (common.Hash, error) {
+	parsedABI, err := abi.JSON(strings.NewReader(abis.Erc20))
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	msg := ethereum.CallMsg{
+		To:   &contractAddress,
+		Data: parsedABI.Methods["DOMAIN_SEPARATOR"].ID,
+	}
+
+	// Query the blockchain
+	result, err := client.CallContract(context.Background(), msg, nil)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	// Unpack the result
+	var domainSeparator [32]byte
+	err = parsedABI.UnpackIntoInterface(&domainSeparator, "DOMAIN_SEPARATOR", result)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if domainSeparator == [32]byte{} {
+		return common.Hash{}, errors.New("DOMAIN_SEPARATOR does not exist")
+	}
+
+	return domainSeparator, nil
+}
+
+// ReadEip712Domain reads the EIP-712 domain of a contract through the EIP-5267 'eip712Domain' function.
+func ReadEip712Domain(client *ethclient.Client, contractAddress common.Address) (*PermitDomain, error) {
+	parsedABI, err := abi.JSON(strings.NewReader(abis.Erc20))
+	if err != nil {
+		return nil, err
+	}
+
+	msg := ethereum.CallMsg{
+		To:   &contractAddress,
+		Data: parsedABI.Methods["eip712Domain"].ID,
+	}
+
+	// Query the blockchain
+	result, err := client.CallContract(context.Background(), msg, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unpack the result
+	var eip712Domain struct {
+		Fields            [1]byte
+		Name              string
+		Version           string
+		ChainId           *big.Int
+		VerifyingContract common.Address
+		Salt              [32]byte
+		Extensions        []*big.Int
+	}
+	err = parsedABI.UnpackIntoInterface(&eip712Domain, "eip712Domain", result)
+	if err != nil {
+		return nil, err
+	}
+
+	if eip712Domain.Fields[0] == 0 {
+		return nil, errors.New("eip712Domain returned no domain fields")
+	}
+	// Extensions change how the domain is hashed and none are standardized yet
+	if len(eip712Domain.Extensions) > 0 {
+		return nil, errors.New("eip712Domain extensions are not supported")
+	}
+
+	return &PermitDomain{
+		Fields:            eip712Domain.Fields[0],
+		Name:              eip712Domain.Name,
+		Version:           eip712Domain.Version,
+		ChainId:           eip712Domain.ChainId,
+		VerifyingContract: eip712Domain.VerifyingContract,
+		Salt:              eip712Domain.Salt,
+	}, nil
+}
+
+// GetApproveCalldata returns the calldata of an ERC20 approval
+func GetApproveCalldata(spenderAddress common.Address, amount *big.Int) ([]byte, error) {
 	parsedABI, err := abi.JSON(strings.NewReader(abis.Erc20))
 	if err != nil {
-		return fmt.Errorf("failed to parse USDC ABI: %v", err)
+		return nil, fmt.Errorf("failed to parse ABI: %v", err)
 	}
 
 	// Pack the transaction data with the method signature and parameters
-	data, err := parsedABI.Pack("approve", config.SpenderAddress, amounts.BigMaxUint256)
+	data, err := parsedABI.Pack("approve", spenderAddress, amount)
 	if err != nil {
-		return fmt.Errorf("failed to pack data for approve: %v", err)
+		return nil, fmt.Errorf("failed to pack data for approve: %v", err)
+	}
+	return data, nil
+}
+
+// ApproveTokenForRouter sends an ERC20 approval and returns its receipt
+func ApproveTokenForRouter(ctx context.Context, client *ethclient.Client, nonceCache map[string]uint64, config Erc20ApprovalConfig) (*types.Receipt, error) {
+	amount := config.Amount
+	if amount == nil {
+		amount = amounts.BigMaxUint256
+	}
+
+	data, err := GetApproveCalldata(config.SpenderAddress, amount)
+	if err != nil {
+		return nil, err
 	}
 
 	txConfig := TxConfig{
@@ -387,18 +597,20 @@ func ApproveTokenForRouter(ctx context.Context, client *ethclient.Client, nonceC
 		Value:         big.NewInt(0),
 		To:            config.Erc20Address.Hex(),
 		Data:          data,
+		MaxFee:        config.MaxFee,
+		HeadWatcher:   config.HeadWatcher,
+		Broadcaster:   config.Broadcaster,
+		WaitOptions:   config.WaitOptions,
 	}
-	err = ExecuteTransaction(ctx, txConfig, client, nonceCache)
+	receipt, err := ExecuteTransaction(ctx, txConfig, client, nonceCache)
 	if err != nil {
-		return fmt.Errorf("failed to execute transaction: %v", err)
+		return receipt, fmt.Errorf("failed to execute transaction: %w", err)
 	}
-	return nil
+	return receipt, nil
 }
 
+// GetTimestampBelowCalldata returns the calldata of a predicate that holds until the expiration, a unix timestamp in seconds
 func GetTimestampBelowCalldata(expiration int64) ([]byte, error) {
-
-	expiration = time.Now().UnixMilli()
-
 	parsedABI, err := abi.JSON(strings.NewReader(abis.SeriesNonceManager))
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse ABI: %v", err)
@@ -521,29 +733,93 @@ func RevokeApprovalForRouter(ctx context.Context, client *ethclient.Client, nonc
 		Value:         big.NewInt(0),
 		To:            config.Erc20Address.Hex(),
 		Data:          data,
+		HeadWatcher:   config.HeadWatcher,
+		Broadcaster:   config.Broadcaster,
+		WaitOptions:   config.WaitOptions,
 	}
-	err = ExecuteTransaction(ctx, txConfig, client, nonceCache)
+	_, err = ExecuteTransaction(ctx, txConfig, client, nonceCache)
 	if err != nil {
-		return fmt.Errorf("failed to execute transaction: %v", err)
+		return fmt.Errorf("failed to execute transaction: %w", err)
 	}
 	return nil
 }
 
-func WaitForTransaction(ctx context.Context, client *ethclient.Client, txHash common.Hash) (*types.Receipt, error) {
-	for {
-		receipt, err := client.TransactionReceipt(context.Background(), txHash)
-		if receipt != nil {
-			fmt.Println("Transaction complete!")
-			return receipt, nil
-		}
-		if err != nil {
-			fmt.Println("Waiting for transaction to be mined")
-		}
-		select {
-		case <-time.After(1000 * time.Millisecond): // check again after a delay
-		case <-ctx.Done():
-			fmt.Println("Context cancelled")
-			return nil, context.Background().Err()
-		}
+// GetWrapNativeCalldata returns the calldata of a deposit on a wrapped native token contract, the amount is sent as the transaction value
+func GetWrapNativeCalldata() ([]byte, error) {
+	parsedABI, err := abi.JSON(strings.NewReader(abis.Weth))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ABI: %v", err)
 	}
+
+	data, err := parsedABI.Pack("deposit")
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack data for deposit: %v", err)
+	}
+	return data, nil
+}
+
+// GetUnwrapNativeCalldata returns the calldata of a withdrawal from a wrapped native token contract
+func GetUnwrapNativeCalldata(amount *big.Int) ([]byte, error) {
+	parsedABI, err := abi.JSON(strings.NewReader(abis.Weth))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ABI: %v", err)
+	}
+
+	data, err := parsedABI.Pack("withdraw", amount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack data for withdraw: %v", err)
+	}
+	return data, nil
+}
+
+// WrapNativeToken deposits native tokens into the wrapped native token contract of the chain
+func WrapNativeToken(ctx context.Context, client *ethclient.Client, nonceCache map[string]uint64, config WrapNativeConfig) error {
+	data, err := GetWrapNativeCalldata()
+	if err != nil {
+		return err
+	}
+
+	txConfig := TxConfig{
+		Description:   "Wrap",
+		PublicAddress: config.PublicAddress,
+		PrivateKey:    config.Key,
+		ChainId:       big.NewInt(int64(config.ChainId)),
+		Value:         config.Amount,
+		To:            config.WrappedNativeAddress.Hex(),
+		Data:          data,
+		HeadWatcher:   config.HeadWatcher,
+		Broadcaster:   config.Broadcaster,
+		WaitOptions:   config.WaitOptions,
+	}
+	_, err = ExecuteTransaction(ctx, txConfig, client, nonceCache)
+	if err != nil {
+		return fmt.Errorf("failed to execute transaction: %w", err)
+	}
+	return nil
+}
+
+// UnwrapNativeToken withdraws native tokens from the wrapped native token contract of the chain
+func UnwrapNativeToken(ctx context.Context, client *ethclient.Client, nonceCache map[string]uint64, config WrapNativeConfig) error {
+	data, err := GetUnwrapNativeCalldata(config.Amount)
+	if err != nil {
+		return err
+	}
+
+	txConfig := TxConfig{
+		Description:   "Unwrap",
+		PublicAddress: config.PublicAddress,
+		PrivateKey:    config.Key,
+		ChainId:       big.NewInt(int64(config.ChainId)),
+		Value:         big.NewInt(0),
+		To:            config.WrappedNativeAddress.Hex(),
+		Data:          data,
+		HeadWatcher:   config.HeadWatcher,
+		Broadcaster:   config.Broadcaster,
+		WaitOptions:   config.WaitOptions,
+	}
+	_, err = ExecuteTransaction(ctx, txConfig, client, nonceCache)
+	if err != nil {
+		return fmt.Errorf("failed to execute transaction: %w", err)
+	}
+	return nil
 }
@@ -1,13 +1,23 @@
 package onchain
 
 import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
 	"math/big"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/stretchr/testify/require"
 
+	"github.com/1inch/1inch-sdk-go/helpers/consts/abis"
 	"github.com/1inch/1inch-sdk-go/helpers/consts/amounts"
+	"github.com/1inch/1inch-sdk-go/helpers/consts/chains"
+	"github.com/1inch/1inch-sdk-go/helpers/consts/tokens"
 )
 
 func TestCreatePermitParams(t *testing.T) {
@@ -18,6 +28,8 @@ func TestCreatePermitParams(t *testing.T) {
 		Value                *big.Int
 		Deadline             int64
 		Signature            string
+		Type                 PermitType
+		Nonce                int64
 		expectedPermitString string
 	}{
 		{
@@ -29,6 +41,16 @@ func TestCreatePermitParams(t *testing.T) {
 			Signature:            "c8dcab9ab2ce2055e61c0718117f8d77a56cd0a8b8370d8f5e16932a60d21a3e0eb0214dcbe4e7c5131cc45fd552e12f5bcef3b9c7fcb47ace9d4f694a496d471b",
 			expectedPermitString: "0x00000000000000000000000050c5df26654b5efbdd0c54a062dfa6012933defe0000000000000000000000001111111254eeb25477b68fb85ed929f73a960582ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff000000000000000000000000000000000000000000000000000000006594cdd3000000000000000000000000000000000000000000000000000000000000001bc8dcab9ab2ce2055e61c0718117f8d77a56cd0a8b8370d8f5e16932a60d21a3e0eb0214dcbe4e7c5131cc45fd552e12f5bcef3b9c7fcb47ace9d4f694a496d47",
 		},
+		{
+			description:          "Create DAI-style Permit parameter",
+			Owner:                "0x50c5df26654b5efbdd0c54a062dfa6012933defe",
+			Spender:              "0x1111111254eeb25477b68fb85ed929f73a960582",
+			Deadline:             1704250835,
+			Signature:            "c8dcab9ab2ce2055e61c0718117f8d77a56cd0a8b8370d8f5e16932a60d21a3e0eb0214dcbe4e7c5131cc45fd552e12f5bcef3b9c7fcb47ace9d4f694a496d471b",
+			Type:                 PermitTypeDai,
+			Nonce:                5,
+			expectedPermitString: "0x00000000000000000000000050c5df26654b5efbdd0c54a062dfa6012933defe0000000000000000000000001111111254eeb25477b68fb85ed929f73a9605820000000000000000000000000000000000000000000000000000000000000005000000000000000000000000000000000000000000000000000000006594cdd30000000000000000000000000000000000000000000000000000000000000001000000000000000000000000000000000000000000000000000000000000001bc8dcab9ab2ce2055e61c0718117f8d77a56cd0a8b8370d8f5e16932a60d21a3e0eb0214dcbe4e7c5131cc45fd552e12f5bcef3b9c7fcb47ace9d4f694a496d47",
+		},
 	}
 
 	for _, tc := range testcases {
@@ -40,6 +62,8 @@ func TestCreatePermitParams(t *testing.T) {
 				Value:     tc.Value,
 				Deadline:  tc.Deadline,
 				Signature: tc.Signature,
+				Type:      tc.Type,
+				Nonce:     tc.Nonce,
 			}
 
 			result := CreatePermitParams(config)
@@ -114,3 +138,154 @@ func TestRemove0xPrefix(t *testing.T) {
 		})
 	}
 }
+
+func TestWrapNativeCalldata(t *testing.T) {
+	depositData, err := GetWrapNativeCalldata()
+	require.NoError(t, err)
+	require.Equal(t, "d0e30db0", hex.EncodeToString(depositData))
+
+	withdrawData, err := GetUnwrapNativeCalldata(big.NewInt(1000))
+	require.NoError(t, err)
+	require.Equal(t, "2e1a7d4d", hex.EncodeToString(withdrawData[:4]))
+	require.Equal(t, big.NewInt(1000), new(big.Int).SetBytes(withdrawData[4:]))
+}
+
+func TestGetTimestampBelowCalldata(t *testing.T) {
+	parsedABI, err := abi.JSON(strings.NewReader(abis.SeriesNonceManager))
+	require.NoError(t, err)
+
+	expiration := time.Now().Add(time.Hour).Unix()
+	data, err := GetTimestampBelowCalldata(expiration)
+	require.NoError(t, err)
+
+	method, err := parsedABI.MethodById(data[:4])
+	require.NoError(t, err)
+	require.Equal(t, "timestampBelow", method.Name)
+	args, err := method.Inputs.Unpack(data[4:])
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(expiration), args[0])
+}
+
+func TestGetTimestampBelowAndNonceEqualsCalldata(t *testing.T) {
+	seriesNonceManagerABI, err := abi.JSON(strings.NewReader(abis.SeriesNonceManager))
+	require.NoError(t, err)
+	routerABI, err := abi.JSON(strings.NewReader(abis.AggregationRouterV5))
+	require.NoError(t, err)
+
+	maker := "0x2a250893f86Dc8497E131508f680338ac647B498"
+	seriesNonceManager := "0x303389f541ff2d620e42832f180a08e767b28e10"
+	expiration := time.Now().Add(24 * time.Hour).Unix()
+
+	data, err := GetTimestampBelowAndNonceEqualsCalldata(expiration, big.NewInt(5), maker)
+	require.NoError(t, err)
+	predicate, err := GetPredicateCalldata(seriesNonceManager, data)
+	require.NoError(t, err)
+
+	// The predicate is a static call from the router to the series nonce manager
+	method, err := routerABI.MethodById(predicate[:4])
+	require.NoError(t, err)
+	require.Equal(t, "arbitraryStaticCall", method.Name)
+	args, err := method.Inputs.Unpack(predicate[4:])
+	require.NoError(t, err)
+	require.Equal(t, common.HexToAddress(seriesNonceManager), args[0])
+	require.Equal(t, data, args[1])
+
+	method, err = seriesNonceManagerABI.MethodById(data[:4])
+	require.NoError(t, err)
+	require.Equal(t, "timestampBelowAndNonceEquals", method.Name)
+	args, err = method.Inputs.Unpack(data[4:])
+	require.NoError(t, err)
+
+	// timeNonceSeriesAccount packs the expiration, the nonce, the series and the maker into a single word
+	packed := args[0].(*big.Int)
+	mask := func(bits uint) *big.Int {
+		return new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), bits), big.NewInt(1))
+	}
+	require.Equal(t, expiration, new(big.Int).Rsh(packed, 216).Int64())
+	require.Equal(t, int64(5), new(big.Int).And(new(big.Int).Rsh(packed, 176), mask(40)).Int64())
+	require.Equal(t, int64(0), new(big.Int).And(new(big.Int).Rsh(packed, 160), mask(16)).Int64())
+	require.Equal(t, common.HexToAddress(maker), common.BigToAddress(new(big.Int).And(packed, mask(160))))
+}
+
+func TestReadContractBalance(t *testing.T) {
+	client := setupRpc(t, map[string]rpcHandler{
+		"eth_call": erc20CallHandler(t, map[string][]byte{
+			"balanceOf": common.LeftPadBytes(big.NewInt(42).Bytes(), 32),
+		}),
+	})
+
+	balance, err := ReadContractBalance(client, common.HexToAddress(tokens.EthereumWeth), common.HexToAddress("0x50c5df26654b5efbdd0c54a062dfa6012933defe"))
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(42), balance)
+}
+
+func TestBuildTransactionMaxFee(t *testing.T) {
+	testcases := []struct {
+		description   string
+		estimateFails bool
+		maxFee        *big.Int
+		expectedGas   uint64
+		expectedError error
+	}{
+		{
+			description: "Estimate raised by 25%",
+			expectedGas: 125000,
+		},
+		{
+			description: "Within the maximum fee",
+			// 125000 gas at a fee cap of 45 gwei
+			maxFee:      big.NewInt(5625e12),
+			expectedGas: 125000,
+		},
+		{
+			description:   "Fallback gas",
+			estimateFails: true,
+			expectedGas:   300000,
+		},
+		{
+			description:   "Error - above the maximum fee",
+			maxFee:        big.NewInt(5624e12),
+			expectedError: ErrMaxFeeExceeded,
+		},
+		{
+			description:   "Error - fallback gas above the maximum fee",
+			estimateFails: true,
+			maxFee:        big.NewInt(5625e12),
+			expectedError: ErrMaxFeeExceeded,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.description, func(t *testing.T) {
+			client := setupRpc(t, map[string]rpcHandler{
+				"eth_estimateGas": func(params []json.RawMessage) (interface{}, error) {
+					if tc.estimateFails {
+						return nil, errExecutionReverted
+					}
+					return hexutil.Uint64(100000), nil
+				},
+				"eth_gasPrice": func(params []json.RawMessage) (interface{}, error) {
+					return (*hexutil.Big)(big.NewInt(30e9)), nil
+				},
+				"eth_maxPriorityFeePerGas": func(params []json.RawMessage) (interface{}, error) {
+					return (*hexutil.Big)(big.NewInt(1e9)), nil
+				},
+			})
+
+			tx, err := BuildTransaction(context.Background(), client, 3, TxConfig{
+				PublicAddress: common.HexToAddress("0x50c5df26654b5efbdd0c54a062dfa6012933defe"),
+				ChainId:       big.NewInt(chains.Polygon),
+				Value:         big.NewInt(0),
+				To:            tokens.PolygonDai,
+				MaxFee:        tc.maxFee,
+			}, 300000)
+			if tc.expectedError != nil {
+				require.ErrorIs(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedGas, tx.Gas())
+			require.Equal(t, big.NewInt(45e9), tx.GasFeeCap())
+		})
+	}
+}
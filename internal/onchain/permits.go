@@ -1,12 +1,13 @@
 package onchain
 
 import (
+	"errors"
 	"fmt"
 	"math/big"
+	"slices"
 	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/signer/core/apitypes"
@@ -23,39 +24,54 @@ type CreatePermitConfig struct {
 	ChainId       int
 	PrivateKey    string
 	Deadline      int64
+	Value         *big.Int // Optional, defaults to an unlimited allowance
 }
 
+// ErrDaiPermitIsUnlimited is returned when a limited allowance is requested from a DAI-style permit, which can only grant an unlimited one
+var ErrDaiPermitIsUnlimited = errors.New("DAI-style permits can only grant an unlimited allowance")
+
 func CreatePermit(config *CreatePermitConfig) (string, error) {
 
-	// TODO due to a bug in the Limit Order API, we must check the version of the contract before attempting permit generation
-	// If the version of the contract is not 1, we exit early and default to an approval
-	version, err := ReadContractVersion(config.EthClient, common.HexToAddress(config.MakerAsset))
+	tokenAddress := common.HexToAddress(config.MakerAsset)
+
+	// A token that does not support a known permit would reject the signature, so its error is returned for the caller to approve instead
+	permitType, err := readPermitType(config.EthClient, tokenAddress)
 	if err != nil {
-		return "0x", fmt.Errorf("failed to read contract version: %v", err)
-	}
-	if version != "1" {
-		return "0x", fmt.Errorf("contract version is not 1")
+		return "0x", err
 	}
 
-	name, err := ReadContractName(config.EthClient, common.HexToAddress(config.MakerAsset))
+	domain, err := ReadPermitDomain(config.EthClient, config.ChainId, tokenAddress)
 	if err != nil {
-		return "0x", fmt.Errorf("failed to read contract name: %v", err)
+		// Fall back to the standard domain built from the token name and version
+		domain, err = getDefaultPermitDomain(config.EthClient, config.ChainId, tokenAddress)
+		if err != nil {
+			return "0x", err
+		}
+	}
+
+	value := config.Value
+	if value == nil {
+		value = amounts.BigMaxUint256
+	}
+	if permitType == PermitTypeDai && value.Cmp(amounts.BigMaxUint256) != 0 {
+		return "0x", ErrDaiPermitIsUnlimited
 	}
 
-	nonce, err := ReadContractNonce(config.EthClient, config.PublicAddress, common.HexToAddress(config.MakerAsset))
+	nonce, err := ReadContractNonce(config.EthClient, config.PublicAddress, tokenAddress)
 	if err != nil {
 		return "0x", fmt.Errorf("failed to read contract nonce: %v", err)
 	}
 
 	sig, err := CreatePermitSignature(&PermitSignatureConfig{
 		FromToken:     config.MakerAsset,
-		Name:          name,
-		Version:       version,
 		PublicAddress: config.PublicAddress.Hex(),
 		ChainId:       config.ChainId,
 		Key:           config.PrivateKey,
 		Nonce:         nonce,
 		Deadline:      config.Deadline,
+		Value:         value,
+		Type:          permitType,
+		Domain:        domain,
 	})
 	if err != nil {
 		return "0x", fmt.Errorf("failed to create permit signature: %v", err)
@@ -69,20 +85,25 @@ func CreatePermit(config *CreatePermitConfig) (string, error) {
 	return CreatePermitParams(&PermitParamsConfig{
 		Owner:     config.PublicAddress.Hex(),
 		Spender:   aggregationRouter,
-		Value:     amounts.BigMaxUint256,
+		Value:     value,
 		Deadline:  config.Deadline,
 		Signature: sig,
+		Type:      permitType,
+		Nonce:     nonce,
 	}), nil
 }
 
 func CreatePermitSignature(config *PermitSignatureConfig) (string, error) {
 
-	// Domain Data
-	domainData := apitypes.TypedDataDomain{
-		Name:              config.Name,
-		Version:           config.Version,
-		ChainId:           math.NewHexOrDecimal256(int64(config.ChainId)),
-		VerifyingContract: config.FromToken,
+	domain := config.Domain
+	if domain == nil {
+		domain = &PermitDomain{
+			Fields:            DomainFieldName | DomainFieldVersion | DomainFieldChainId | DomainFieldVerifyingContract,
+			Name:              config.Name,
+			Version:           config.Version,
+			ChainId:           big.NewInt(int64(config.ChainId)),
+			VerifyingContract: common.HexToAddress(config.FromToken),
+		}
 	}
 
 	aggregationRouter, err := contracts.Get1inchRouterFromChainId(config.ChainId)
@@ -90,35 +111,61 @@ func CreatePermitSignature(config *PermitSignatureConfig) (string, error) {
 		return "", fmt.Errorf("failed to get 1inch router address: %v", err)
 	}
 
-	// Order Message
-	orderMessage := apitypes.TypedDataMessage{
-		"owner":    config.PublicAddress,
-		"spender":  aggregationRouter,
-		"value":    amounts.BigMaxUint256,
-		"nonce":    big.NewInt(config.Nonce),
-		"deadline": big.NewInt(config.Deadline),
+	value := config.Value
+	if value == nil {
+		value = amounts.BigMaxUint256
 	}
 
-	// Typed Data
-	typedData := apitypes.TypedData{
-		Types: map[string][]apitypes.Type{
-			"EIP712Domain": {
-				{Name: "name", Type: "string"},
-				{Name: "version", Type: "string"},
-				{Name: "chainId", Type: "uint256"},
-				{Name: "verifyingContract", Type: "address"},
+	// Permit Message
+	var typedData apitypes.TypedData
+	switch config.Type {
+	case PermitTypeEip2612:
+		typedData = apitypes.TypedData{
+			Types: map[string][]apitypes.Type{
+				"Permit": {
+					{Name: "owner", Type: "address"},
+					{Name: "spender", Type: "address"},
+					{Name: "value", Type: "uint256"},
+					{Name: "nonce", Type: "uint256"},
+					{Name: "deadline", Type: "uint256"},
+				},
+			},
+			PrimaryType: "Permit",
+			Message: apitypes.TypedDataMessage{
+				"owner":    config.PublicAddress,
+				"spender":  aggregationRouter,
+				"value":    value,
+				"nonce":    big.NewInt(config.Nonce),
+				"deadline": big.NewInt(config.Deadline),
+			},
+		}
+	case PermitTypeDai:
+		typedData = apitypes.TypedData{
+			Types: map[string][]apitypes.Type{
+				"Permit": {
+					{Name: "holder", Type: "address"},
+					{Name: "spender", Type: "address"},
+					{Name: "nonce", Type: "uint256"},
+					{Name: "expiry", Type: "uint256"},
+					{Name: "allowed", Type: "bool"},
+				},
 			},
-			"Permit": {
-				{Name: "owner", Type: "address"},
-				{Name: "spender", Type: "address"},
-				{Name: "value", Type: "uint256"},
-				{Name: "nonce", Type: "uint256"},
-				{Name: "deadline", Type: "uint256"},
+			PrimaryType: "Permit",
+			Message: apitypes.TypedDataMessage{
+				"holder":  config.PublicAddress,
+				"spender": aggregationRouter,
+				"nonce":   big.NewInt(config.Nonce),
+				"expiry":  big.NewInt(config.Deadline),
+				"allowed": true,
 			},
-		},
-		PrimaryType: "Permit",
-		Domain:      domainData,
-		Message:     orderMessage,
+		}
+	default:
+		return "", fmt.Errorf("unsupported permit type: %d", config.Type)
+	}
+
+	// The domain is hashed by HashPermitDomain so that any combination of fields is supported, it is only set here to make the typed data valid
+	typedData.Domain = apitypes.TypedDataDomain{
+		VerifyingContract: domain.VerifyingContract.Hex(),
 	}
 
 	// Hash the data
@@ -126,29 +173,8 @@ func CreatePermitSignature(config *PermitSignatureConfig) (string, error) {
 	if err != nil {
 		return "", fmt.Errorf("error hashing typed data: %v", err)
 	}
-	domainSeparator, err := typedData.HashStruct("EIP712Domain", typedData.Domain.Map())
-	if err != nil {
-		return "", fmt.Errorf("error hashing domain separator: %v", err)
-	}
-
-	// Prepare the data for signing
-	rawData := []byte(fmt.Sprintf("\x19\x01%s%s", string(domainSeparator), string(typedDataHash)))
-	challengeHash := crypto.Keccak256Hash(rawData)
 
-	// Convert private key and sign
-	privateKey, err := crypto.HexToECDSA(config.Key)
-	if err != nil {
-		return "", fmt.Errorf("error converting private key to ECDSA: %v", err)
-	}
-	signature, err := crypto.Sign(challengeHash.Bytes(), privateKey)
-	if err != nil {
-		return "", fmt.Errorf("error signing challenge hash: %v", err)
-	}
-	signature[64] += 27 // Adjust the `v` value
-
-	// Convert signature to hex string
-	signatureHex := fmt.Sprintf("0x%x", signature)
-	return signatureHex, nil
+	return signDigest(HashPermitDomain(domain).Bytes(), typedDataHash, config.Key)
 }
 
 func CreatePermitParams(config *PermitParamsConfig) string {
@@ -166,6 +192,17 @@ func CreatePermitParams(config *PermitParamsConfig) string {
 	spenderNoPrefix := Remove0xPrefix(config.Spender)
 	signatureNoPrefix := Remove0xPrefix(config.Signature)
 
+	if config.Type == PermitTypeDai {
+		// DAI-style permits replace the value with a nonce and add an allowed flag
+		// holder, spender, nonce, expiry, allowed, v, r, s
+		return "0x" + padStringWithZeroes(ownerNoPrefix) +
+			padStringWithZeroes(spenderNoPrefix) +
+			padStringWithZeroes(fmt.Sprintf("%x", config.Nonce)) +
+			padStringWithZeroes(fmt.Sprintf("%x", config.Deadline)) +
+			padStringWithZeroes("1") +
+			ConvertSignatureToVRSString(signatureNoPrefix)
+	}
+
 	return "0x" + padStringWithZeroes(ownerNoPrefix) +
 		padStringWithZeroes(spenderNoPrefix) +
 		padStringWithZeroes(fmt.Sprintf("%x", config.Value)) +
@@ -174,14 +211,173 @@ func CreatePermitParams(config *PermitParamsConfig) string {
 }
 
 func ShouldUsePermit(ethClient *ethclient.Client, chainId int, srcToken string) bool {
-	typehash, err := GetTypeHash(ethClient, srcToken) // TODO this typehash lookup can miss many permit1-enabled tokens
-	if err == nil {
-		// If a typehash for Permit1 is present, use that instead of Approve
-		if typehash == typehashes.Permit1 {
-			return true
+	_, err := GetPermitInfo(ethClient, chainId, common.HexToAddress(srcToken))
+	return err == nil
+}
+
+// GetPermitInfo detects whether a token supports EIP-2612 or DAI-style permits and resolves the EIP-712 domain it expects
+func GetPermitInfo(client *ethclient.Client, chainId int, tokenAddress common.Address) (*PermitInfo, error) {
+	permitType, err := readPermitType(client, tokenAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	domain, err := ReadPermitDomain(client, chainId, tokenAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PermitInfo{
+		Type:   permitType,
+		Domain: *domain,
+	}, nil
+}
+
+// ReadPermitDomain resolves the EIP-712 domain of a token
+// eip712Domain (EIP-5267) is used when available, otherwise the DOMAIN_SEPARATOR is matched against domains built from the token name and version
+func ReadPermitDomain(client *ethclient.Client, chainId int, tokenAddress common.Address) (*PermitDomain, error) {
+	domainSeparator, domainSeparatorErr := ReadDomainSeparator(client, tokenAddress)
+
+	domain, err := ReadEip712Domain(client, tokenAddress)
+	if err == nil && (domainSeparatorErr != nil || HashPermitDomain(domain) == domainSeparator) {
+		return domain, nil
+	}
+
+	if domainSeparatorErr != nil {
+		return nil, fmt.Errorf("failed to read DOMAIN_SEPARATOR: %v", domainSeparatorErr)
+	}
+
+	name, err := ReadContractName(client, tokenAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read contract name: %v", err)
+	}
+
+	version, err := ReadContractVersion(client, tokenAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read contract version: %v", err)
+	}
+
+	return FindPermitDomain(domainSeparator, chainId, tokenAddress, name, version)
+}
+
+// FindPermitDomain returns the domain whose hash matches the domain separator of a token
+// Candidates cover the token version as well as versions "1" and "2", domains without a version, and domains that carry the chain id as a salt
+func FindPermitDomain(domainSeparator common.Hash, chainId int, tokenAddress common.Address, name string, version string) (*PermitDomain, error) {
+	chainIdBig := big.NewInt(int64(chainId))
+
+	var versions []string
+	for _, candidate := range []string{version, "1", "2"} {
+		if candidate != "" && !slices.Contains(versions, candidate) {
+			versions = append(versions, candidate)
+		}
+	}
+
+	versionFields := []byte{0, DomainFieldVersion}
+	chainFields := []byte{DomainFieldChainId, DomainFieldSalt, 0}
+
+	for _, versionField := range versionFields {
+		for _, chainField := range chainFields {
+			candidateVersions := versions
+			if versionField == 0 {
+				candidateVersions = []string{""}
+			}
+			for _, candidateVersion := range candidateVersions {
+				domain := &PermitDomain{
+					Fields:            DomainFieldName | DomainFieldVerifyingContract | versionField | chainField,
+					Name:              name,
+					Version:           candidateVersion,
+					VerifyingContract: tokenAddress,
+				}
+				switch chainField {
+				case DomainFieldChainId:
+					domain.ChainId = chainIdBig
+				case DomainFieldSalt:
+					domain.Salt = common.BigToHash(chainIdBig)
+				}
+				if HashPermitDomain(domain) == domainSeparator {
+					return domain, nil
+				}
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("DOMAIN_SEPARATOR %s does not match any known domain for token %s", domainSeparator.Hex(), tokenAddress.Hex())
+}
+
+// HashPermitDomain computes the EIP-712 domain separator of a domain using only the fields it declares
+func HashPermitDomain(domain *PermitDomain) common.Hash {
+	var fieldTypes []string
+	var encoded [][]byte
+
+	if domain.Fields&DomainFieldName != 0 {
+		fieldTypes = append(fieldTypes, "string name")
+		encoded = append(encoded, crypto.Keccak256([]byte(domain.Name)))
+	}
+	if domain.Fields&DomainFieldVersion != 0 {
+		fieldTypes = append(fieldTypes, "string version")
+		encoded = append(encoded, crypto.Keccak256([]byte(domain.Version)))
+	}
+	if domain.Fields&DomainFieldChainId != 0 {
+		chainId := domain.ChainId
+		if chainId == nil {
+			chainId = big.NewInt(0)
 		}
+		fieldTypes = append(fieldTypes, "uint256 chainId")
+		encoded = append(encoded, common.BigToHash(chainId).Bytes())
 	}
-	return false
+	if domain.Fields&DomainFieldVerifyingContract != 0 {
+		fieldTypes = append(fieldTypes, "address verifyingContract")
+		encoded = append(encoded, common.LeftPadBytes(domain.VerifyingContract.Bytes(), 32))
+	}
+	if domain.Fields&DomainFieldSalt != 0 {
+		fieldTypes = append(fieldTypes, "bytes32 salt")
+		encoded = append(encoded, domain.Salt.Bytes())
+	}
+
+	typeHash := crypto.Keccak256([]byte(fmt.Sprintf("EIP712Domain(%s)", strings.Join(fieldTypes, ","))))
+	return crypto.Keccak256Hash(append([][]byte{typeHash}, encoded...)...)
+}
+
+// readPermitType determines the permit flavor of a token from its PERMIT_TYPEHASH
+// Tokens that do not expose the typehash are assumed to be EIP-2612 as long as they track permit nonces
+func readPermitType(client *ethclient.Client, tokenAddress common.Address) (PermitType, error) {
+	typehash, err := GetTypeHash(client, tokenAddress.Hex())
+	if err != nil {
+		_, err = ReadContractNonce(client, common.Address{}, tokenAddress)
+		if err != nil {
+			return 0, fmt.Errorf("token does not support permits: %v", err)
+		}
+		return PermitTypeEip2612, nil
+	}
+
+	switch typehash {
+	case typehashes.Permit1:
+		return PermitTypeEip2612, nil
+	case typehashes.DaiPermit:
+		return PermitTypeDai, nil
+	default:
+		return 0, fmt.Errorf("unsupported PERMIT_TYPEHASH: %s", typehash)
+	}
+}
+
+func getDefaultPermitDomain(client *ethclient.Client, chainId int, tokenAddress common.Address) (*PermitDomain, error) {
+	name, err := ReadContractName(client, tokenAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read contract name: %v", err)
+	}
+
+	version, err := ReadContractVersion(client, tokenAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read contract version: %v", err)
+	}
+
+	return &PermitDomain{
+		Fields:            DomainFieldName | DomainFieldVersion | DomainFieldChainId | DomainFieldVerifyingContract,
+		Name:              name,
+		Version:           version,
+		ChainId:           big.NewInt(int64(chainId)),
+		VerifyingContract: tokenAddress,
+	}, nil
 }
 
 func padStringWithZeroes(s string) string {
@@ -206,3 +402,23 @@ func ConvertSignatureToVRSString(signature string) string {
 	//v := signature[128:]
 	return padStringWithZeroes(signature[128:]) + signature[:128]
 }
+
+// signDigest signs the EIP-712 digest of a struct hash under a domain separator
+func signDigest(domainSeparator []byte, typedDataHash []byte, key string) (string, error) {
+	// Prepare the data for signing
+	rawData := []byte(fmt.Sprintf("\x19\x01%s%s", string(domainSeparator), string(typedDataHash)))
+	challengeHash := crypto.Keccak256Hash(rawData)
+
+	// Convert private key and sign
+	privateKey, err := crypto.HexToECDSA(key)
+	if err != nil {
+		return "", fmt.Errorf("error converting private key to ECDSA: %v", err)
+	}
+	signature, err := crypto.Sign(challengeHash.Bytes(), privateKey)
+	if err != nil {
+		return "", fmt.Errorf("error signing challenge hash: %v", err)
+	}
+	signature[64] += 27 // Adjust the `v` value
+
+	return fmt.Sprintf("0x%x", signature), nil
+}
@@ -1,11 +1,21 @@
 package onchain
 
 import (
+	"math/big"
+	"strings"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/require"
 
+	"github.com/1inch/1inch-sdk-go/helpers/consts/abis"
+	"github.com/1inch/1inch-sdk-go/helpers/consts/amounts"
 	"github.com/1inch/1inch-sdk-go/helpers/consts/chains"
+	"github.com/1inch/1inch-sdk-go/helpers/consts/contracts"
+	"github.com/1inch/1inch-sdk-go/helpers/consts/tokens"
+	"github.com/1inch/1inch-sdk-go/helpers/consts/typehashes"
 )
 
 func TestCreatePermitSignature(t *testing.T) {
@@ -78,3 +88,379 @@ func TestConvertSignatureToVRSString(t *testing.T) {
 		})
 	}
 }
+
+func TestHashPermitDomain(t *testing.T) {
+	testcases := []struct {
+		description             string
+		domain                  *PermitDomain
+		expectedDomainSeparator string
+	}{
+		{
+			description: "DAI on Ethereum",
+			domain: &PermitDomain{
+				Fields:            DomainFieldName | DomainFieldVersion | DomainFieldChainId | DomainFieldVerifyingContract,
+				Name:              "Dai Stablecoin",
+				Version:           "1",
+				ChainId:           big.NewInt(chains.Ethereum),
+				VerifyingContract: common.HexToAddress(tokens.EthereumDai),
+			},
+			expectedDomainSeparator: "0xdbb8cf42e1ecb028be3f3dbc922e1d878b963f411dc388ced501601c60f7c6f7",
+		},
+		{
+			description: "Domain without a version",
+			domain: &PermitDomain{
+				Fields:            DomainFieldName | DomainFieldChainId | DomainFieldVerifyingContract,
+				Name:              "Uniswap",
+				ChainId:           big.NewInt(chains.Ethereum),
+				VerifyingContract: common.HexToAddress("0x1f9840a85d5aF5bf1D1762F925BDADdC4201F984"),
+			},
+			expectedDomainSeparator: crypto.Keccak256Hash(
+				crypto.Keccak256([]byte("EIP712Domain(string name,uint256 chainId,address verifyingContract)")),
+				crypto.Keccak256([]byte("Uniswap")),
+				common.BigToHash(big.NewInt(chains.Ethereum)).Bytes(),
+				common.LeftPadBytes(common.HexToAddress("0x1f9840a85d5aF5bf1D1762F925BDADdC4201F984").Bytes(), 32),
+			).Hex(),
+		},
+		{
+			description: "Domain with the chain id as a salt",
+			domain: &PermitDomain{
+				Fields:            DomainFieldName | DomainFieldVersion | DomainFieldVerifyingContract | DomainFieldSalt,
+				Name:              "USD Coin (PoS)",
+				Version:           "1",
+				VerifyingContract: common.HexToAddress(tokens.PolygonUsdc),
+				Salt:              common.BigToHash(big.NewInt(chains.Polygon)),
+			},
+			expectedDomainSeparator: crypto.Keccak256Hash(
+				crypto.Keccak256([]byte("EIP712Domain(string name,string version,address verifyingContract,bytes32 salt)")),
+				crypto.Keccak256([]byte("USD Coin (PoS)")),
+				crypto.Keccak256([]byte("1")),
+				common.LeftPadBytes(common.HexToAddress(tokens.PolygonUsdc).Bytes(), 32),
+				common.BigToHash(big.NewInt(chains.Polygon)).Bytes(),
+			).Hex(),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.description, func(t *testing.T) {
+			require.Equal(t, tc.expectedDomainSeparator, HashPermitDomain(tc.domain).Hex())
+		})
+	}
+}
+
+func TestFindPermitDomain(t *testing.T) {
+	token := common.HexToAddress(tokens.PolygonFrax)
+	chainId := big.NewInt(chains.Polygon)
+
+	testcases := []struct {
+		description          string
+		actualDomain         *PermitDomain
+		readVersion          string
+		expectedErrorMessage string
+	}{
+		{
+			description: "Standard domain",
+			actualDomain: &PermitDomain{
+				Fields:            DomainFieldName | DomainFieldVersion | DomainFieldChainId | DomainFieldVerifyingContract,
+				Name:              "Frax",
+				Version:           "1",
+				ChainId:           chainId,
+				VerifyingContract: token,
+			},
+			readVersion: "1",
+		},
+		{
+			description: "Version 2 on a token without a version getter",
+			actualDomain: &PermitDomain{
+				Fields:            DomainFieldName | DomainFieldVersion | DomainFieldChainId | DomainFieldVerifyingContract,
+				Name:              "Frax",
+				Version:           "2",
+				ChainId:           chainId,
+				VerifyingContract: token,
+			},
+			readVersion: "1",
+		},
+		{
+			description: "Custom version read from the token",
+			actualDomain: &PermitDomain{
+				Fields:            DomainFieldName | DomainFieldVersion | DomainFieldChainId | DomainFieldVerifyingContract,
+				Name:              "Frax",
+				Version:           "3.1",
+				ChainId:           chainId,
+				VerifyingContract: token,
+			},
+			readVersion: "3.1",
+		},
+		{
+			description: "Domain without a version",
+			actualDomain: &PermitDomain{
+				Fields:            DomainFieldName | DomainFieldChainId | DomainFieldVerifyingContract,
+				Name:              "Frax",
+				ChainId:           chainId,
+				VerifyingContract: token,
+			},
+			readVersion: "1",
+		},
+		{
+			description: "Domain with the chain id as a salt",
+			actualDomain: &PermitDomain{
+				Fields:            DomainFieldName | DomainFieldVersion | DomainFieldVerifyingContract | DomainFieldSalt,
+				Name:              "Frax",
+				Version:           "1",
+				VerifyingContract: token,
+				Salt:              common.BigToHash(chainId),
+			},
+			readVersion: "1",
+		},
+		{
+			description: "Error - domain for another chain",
+			actualDomain: &PermitDomain{
+				Fields:            DomainFieldName | DomainFieldVersion | DomainFieldChainId | DomainFieldVerifyingContract,
+				Name:              "Frax",
+				Version:           "1",
+				ChainId:           big.NewInt(chains.Ethereum),
+				VerifyingContract: token,
+			},
+			readVersion:          "1",
+			expectedErrorMessage: "does not match any known domain",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.description, func(t *testing.T) {
+			domain, err := FindPermitDomain(HashPermitDomain(tc.actualDomain), chains.Polygon, token, "Frax", tc.readVersion)
+			if tc.expectedErrorMessage != "" {
+				require.ErrorContains(t, err, tc.expectedErrorMessage)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.actualDomain, domain)
+		})
+	}
+}
+
+func TestGetPermitInfo(t *testing.T) {
+	parsedABI, err := abi.JSON(strings.NewReader(abis.Erc20))
+	require.NoError(t, err)
+
+	token := common.HexToAddress(tokens.EthereumDai)
+	daiDomain := &PermitDomain{
+		Fields:            DomainFieldName | DomainFieldVersion | DomainFieldChainId | DomainFieldVerifyingContract,
+		Name:              "Dai Stablecoin",
+		Version:           "1",
+		ChainId:           big.NewInt(chains.Ethereum),
+		VerifyingContract: token,
+	}
+
+	pack := func(method string, args ...interface{}) []byte {
+		result, err := parsedABI.Methods[method].Outputs.Pack(args...)
+		require.NoError(t, err)
+		return result
+	}
+
+	testcases := []struct {
+		description          string
+		results              map[string][]byte
+		expectedInfo         *PermitInfo
+		expectedErrorMessage string
+	}{
+		{
+			description: "DAI-style permit resolved through DOMAIN_SEPARATOR",
+			results: map[string][]byte{
+				"PERMIT_TYPEHASH":  common.FromHex(typehashes.DaiPermit),
+				"DOMAIN_SEPARATOR": HashPermitDomain(daiDomain).Bytes(),
+				"name":             pack("name", "Dai Stablecoin"),
+				"version":          pack("version", "1"),
+			},
+			expectedInfo: &PermitInfo{Type: PermitTypeDai, Domain: *daiDomain},
+		},
+		{
+			description: "EIP-2612 permit resolved through eip712Domain without a PERMIT_TYPEHASH",
+			results: map[string][]byte{
+				"nonces":           pack("nonces", big.NewInt(0)),
+				"DOMAIN_SEPARATOR": HashPermitDomain(daiDomain).Bytes(),
+				"eip712Domain":     pack("eip712Domain", [1]byte{daiDomain.Fields}, daiDomain.Name, daiDomain.Version, daiDomain.ChainId, token, [32]byte{}, []*big.Int{}),
+			},
+			expectedInfo: &PermitInfo{Type: PermitTypeEip2612, Domain: *daiDomain},
+		},
+		{
+			description: "Error - token without permit support",
+			results: map[string][]byte{
+				"name": pack("name", "Dai Stablecoin"),
+			},
+			expectedErrorMessage: "token does not support permits",
+		},
+		{
+			description: "Error - unknown PERMIT_TYPEHASH",
+			results: map[string][]byte{
+				"PERMIT_TYPEHASH": crypto.Keccak256([]byte("Permit(address owner)")),
+			},
+			expectedErrorMessage: "unsupported PERMIT_TYPEHASH",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.description, func(t *testing.T) {
+			client := setupRpc(t, map[string]rpcHandler{
+				"eth_call": erc20CallHandler(t, tc.results),
+			})
+
+			info, err := GetPermitInfo(client, chains.Ethereum, token)
+			if tc.expectedErrorMessage != "" {
+				require.ErrorContains(t, err, tc.expectedErrorMessage)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedInfo, info)
+		})
+	}
+}
+
+func TestCreateDaiPermitSignature(t *testing.T) {
+	key := "ad21c0552a3b52e94520da713455cc347e4e89628a334be24d85b8083848434f"
+	privateKey, err := crypto.HexToECDSA(key)
+	require.NoError(t, err)
+	publicAddress := crypto.PubkeyToAddress(privateKey.PublicKey)
+
+	domain := &PermitDomain{
+		Fields:            DomainFieldName | DomainFieldVersion | DomainFieldChainId | DomainFieldVerifyingContract,
+		Name:              "Dai Stablecoin",
+		Version:           "1",
+		ChainId:           big.NewInt(chains.Ethereum),
+		VerifyingContract: common.HexToAddress(tokens.EthereumDai),
+	}
+
+	signature, err := CreatePermitSignature(&PermitSignatureConfig{
+		FromToken:     tokens.EthereumDai,
+		PublicAddress: publicAddress.Hex(),
+		ChainId:       chains.Ethereum,
+		Key:           key,
+		Nonce:         4,
+		Deadline:      1704250835,
+		Type:          PermitTypeDai,
+		Domain:        domain,
+	})
+	require.NoError(t, err)
+
+	structHash := crypto.Keccak256(
+		common.FromHex(typehashes.DaiPermit),
+		common.LeftPadBytes(publicAddress.Bytes(), 32),
+		common.LeftPadBytes(common.HexToAddress(contracts.AggregationRouterV5).Bytes(), 32),
+		common.BigToHash(big.NewInt(4)).Bytes(),
+		common.BigToHash(big.NewInt(1704250835)).Bytes(),
+		common.BigToHash(big.NewInt(1)).Bytes(),
+	)
+	digest := crypto.Keccak256(append(append([]byte("\x19\x01"), HashPermitDomain(domain).Bytes()...), structHash...))
+
+	sig := common.FromHex(signature)
+	sig[64] -= 27
+	recovered, err := crypto.SigToPub(digest, sig)
+	require.NoError(t, err)
+	require.Equal(t, publicAddress, crypto.PubkeyToAddress(*recovered))
+}
+
+func TestCreatePermit(t *testing.T) {
+	parsedABI, err := abi.JSON(strings.NewReader(abis.Erc20))
+	require.NoError(t, err)
+
+	privateKey, err := crypto.HexToECDSA(testKey)
+	require.NoError(t, err)
+	owner := crypto.PubkeyToAddress(privateKey.PublicKey)
+	token := common.HexToAddress(tokens.EthereumUsdc)
+	deadline := int64(1700000000)
+
+	pack := func(method string, args ...interface{}) []byte {
+		result, err := parsedABI.Methods[method].Outputs.Pack(args...)
+		require.NoError(t, err)
+		return result
+	}
+
+	// The standard domain the token name and version resolve to when the token exposes neither eip712Domain nor DOMAIN_SEPARATOR
+	defaultDomain := &PermitDomain{
+		Fields:            DomainFieldName | DomainFieldVersion | DomainFieldChainId | DomainFieldVerifyingContract,
+		Name:              "USD Coin",
+		Version:           "2",
+		ChainId:           big.NewInt(chains.Ethereum),
+		VerifyingContract: token,
+	}
+	signature, err := CreatePermitSignature(&PermitSignatureConfig{
+		FromToken:     token.Hex(),
+		PublicAddress: owner.Hex(),
+		ChainId:       chains.Ethereum,
+		Key:           testKey,
+		Nonce:         3,
+		Deadline:      deadline,
+		Value:         amounts.BigMaxUint256,
+		Type:          PermitTypeEip2612,
+		Domain:        defaultDomain,
+	})
+	require.NoError(t, err)
+	defaultDomainPermit := CreatePermitParams(&PermitParamsConfig{
+		Owner:     owner.Hex(),
+		Spender:   contracts.AggregationRouterV5,
+		Value:     amounts.BigMaxUint256,
+		Deadline:  deadline,
+		Signature: signature,
+		Type:      PermitTypeEip2612,
+		Nonce:     3,
+	})
+
+	testcases := []struct {
+		description          string
+		results              map[string][]byte
+		expectedPermit       string
+		expectedErrorMessage string
+	}{
+		{
+			description: "Known permit type with a domain that cannot be resolved",
+			results: map[string][]byte{
+				"PERMIT_TYPEHASH": common.FromHex(typehashes.Permit1),
+				"nonces":          pack("nonces", big.NewInt(3)),
+				"name":            pack("name", "USD Coin"),
+				"version":         pack("version", "2"),
+			},
+			expectedPermit: defaultDomainPermit,
+		},
+		{
+			description: "Error - token without permit support",
+			results: map[string][]byte{
+				"name":    pack("name", "USD Coin"),
+				"version": pack("version", "2"),
+			},
+			expectedErrorMessage: "token does not support permits",
+		},
+		{
+			description: "Error - unknown PERMIT_TYPEHASH",
+			results: map[string][]byte{
+				"PERMIT_TYPEHASH": crypto.Keccak256([]byte("Permit(address owner)")),
+				"nonces":          pack("nonces", big.NewInt(3)),
+				"name":            pack("name", "USD Coin"),
+				"version":         pack("version", "2"),
+			},
+			expectedErrorMessage: "unsupported PERMIT_TYPEHASH",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.description, func(t *testing.T) {
+			client := setupRpc(t, map[string]rpcHandler{
+				"eth_call": erc20CallHandler(t, tc.results),
+			})
+
+			permit, err := CreatePermit(&CreatePermitConfig{
+				EthClient:     client,
+				MakerAsset:    token.Hex(),
+				PublicAddress: owner,
+				ChainId:       chains.Ethereum,
+				PrivateKey:    testKey,
+				Deadline:      deadline,
+			})
+			if tc.expectedErrorMessage != "" {
+				require.ErrorContains(t, err, tc.expectedErrorMessage)
+				require.Equal(t, "0x", permit)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedPermit, permit)
+		})
+	}
+}
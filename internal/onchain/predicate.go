@@ -0,0 +1,172 @@
+package onchain
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/1inch/1inch-sdk-go/helpers/consts/abis"
+)
+
+// maxPredicateOperands is the number of 32-bit offsets that fit in the uint256 offsets argument of and/or
+const maxPredicateOperands = 8
+
+// chainlinkLatestAnswerAbi is the only method of a Chainlink aggregator the predicate builder needs
+const chainlinkLatestAnswerAbi = `[{"inputs":[],"name":"latestAnswer","outputs":[{"internalType":"int256","name":"","type":"int256"}],"stateMutability":"view","type":"function"}]`
+
+// Predicate is the calldata of a view call the router makes to itself when it checks whether an order can be filled.
+// Boolean predicates return 1 when they hold. Value calls such as ArbitraryStaticCall return a uint256 instead and
+// are meant to be compared with Lt, Gt or Eq.
+type Predicate []byte
+
+// Hex returns the predicate as a 0x prefixed hex string
+func (p Predicate) Hex() string {
+	return fmt.Sprintf("0x%x", []byte(p))
+}
+
+// And holds when every predicate holds, they are checked in order and the first one that fails stops the check
+func And(predicates ...Predicate) (Predicate, error) {
+	return packPredicates("and", predicates)
+}
+
+// Or holds when any predicate holds, they are checked in order and the first one that holds stops the check
+func Or(predicates ...Predicate) (Predicate, error) {
+	return packPredicates("or", predicates)
+}
+
+// Not holds when the predicate does not. AggregationRouterV5 has no not helper, so the predicate is compared with 0.
+// A comparison whose call reverts does not hold, so its negation holds.
+func Not(predicate Predicate) (Predicate, error) {
+	return Eq(big.NewInt(0), predicate)
+}
+
+// Lt holds when the call returns less than the value
+func Lt(value *big.Int, call Predicate) (Predicate, error) {
+	return packComparison("lt", value, call)
+}
+
+// Gt holds when the call returns more than the value
+func Gt(value *big.Int, call Predicate) (Predicate, error) {
+	return packComparison("gt", value, call)
+}
+
+// Eq holds when the call returns the value
+func Eq(value *big.Int, call Predicate) (Predicate, error) {
+	return packComparison("eq", value, call)
+}
+
+// ArbitraryStaticCall returns the uint256 a view call to another contract returns, the router reverts if the call does
+func ArbitraryStaticCall(target common.Address, data []byte) (Predicate, error) {
+	return packRouterCall("arbitraryStaticCall", target, data)
+}
+
+// TimestampBelow holds until the expiration, a unix timestamp in seconds
+func TimestampBelow(expiration int64) (Predicate, error) {
+	if expiration <= 0 {
+		return nil, fmt.Errorf("expiration must be greater than 0")
+	}
+	return packRouterCall("timestampBelow", big.NewInt(expiration))
+}
+
+// NonceEquals holds while the nonce of the maker on the router itself equals the nonce
+func NonceEquals(maker common.Address, nonce *big.Int) (Predicate, error) {
+	if nonce == nil || nonce.Sign() < 0 {
+		return nil, fmt.Errorf("nonce must be 0 or greater")
+	}
+	return packRouterCall("nonceEquals", maker, nonce)
+}
+
+// TimestampBelowAndNonceEquals holds until the expiration while the limit order series nonce of the maker on the
+// series nonce manager equals the nonce. This is the predicate of every limit order posted by the SDK.
+func TimestampBelowAndNonceEquals(seriesNonceManager string, expiration int64, nonce *big.Int, maker string) (Predicate, error) {
+	data, err := GetTimestampBelowAndNonceEqualsCalldata(expiration, nonce, maker)
+	if err != nil {
+		return nil, err
+	}
+	return ArbitraryStaticCall(common.HexToAddress(seriesNonceManager), data)
+}
+
+// ChainlinkAnswerAbove holds while the latest answer of a Chainlink aggregator is greater than the value.
+// The answer is compared as a uint256, so the value is in the decimals of the aggregator.
+func ChainlinkAnswerAbove(aggregator common.Address, value *big.Int) (Predicate, error) {
+	call, err := chainlinkLatestAnswer(aggregator)
+	if err != nil {
+		return nil, err
+	}
+	return Gt(value, call)
+}
+
+// ChainlinkAnswerBelow holds while the latest answer of a Chainlink aggregator is less than the value, which is how
+// stop-loss orders are built. The answer is compared as a uint256, so the value is in the decimals of the aggregator.
+func ChainlinkAnswerBelow(aggregator common.Address, value *big.Int) (Predicate, error) {
+	call, err := chainlinkLatestAnswer(aggregator)
+	if err != nil {
+		return nil, err
+	}
+	return Lt(value, call)
+}
+
+func chainlinkLatestAnswer(aggregator common.Address) (Predicate, error) {
+	parsedABI, err := abi.JSON(strings.NewReader(chainlinkLatestAnswerAbi))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ABI: %v", err)
+	}
+
+	data, err := parsedABI.Pack("latestAnswer")
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack data: %v", err)
+	}
+	return ArbitraryStaticCall(aggregator, data)
+}
+
+func packComparison(method string, value *big.Int, call Predicate) (Predicate, error) {
+	if value == nil || value.Sign() < 0 {
+		return nil, fmt.Errorf("value of %s must be 0 or greater", method)
+	}
+	if len(call) == 0 {
+		return nil, fmt.Errorf("call of %s cannot be empty", method)
+	}
+	return packRouterCall(method, value, []byte(call))
+}
+
+// packPredicates packs the predicates for and/or, which read the end of each predicate in the data from consecutive
+// 32-bit slots of the offsets
+func packPredicates(method string, predicates []Predicate) (Predicate, error) {
+	if len(predicates) == 0 {
+		return nil, fmt.Errorf("%s needs at least one predicate", method)
+	}
+	if len(predicates) > maxPredicateOperands {
+		return nil, fmt.Errorf("%s supports at most %d predicates", method, maxPredicateOperands)
+	}
+
+	offsets := new(big.Int)
+	var data []byte
+	for i, predicate := range predicates {
+		if len(predicate) == 0 {
+			return nil, fmt.Errorf("predicate %d of %s cannot be empty", i, method)
+		}
+		data = append(data, predicate...)
+		if uint64(len(data)) > uint64(^uint32(0)) {
+			return nil, fmt.Errorf("predicates of %s are too long", method)
+		}
+		end := new(big.Int).SetUint64(uint64(len(data)))
+		offsets.Or(offsets, end.Lsh(end, uint(32*i)))
+	}
+	return packRouterCall(method, offsets, data)
+}
+
+func packRouterCall(method string, args ...interface{}) (Predicate, error) {
+	parsedABI, err := abi.JSON(strings.NewReader(abis.AggregationRouterV5))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ABI: %v", err)
+	}
+
+	data, err := parsedABI.Pack(method, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack data: %v", err)
+	}
+	return data, nil
+}
@@ -0,0 +1,161 @@
+package onchain
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/1inch/1inch-sdk-go/helpers/consts/abis"
+)
+
+func TestPredicateBuilders(t *testing.T) {
+	parsedABI, err := abi.JSON(strings.NewReader(abis.AggregationRouterV5))
+	require.NoError(t, err)
+
+	maker := common.HexToAddress("0x2c9b2DBdbA8A9c969Ac24153f5C1c23CB0e63914")
+	aggregator := common.HexToAddress("0xF9680D99D6C9589e2a93a78A04A279e509205945")
+	expiry, err := TimestampBelow(1700000000)
+	require.NoError(t, err)
+	nonce, err := NonceEquals(maker, big.NewInt(3))
+	require.NoError(t, err)
+
+	// latestAnswer()
+	latestAnswer := common.FromHex("0x50d25bcd")
+	oracleCall, err := ArbitraryStaticCall(aggregator, latestAnswer)
+	require.NoError(t, err)
+
+	testcases := []struct {
+		description      string
+		build            func() (Predicate, error)
+		expectedMethod   string
+		expectedArgs     []interface{}
+		expectedErrorMsg string
+	}{
+		{
+			description:    "Timestamp below",
+			build:          func() (Predicate, error) { return TimestampBelow(1700000000) },
+			expectedMethod: "timestampBelow",
+			expectedArgs:   []interface{}{big.NewInt(1700000000)},
+		},
+		{
+			description:    "Nonce equals",
+			build:          func() (Predicate, error) { return NonceEquals(maker, big.NewInt(3)) },
+			expectedMethod: "nonceEquals",
+			expectedArgs:   []interface{}{maker, big.NewInt(3)},
+		},
+		{
+			description:    "And packs the end of each predicate in 32-bit offsets",
+			build:          func() (Predicate, error) { return And(expiry, nonce) },
+			expectedMethod: "and",
+			expectedArgs: []interface{}{
+				new(big.Int).Or(big.NewInt(int64(len(expiry))), new(big.Int).Lsh(big.NewInt(int64(len(expiry)+len(nonce))), 32)),
+				append(append([]byte{}, expiry...), nonce...),
+			},
+		},
+		{
+			description:    "Or of a single predicate",
+			build:          func() (Predicate, error) { return Or(expiry) },
+			expectedMethod: "or",
+			expectedArgs:   []interface{}{big.NewInt(int64(len(expiry))), []byte(expiry)},
+		},
+		{
+			description:    "Not compares the predicate with 0",
+			build:          func() (Predicate, error) { return Not(expiry) },
+			expectedMethod: "eq",
+			expectedArgs:   []interface{}{big.NewInt(0), []byte(expiry)},
+		},
+		{
+			description:    "Arbitrary static call",
+			build:          func() (Predicate, error) { return ArbitraryStaticCall(aggregator, latestAnswer) },
+			expectedMethod: "arbitraryStaticCall",
+			expectedArgs:   []interface{}{aggregator, latestAnswer},
+		},
+		{
+			description: "Chainlink answer above",
+			build: func() (Predicate, error) {
+				return ChainlinkAnswerAbove(aggregator, big.NewInt(180000000000))
+			},
+			expectedMethod: "gt",
+			expectedArgs:   []interface{}{big.NewInt(180000000000), []byte(oracleCall)},
+		},
+		{
+			description: "Chainlink answer below",
+			build: func() (Predicate, error) {
+				return ChainlinkAnswerBelow(aggregator, big.NewInt(150000000000))
+			},
+			expectedMethod: "lt",
+			expectedArgs:   []interface{}{big.NewInt(150000000000), []byte(oracleCall)},
+		},
+		{
+			description:      "Error - and without predicates",
+			build:            func() (Predicate, error) { return And() },
+			expectedErrorMsg: "and needs at least one predicate",
+		},
+		{
+			description: "Error - or with too many predicates",
+			build: func() (Predicate, error) {
+				return Or(expiry, expiry, expiry, expiry, expiry, expiry, expiry, expiry, expiry)
+			},
+			expectedErrorMsg: "or supports at most 8 predicates",
+		},
+		{
+			description:      "Error - empty predicate",
+			build:            func() (Predicate, error) { return And(expiry, nil) },
+			expectedErrorMsg: "predicate 1 of and cannot be empty",
+		},
+		{
+			description:      "Error - negative value",
+			build:            func() (Predicate, error) { return Gt(big.NewInt(-1), expiry) },
+			expectedErrorMsg: "value of gt must be 0 or greater",
+		},
+		{
+			description:      "Error - comparison without a call",
+			build:            func() (Predicate, error) { return Eq(big.NewInt(1), nil) },
+			expectedErrorMsg: "call of eq cannot be empty",
+		},
+		{
+			description:      "Error - expiration in the past of the epoch",
+			build:            func() (Predicate, error) { return TimestampBelow(0) },
+			expectedErrorMsg: "expiration must be greater than 0",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.description, func(t *testing.T) {
+			predicate, err := tc.build()
+			if tc.expectedErrorMsg != "" {
+				require.EqualError(t, err, tc.expectedErrorMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			method, err := parsedABI.MethodById(predicate[:4])
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedMethod, method.Name)
+
+			args, err := method.Inputs.Unpack(predicate[4:])
+			require.NoError(t, err)
+			require.Equal(t, fmt.Sprint(tc.expectedArgs), fmt.Sprint(args))
+		})
+	}
+}
+
+func TestTimestampBelowAndNonceEquals(t *testing.T) {
+	seriesNonceManager := "0xa5eb255EF45dFb48B5d133d08833DEF69871691D"
+	maker := "0x50c5df26654B5EFBdD0C54a062dfa6012933deFe"
+
+	predicate, err := TimestampBelowAndNonceEquals(seriesNonceManager, 1700000000, big.NewInt(2), maker)
+	require.NoError(t, err)
+
+	data, err := GetTimestampBelowAndNonceEqualsCalldata(1700000000, big.NewInt(2), maker)
+	require.NoError(t, err)
+	expected, err := GetPredicateCalldata(seriesNonceManager, data)
+	require.NoError(t, err)
+	require.Equal(t, expected, []byte(predicate))
+	require.Equal(t, fmt.Sprintf("0x%x", expected), predicate.Hex())
+}
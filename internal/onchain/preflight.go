@@ -0,0 +1,134 @@
+package onchain
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	"github.com/1inch/1inch-sdk-go/helpers"
+	"github.com/1inch/1inch-sdk-go/helpers/consts/tokens"
+)
+
+const nativeTokenDecimals = 18
+
+// ApprovalGasFallback is a generous amount of gas for an ERC20 approval that cannot be estimated
+const ApprovalGasFallback = uint64(100000)
+
+// SwapGasFallback is a generous amount of gas for a router swap that cannot be estimated, usually because the approval
+// it depends on has not been sent yet
+const SwapGasFallback = uint64(1000000)
+
+// InsufficientFundsError is returned when a wallet does not hold enough of a token for the transactions it is about to send
+type InsufficientFundsError struct {
+	Token     string // Address of the token, tokens.NativeToken for the native gas token
+	Symbol    string
+	Decimals  uint8
+	Required  *big.Int
+	Available *big.Int
+	GasCost   *big.Int // Estimated part of a native token requirement spent on gas, nil for ERC20 tokens
+}
+
+// Shortfall returns how much of the token is missing, in the smallest unit of the token
+func (e *InsufficientFundsError) Shortfall() *big.Int {
+	return new(big.Int).Sub(e.Required, e.Available)
+}
+
+func (e *InsufficientFundsError) Error() string {
+	message := fmt.Sprintf("insufficient %s balance: %s required, %s available, %s %s short",
+		e.Symbol,
+		helpers.SimplifyValue(e.Required.String(), int(e.Decimals)),
+		helpers.SimplifyValue(e.Available.String(), int(e.Decimals)),
+		helpers.SimplifyValue(e.Shortfall().String(), int(e.Decimals)),
+		e.Symbol,
+	)
+	if e.GasCost != nil && e.GasCost.Sign() > 0 {
+		message += fmt.Sprintf(" (including an estimated %s %s of gas)", helpers.SimplifyValue(e.GasCost.String(), int(e.Decimals)), e.Symbol)
+	}
+	return message
+}
+
+// CheckFunds makes sure a wallet holds the ERC20 amount it is about to spend along with enough native tokens
+// for the value and the gas of its transactions, an *InsufficientFundsError is returned otherwise
+func CheckFunds(ctx context.Context, client *ethclient.Client, config PreflightConfig) error {
+	if config.Amount != nil && config.Token != (common.Address{}) && config.Token != common.HexToAddress(tokens.NativeToken) {
+		balance, err := ReadContractBalance(client, config.Token, config.PublicAddress)
+		if err != nil {
+			return fmt.Errorf("failed to read balance of %s: %v", config.TokenSymbol, err)
+		}
+		if balance.Cmp(config.Amount) < 0 {
+			return &InsufficientFundsError{
+				Token:     config.Token.Hex(),
+				Symbol:    config.TokenSymbol,
+				Decimals:  config.TokenDecimals,
+				Required:  config.Amount,
+				Available: balance,
+			}
+		}
+	}
+
+	if len(config.Transactions) == 0 {
+		return nil
+	}
+
+	gasCost, err := EstimateGasCost(ctx, client, config.PublicAddress, config.Transactions)
+	if err != nil {
+		return err
+	}
+
+	required := new(big.Int).Set(gasCost)
+	for _, msg := range config.Transactions {
+		if msg.Value != nil {
+			required.Add(required, msg.Value)
+		}
+	}
+
+	balance, err := client.BalanceAt(ctx, config.PublicAddress, nil)
+	if err != nil {
+		return fmt.Errorf("failed to read balance of %s: %v", config.NativeSymbol, err)
+	}
+	if balance.Cmp(required) < 0 {
+		return &InsufficientFundsError{
+			Token:     tokens.NativeToken,
+			Symbol:    config.NativeSymbol,
+			Decimals:  nativeTokenDecimals,
+			Required:  required,
+			Available: balance,
+			GasCost:   gasCost,
+		}
+	}
+	return nil
+}
+
+// EstimateGasCost returns the gas cost of sending the transactions at the current gas price
+// Transactions that cannot be estimated yet, such as a swap waiting on the approval sent before it, fall back to their Gas field
+func EstimateGasCost(ctx context.Context, client *ethclient.Client, from common.Address, transactions []ethereum.CallMsg) (*big.Int, error) {
+	if len(transactions) == 0 {
+		return big.NewInt(0), nil
+	}
+
+	var totalGas uint64
+	for i, msg := range transactions {
+		msg.From = from
+		fallbackGas := msg.Gas
+		msg.Gas = 0
+		gas, err := client.EstimateGas(ctx, msg)
+		if err != nil {
+			if fallbackGas == 0 {
+				return nil, fmt.Errorf("failed to estimate gas of transaction %d: %v", i+1, err)
+			}
+			gas = fallbackGas
+		}
+		totalGas += gas
+	}
+
+	gasPrice, err := client.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to suggest gas price: %v", err)
+	}
+
+	return new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(totalGas)), nil
+}
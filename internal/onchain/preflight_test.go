@@ -0,0 +1,132 @@
+package onchain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/1inch/1inch-sdk-go/helpers/consts/tokens"
+)
+
+func TestCheckFunds(t *testing.T) {
+	wallet := common.HexToAddress("0x50c5df26654b5efbdd0c54a062dfa6012933defe")
+	token := common.HexToAddress(tokens.EthereumUsdc)
+	router := common.HexToAddress("0x1111111254eeb25477b68fb85ed929f73a960582")
+	gwei := big.NewInt(1e9)
+
+	testcases := []struct {
+		description          string
+		tokenBalance         int64
+		nativeBalance        *big.Int
+		estimateFails        bool
+		transactions         []ethereum.CallMsg
+		expectedError        *InsufficientFundsError
+		expectedErrorMessage string
+	}{
+		{
+			description:   "Enough of the token and of the native token",
+			tokenBalance:  5_000_000,
+			nativeBalance: big.NewInt(1e18),
+			transactions:  []ethereum.CallMsg{{To: &router}},
+		},
+		{
+			description:   "Token balance only",
+			tokenBalance:  5_000_000,
+			nativeBalance: big.NewInt(0),
+		},
+		{
+			description:   "Unestimated transaction uses its gas fallback",
+			tokenBalance:  5_000_000,
+			nativeBalance: new(big.Int).Mul(big.NewInt(300_000), gwei),
+			estimateFails: true,
+			transactions:  []ethereum.CallMsg{{To: &router, Gas: 300_000}},
+		},
+		{
+			description:   "Error - not enough of the token",
+			tokenBalance:  1_500_000,
+			nativeBalance: big.NewInt(1e18),
+			expectedError: &InsufficientFundsError{
+				Token:     token.Hex(),
+				Symbol:    "USDC",
+				Decimals:  6,
+				Required:  big.NewInt(2_000_000),
+				Available: big.NewInt(1_500_000),
+			},
+			expectedErrorMessage: "insufficient USDC balance: 2 required, 1.5 available, 0.5 USDC short",
+		},
+		{
+			description:   "Error - not enough native token for value and gas",
+			tokenBalance:  5_000_000,
+			nativeBalance: big.NewInt(1e17),
+			transactions:  []ethereum.CallMsg{{To: &router, Value: big.NewInt(1e17)}},
+			expectedError: &InsufficientFundsError{
+				Token:     tokens.NativeToken,
+				Symbol:    "ETH",
+				Decimals:  18,
+				Required:  new(big.Int).Add(big.NewInt(1e17), new(big.Int).Mul(big.NewInt(100_000), gwei)),
+				Available: big.NewInt(1e17),
+				GasCost:   new(big.Int).Mul(big.NewInt(100_000), gwei),
+			},
+			expectedErrorMessage: "insufficient ETH balance: 0.1001 required, 0.1 available, 0.0001 ETH short (including an estimated 0.0001 ETH of gas)",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.description, func(t *testing.T) {
+			client := setupRpc(t, map[string]rpcHandler{
+				"eth_call": erc20CallHandler(t, map[string][]byte{
+					"balanceOf": common.LeftPadBytes(big.NewInt(tc.tokenBalance).Bytes(), 32),
+				}),
+				"eth_estimateGas": func(params []json.RawMessage) (interface{}, error) {
+					if tc.estimateFails {
+						return nil, errExecutionReverted
+					}
+					return hexutil.Uint64(100_000), nil
+				},
+				"eth_gasPrice": func(params []json.RawMessage) (interface{}, error) {
+					return (*hexutil.Big)(gwei), nil
+				},
+				"eth_getBalance": func(params []json.RawMessage) (interface{}, error) {
+					return (*hexutil.Big)(tc.nativeBalance), nil
+				},
+			})
+
+			err := CheckFunds(context.Background(), client, PreflightConfig{
+				PublicAddress: wallet,
+				Token:         token,
+				TokenSymbol:   "USDC",
+				TokenDecimals: 6,
+				Amount:        big.NewInt(2_000_000),
+				NativeSymbol:  "ETH",
+				Transactions:  tc.transactions,
+			})
+			if tc.expectedError != nil {
+				var insufficientFundsErr *InsufficientFundsError
+				require.True(t, errors.As(err, &insufficientFundsErr))
+				require.Equal(t, tc.expectedError, insufficientFundsErr)
+				require.EqualError(t, err, tc.expectedErrorMessage)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestEstimateGasCostWithoutFallback(t *testing.T) {
+	router := common.HexToAddress("0x1111111254eeb25477b68fb85ed929f73a960582")
+	client := setupRpc(t, map[string]rpcHandler{
+		"eth_estimateGas": func(params []json.RawMessage) (interface{}, error) {
+			return nil, errExecutionReverted
+		},
+	})
+
+	_, err := EstimateGasCost(context.Background(), client, common.Address{}, []ethereum.CallMsg{{To: &router}})
+	require.ErrorContains(t, err, "failed to estimate gas of transaction 1")
+}
@@ -0,0 +1,269 @@
+package onchain
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/1inch/1inch-sdk-go/helpers/consts/abis"
+)
+
+// rangePriceScale is the amount of maker asset base units the prices of a range order are quoted for
+var rangePriceScale = big.NewInt(1e18)
+
+// DynamicPricing prices a limit order through calculator contracts the router calls for its getMakingAmount and
+// getTakingAmount interactions, instead of the fixed ratio of its making and taking amounts
+type DynamicPricing interface {
+	Validate() error
+	// Getters returns the getMakingAmount and getTakingAmount interactions of an order selling the making amount
+	Getters(orderMakingAmount *big.Int) (getMakingAmount []byte, getTakingAmount []byte, err error)
+	// TakingAmount returns what a taker pays for the making amount at the given time, the remaining making amount is
+	// what is left of the order before the fill
+	TakingAmount(orderMakingAmount, makingAmount, remainingMakingAmount *big.Int, at time.Time) (*big.Int, error)
+	// MakingAmount returns what a taker receives for the taking amount at the given time, the remaining making amount
+	// is what is left of the order before the fill
+	MakingAmount(orderMakingAmount, takingAmount, remainingMakingAmount *big.Int, at time.Time) (*big.Int, error)
+}
+
+// DutchAuction prices an order with a DutchAuctionCalculator. The taking amount of the whole order moves linearly from
+// TakingAmountStart at StartTime to TakingAmountEnd at EndTime and stays at the closest end outside of that window.
+// The taking amount of the order itself should be TakingAmountEnd, the price the maker accepts once the auction is over.
+type DutchAuction struct {
+	Calculator        common.Address // Address of the DutchAuctionCalculator deployment of the chain
+	StartTime         int64          // Unix timestamp in seconds
+	EndTime           int64          // Unix timestamp in seconds
+	TakingAmountStart *big.Int
+	TakingAmountEnd   *big.Int
+}
+
+// Validate checks the auction has a calculator, a time window and positive amounts
+func (a DutchAuction) Validate() error {
+	if a.Calculator == (common.Address{}) {
+		return errors.New("calculator is required")
+	}
+	if a.StartTime <= 0 || a.EndTime <= a.StartTime {
+		return errors.New("start time must be greater than 0 and before the end time")
+	}
+	if a.TakingAmountStart == nil || a.TakingAmountStart.Sign() <= 0 {
+		return errors.New("taking amount start must be greater than 0")
+	}
+	if a.TakingAmountEnd == nil || a.TakingAmountEnd.Sign() <= 0 {
+		return errors.New("taking amount end must be greater than 0")
+	}
+	return nil
+}
+
+func (a DutchAuction) Getters(orderMakingAmount *big.Int) ([]byte, []byte, error) {
+	if err := a.Validate(); err != nil {
+		return nil, nil, err
+	}
+	if err := checkOrderMakingAmount(orderMakingAmount); err != nil {
+		return nil, nil, err
+	}
+
+	startTimeEndTime := new(big.Int).Lsh(big.NewInt(a.StartTime), 128)
+	startTimeEndTime.Or(startTimeEndTime, big.NewInt(a.EndTime))
+
+	// The router appends the requested amount to the arguments of both getters
+	getMakingAmount, err := packAmountGetter(abis.DutchAuctionCalculator, a.Calculator, "getMakingAmount", 1, startTimeEndTime, a.TakingAmountStart, a.TakingAmountEnd, orderMakingAmount)
+	if err != nil {
+		return nil, nil, err
+	}
+	getTakingAmount, err := packAmountGetter(abis.DutchAuctionCalculator, a.Calculator, "getTakingAmount", 1, startTimeEndTime, a.TakingAmountStart, a.TakingAmountEnd, orderMakingAmount)
+	if err != nil {
+		return nil, nil, err
+	}
+	return getMakingAmount, getTakingAmount, nil
+}
+
+// TakingAmountAt returns the taking amount of the whole order at the given time
+func (a DutchAuction) TakingAmountAt(at time.Time) *big.Int {
+	current := at.Unix()
+	if current < a.StartTime {
+		current = a.StartTime
+	}
+	if current > a.EndTime {
+		current = a.EndTime
+	}
+
+	start := new(big.Int).Mul(a.TakingAmountStart, big.NewInt(a.EndTime-current))
+	end := new(big.Int).Mul(a.TakingAmountEnd, big.NewInt(current-a.StartTime))
+	return start.Add(start, end).Div(start, big.NewInt(a.EndTime-a.StartTime))
+}
+
+func (a DutchAuction) TakingAmount(orderMakingAmount, makingAmount, remainingMakingAmount *big.Int, at time.Time) (*big.Int, error) {
+	if err := a.Validate(); err != nil {
+		return nil, err
+	}
+	if err := checkFillAmounts(orderMakingAmount, makingAmount, remainingMakingAmount); err != nil {
+		return nil, err
+	}
+
+	// Rounded up like the calculator, so the maker never receives less than the auction price
+	takingAmount := new(big.Int).Mul(makingAmount, a.TakingAmountAt(at))
+	takingAmount.Add(takingAmount, orderMakingAmount).Sub(takingAmount, big.NewInt(1))
+	return takingAmount.Div(takingAmount, orderMakingAmount), nil
+}
+
+func (a DutchAuction) MakingAmount(orderMakingAmount, takingAmount, remainingMakingAmount *big.Int, at time.Time) (*big.Int, error) {
+	if err := a.Validate(); err != nil {
+		return nil, err
+	}
+	if err := checkOrderMakingAmount(orderMakingAmount); err != nil {
+		return nil, err
+	}
+	if takingAmount == nil || takingAmount.Sign() < 0 {
+		return nil, errors.New("taking amount must be 0 or greater")
+	}
+
+	makingAmount := new(big.Int).Mul(takingAmount, orderMakingAmount)
+	return makingAmount.Div(makingAmount, a.TakingAmountAt(at)), nil
+}
+
+// RangePricing prices an order with a RangeAmountCalculator. The price moves linearly from PriceStart for the first
+// unit sold to PriceEnd for the last one as the order gets filled, so every fill pays the average price of the part of
+// the range it covers. Prices are in taker asset base units for 1e18 base units of the maker asset.
+// The taking amount of the order itself should be the one returned by FullTakingAmount.
+type RangePricing struct {
+	Calculator common.Address // Address of the RangeAmountCalculator deployment of the chain
+	PriceStart *big.Int
+	PriceEnd   *big.Int
+}
+
+// Validate checks the range has a calculator and prices that do not decrease
+func (r RangePricing) Validate() error {
+	if r.Calculator == (common.Address{}) {
+		return errors.New("calculator is required")
+	}
+	if r.PriceStart == nil || r.PriceStart.Sign() <= 0 {
+		return errors.New("price start must be greater than 0")
+	}
+	if r.PriceEnd == nil || r.PriceEnd.Cmp(r.PriceStart) < 0 {
+		return errors.New("price end cannot be lower than price start")
+	}
+	return nil
+}
+
+func (r RangePricing) Getters(orderMakingAmount *big.Int) ([]byte, []byte, error) {
+	if err := r.Validate(); err != nil {
+		return nil, nil, err
+	}
+	if err := checkOrderMakingAmount(orderMakingAmount); err != nil {
+		return nil, nil, err
+	}
+
+	// The router appends the requested amount and the remaining making amount to the arguments of both getters
+	getMakingAmount, err := packAmountGetter(abis.RangeAmountCalculator, r.Calculator, "getRangeMakerAmount", 2, r.PriceStart, r.PriceEnd, orderMakingAmount)
+	if err != nil {
+		return nil, nil, err
+	}
+	getTakingAmount, err := packAmountGetter(abis.RangeAmountCalculator, r.Calculator, "getRangeTakerAmount", 2, r.PriceStart, r.PriceEnd, orderMakingAmount)
+	if err != nil {
+		return nil, nil, err
+	}
+	return getMakingAmount, getTakingAmount, nil
+}
+
+// FullTakingAmount returns what filling the whole order pays
+func (r RangePricing) FullTakingAmount(orderMakingAmount *big.Int) (*big.Int, error) {
+	return r.TakingAmount(orderMakingAmount, orderMakingAmount, orderMakingAmount, time.Time{})
+}
+
+// TakingAmount ignores the time, the price of a range order only depends on how much of it has been filled
+func (r RangePricing) TakingAmount(orderMakingAmount, makingAmount, remainingMakingAmount *big.Int, _ time.Time) (*big.Int, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	if err := checkFillAmounts(orderMakingAmount, makingAmount, remainingMakingAmount); err != nil {
+		return nil, err
+	}
+
+	// ((priceEnd - priceStart) * (2 * filled + makingAmount) / orderMakingAmount + 2 * priceStart) * makingAmount / 2e18
+	filled := new(big.Int).Sub(orderMakingAmount, remainingMakingAmount)
+	takingAmount := new(big.Int).Lsh(filled, 1)
+	takingAmount.Add(takingAmount, makingAmount)
+	takingAmount.Mul(takingAmount, new(big.Int).Sub(r.PriceEnd, r.PriceStart))
+	takingAmount.Div(takingAmount, orderMakingAmount)
+	takingAmount.Add(takingAmount, new(big.Int).Lsh(r.PriceStart, 1))
+	takingAmount.Mul(takingAmount, makingAmount)
+	return takingAmount.Div(takingAmount, new(big.Int).Lsh(rangePriceScale, 1)), nil
+}
+
+// MakingAmount ignores the time and inverts the price curve of TakingAmount. The calculator rounds its square root
+// differently, so the making amount it returns can be a few base units off.
+func (r RangePricing) MakingAmount(orderMakingAmount, takingAmount, remainingMakingAmount *big.Int, _ time.Time) (*big.Int, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	if err := checkFillAmounts(orderMakingAmount, big.NewInt(0), remainingMakingAmount); err != nil {
+		return nil, err
+	}
+	if takingAmount == nil || takingAmount.Sign() < 0 {
+		return nil, errors.New("taking amount must be 0 or greater")
+	}
+
+	// Solves slope * m^2 + b * m - c = 0 for the making amount m, where
+	// slope = priceEnd - priceStart, b = 2 * (priceStart * orderMakingAmount + slope * filled)
+	// and c = 2 * takingAmount * 1e18 * orderMakingAmount
+	filled := new(big.Int).Sub(orderMakingAmount, remainingMakingAmount)
+	slope := new(big.Int).Sub(r.PriceEnd, r.PriceStart)
+	b := new(big.Int).Mul(r.PriceStart, orderMakingAmount)
+	b.Add(b, new(big.Int).Mul(slope, filled)).Lsh(b, 1)
+	c := new(big.Int).Mul(takingAmount, rangePriceScale)
+	c.Mul(c, orderMakingAmount).Lsh(c, 1)
+
+	if slope.Sign() == 0 {
+		return c.Div(c, b), nil
+	}
+
+	discriminant := new(big.Int).Mul(b, b)
+	discriminant.Add(discriminant, new(big.Int).Lsh(new(big.Int).Mul(slope, c), 2))
+	makingAmount := new(big.Int).Sqrt(discriminant)
+	makingAmount.Sub(makingAmount, b)
+	return makingAmount.Div(makingAmount, new(big.Int).Lsh(slope, 1)), nil
+}
+
+// packAmountGetter returns the calculator address followed by the calldata of the method without its last arguments,
+// which the router appends itself when it calls the getter
+func packAmountGetter(contractAbi string, calculator common.Address, method string, appendedArguments int, args ...interface{}) ([]byte, error) {
+	parsedABI, err := abi.JSON(strings.NewReader(contractAbi))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ABI: %v", err)
+	}
+
+	for i := 0; i < appendedArguments; i++ {
+		args = append(args, big.NewInt(0))
+	}
+	data, err := parsedABI.Pack(method, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack data: %v", err)
+	}
+
+	getter := append(calculator.Bytes(), data[:len(data)-32*appendedArguments]...)
+	return getter, nil
+}
+
+func checkOrderMakingAmount(orderMakingAmount *big.Int) error {
+	if orderMakingAmount == nil || orderMakingAmount.Sign() <= 0 {
+		return errors.New("order making amount must be greater than 0")
+	}
+	return nil
+}
+
+func checkFillAmounts(orderMakingAmount, makingAmount, remainingMakingAmount *big.Int) error {
+	if err := checkOrderMakingAmount(orderMakingAmount); err != nil {
+		return err
+	}
+	if remainingMakingAmount == nil || remainingMakingAmount.Sign() < 0 || remainingMakingAmount.Cmp(orderMakingAmount) > 0 {
+		return errors.New("remaining making amount must be between 0 and the order making amount")
+	}
+	if makingAmount == nil || makingAmount.Sign() < 0 || makingAmount.Cmp(remainingMakingAmount) > 0 {
+		return errors.New("making amount must be between 0 and the remaining making amount")
+	}
+	return nil
+}
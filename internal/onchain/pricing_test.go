@@ -0,0 +1,239 @@
+package onchain
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/1inch/1inch-sdk-go/helpers/consts/abis"
+)
+
+var (
+	testCalculator = common.HexToAddress("0x3333333333333333333333333333333333333333")
+	testAuction    = DutchAuction{
+		Calculator:        testCalculator,
+		StartTime:         1700000000,
+		EndTime:           1700003600,
+		TakingAmountStart: big.NewInt(2000e6),
+		TakingAmountEnd:   big.NewInt(1600e6),
+	}
+	testRange = RangePricing{
+		Calculator: testCalculator,
+		PriceStart: big.NewInt(1500e6),
+		PriceEnd:   big.NewInt(2500e6),
+	}
+)
+
+func TestDynamicPricingGetters(t *testing.T) {
+	orderMakingAmount := big.NewInt(1e18)
+	startTimeEndTime := new(big.Int).Or(new(big.Int).Lsh(big.NewInt(1700000000), 128), big.NewInt(1700003600))
+
+	testcases := []struct {
+		description          string
+		pricing              DynamicPricing
+		abi                  string
+		appendedArguments    int
+		expectedMakingMethod string
+		expectedTakingMethod string
+		expectedArgs         []interface{}
+		expectedErrorMsg     string
+	}{
+		{
+			description:          "Dutch auction",
+			pricing:              testAuction,
+			abi:                  abis.DutchAuctionCalculator,
+			appendedArguments:    1,
+			expectedMakingMethod: "getMakingAmount",
+			expectedTakingMethod: "getTakingAmount",
+			expectedArgs:         []interface{}{startTimeEndTime, big.NewInt(2000e6), big.NewInt(1600e6), orderMakingAmount},
+		},
+		{
+			description:          "Range",
+			pricing:              testRange,
+			abi:                  abis.RangeAmountCalculator,
+			appendedArguments:    2,
+			expectedMakingMethod: "getRangeMakerAmount",
+			expectedTakingMethod: "getRangeTakerAmount",
+			expectedArgs:         []interface{}{big.NewInt(1500e6), big.NewInt(2500e6), orderMakingAmount},
+		},
+		{
+			description:      "Error - auction without a calculator",
+			pricing:          DutchAuction{StartTime: 1, EndTime: 2, TakingAmountStart: big.NewInt(1), TakingAmountEnd: big.NewInt(1)},
+			expectedErrorMsg: "calculator is required",
+		},
+		{
+			description:      "Error - auction ending before it starts",
+			pricing:          DutchAuction{Calculator: testCalculator, StartTime: 2, EndTime: 2, TakingAmountStart: big.NewInt(1), TakingAmountEnd: big.NewInt(1)},
+			expectedErrorMsg: "start time must be greater than 0 and before the end time",
+		},
+		{
+			description:      "Error - auction without an end amount",
+			pricing:          DutchAuction{Calculator: testCalculator, StartTime: 1, EndTime: 2, TakingAmountStart: big.NewInt(1)},
+			expectedErrorMsg: "taking amount end must be greater than 0",
+		},
+		{
+			description:      "Error - decreasing range",
+			pricing:          RangePricing{Calculator: testCalculator, PriceStart: big.NewInt(2), PriceEnd: big.NewInt(1)},
+			expectedErrorMsg: "price end cannot be lower than price start",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.description, func(t *testing.T) {
+			getMakingAmount, getTakingAmount, err := tc.pricing.Getters(orderMakingAmount)
+			if tc.expectedErrorMsg != "" {
+				require.EqualError(t, err, tc.expectedErrorMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			parsedABI, err := abi.JSON(strings.NewReader(tc.abi))
+			require.NoError(t, err)
+
+			for method, getter := range map[string][]byte{tc.expectedMakingMethod: getMakingAmount, tc.expectedTakingMethod: getTakingAmount} {
+				require.Equal(t, testCalculator.Bytes(), getter[:20])
+
+				// Append the arguments the router adds when it calls the getter
+				calldata := append(getter[20:], make([]byte, 32*tc.appendedArguments)...)
+				decodedMethod, err := parsedABI.MethodById(calldata[:4])
+				require.NoError(t, err)
+				require.Equal(t, method, decodedMethod.Name)
+
+				args, err := decodedMethod.Inputs.Unpack(calldata[4:])
+				require.NoError(t, err)
+				require.Equal(t, fmt.Sprint(tc.expectedArgs), fmt.Sprint(args[:len(args)-tc.appendedArguments]))
+			}
+		})
+	}
+}
+
+func TestDutchAuctionAmounts(t *testing.T) {
+	orderMakingAmount := big.NewInt(1e18)
+
+	testcases := []struct {
+		description          string
+		at                   time.Time
+		makingAmount         *big.Int
+		expectedOrderTaking  *big.Int
+		expectedTakingAmount *big.Int
+		expectedMakingAmount *big.Int
+	}{
+		{
+			description:          "Before the auction starts",
+			at:                   time.Unix(1699990000, 0),
+			makingAmount:         orderMakingAmount,
+			expectedOrderTaking:  big.NewInt(2000e6),
+			expectedTakingAmount: big.NewInt(2000e6),
+			expectedMakingAmount: big.NewInt(1e18),
+		},
+		{
+			description:          "Halfway through the auction",
+			at:                   time.Unix(1700001800, 0),
+			makingAmount:         big.NewInt(5e17),
+			expectedOrderTaking:  big.NewInt(1800e6),
+			expectedTakingAmount: big.NewInt(900e6),
+			expectedMakingAmount: new(big.Int).Div(new(big.Int).Mul(big.NewInt(1e18), big.NewInt(2000)), big.NewInt(1800)),
+		},
+		{
+			description:          "Taking amount is rounded up",
+			at:                   time.Unix(1700001800, 0),
+			makingAmount:         big.NewInt(1),
+			expectedOrderTaking:  big.NewInt(1800e6),
+			expectedTakingAmount: big.NewInt(1),
+			expectedMakingAmount: new(big.Int).Div(new(big.Int).Mul(big.NewInt(1e18), big.NewInt(2000)), big.NewInt(1800)),
+		},
+		{
+			description:          "After the auction ends",
+			at:                   time.Unix(1700010000, 0),
+			makingAmount:         orderMakingAmount,
+			expectedOrderTaking:  big.NewInt(1600e6),
+			expectedTakingAmount: big.NewInt(1600e6),
+			expectedMakingAmount: big.NewInt(125e16),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.description, func(t *testing.T) {
+			require.Equal(t, tc.expectedOrderTaking.String(), testAuction.TakingAmountAt(tc.at).String())
+
+			takingAmount, err := testAuction.TakingAmount(orderMakingAmount, tc.makingAmount, orderMakingAmount, tc.at)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedTakingAmount.String(), takingAmount.String())
+
+			// What 2000 of the taker asset buys at that time
+			makingAmount, err := testAuction.MakingAmount(orderMakingAmount, big.NewInt(2000e6), orderMakingAmount, tc.at)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedMakingAmount.String(), makingAmount.String())
+		})
+	}
+}
+
+func TestRangePricingAmounts(t *testing.T) {
+	orderMakingAmount := big.NewInt(1e18)
+	half := big.NewInt(5e17)
+
+	testcases := []struct {
+		description           string
+		makingAmount          *big.Int
+		remainingMakingAmount *big.Int
+		expectedTakingAmount  *big.Int
+		expectedErrorMsg      string
+	}{
+		{
+			description:           "Whole order at the average price",
+			makingAmount:          orderMakingAmount,
+			remainingMakingAmount: orderMakingAmount,
+			expectedTakingAmount:  big.NewInt(2000e6),
+		},
+		{
+			description:           "First half of the order",
+			makingAmount:          half,
+			remainingMakingAmount: orderMakingAmount,
+			expectedTakingAmount:  big.NewInt(875e6),
+		},
+		{
+			description:           "Second half of the order",
+			makingAmount:          half,
+			remainingMakingAmount: half,
+			expectedTakingAmount:  big.NewInt(1125e6),
+		},
+		{
+			description:           "Error - fill larger than what remains",
+			makingAmount:          orderMakingAmount,
+			remainingMakingAmount: half,
+			expectedErrorMsg:      "making amount must be between 0 and the remaining making amount",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.description, func(t *testing.T) {
+			takingAmount, err := testRange.TakingAmount(orderMakingAmount, tc.makingAmount, tc.remainingMakingAmount, time.Now())
+			if tc.expectedErrorMsg != "" {
+				require.EqualError(t, err, tc.expectedErrorMsg)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedTakingAmount.String(), takingAmount.String())
+
+			// Inverting the taking amount gives back the making amount
+			makingAmount, err := testRange.MakingAmount(orderMakingAmount, takingAmount, tc.remainingMakingAmount, time.Now())
+			require.NoError(t, err)
+			require.Equal(t, tc.makingAmount.String(), makingAmount.String())
+		})
+	}
+
+	fullTakingAmount, err := testRange.FullTakingAmount(orderMakingAmount)
+	require.NoError(t, err)
+	require.Equal(t, "2000000000", fullTakingAmount.String())
+
+	flat := RangePricing{Calculator: testCalculator, PriceStart: big.NewInt(1500e6), PriceEnd: big.NewInt(1500e6)}
+	makingAmount, err := flat.MakingAmount(orderMakingAmount, big.NewInt(750e6), orderMakingAmount, time.Now())
+	require.NoError(t, err)
+	require.Equal(t, half.String(), makingAmount.String())
+}
@@ -0,0 +1,94 @@
+package onchain
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/stretchr/testify/require"
+
+	"github.com/1inch/1inch-sdk-go/helpers/consts/abis"
+)
+
+// testKey is a throwaway private key used to sign test transactions
+const testKey = "ad21c0552a3b52e94520da713455cc347e4e89628a334be24d85b8083848434f"
+
+var errExecutionReverted = errors.New("execution reverted")
+
+type rpcRequest struct {
+	Id     json.RawMessage   `json:"id"`
+	Method string            `json:"method"`
+	Params []json.RawMessage `json:"params"`
+}
+
+type rpcCallArgs struct {
+	To    string        `json:"to"`
+	Data  hexutil.Bytes `json:"data"`
+	Input hexutil.Bytes `json:"input"`
+}
+
+// rpcHandler returns the result of a JSON-RPC method, a returned error is sent back as a JSON-RPC error
+type rpcHandler func(params []json.RawMessage) (interface{}, error)
+
+// setupRpc starts a test JSON-RPC server and returns an eth client connected to it.
+// Methods without a handler return a JSON-RPC error, just like a node would for a reverting call.
+func setupRpc(t *testing.T, handlers map[string]rpcHandler) *ethclient.Client {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request rpcRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+
+		response := map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      request.Id,
+		}
+		handler, ok := handlers[request.Method]
+		if !ok {
+			response["error"] = map[string]interface{}{"code": -32601, "message": "method not found"}
+		} else if result, err := handler(request.Params); err != nil {
+			response["error"] = map[string]interface{}{"code": 3, "message": err.Error()}
+		} else {
+			response["result"] = result
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(response))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := ethclient.Dial(server.URL)
+	require.NoError(t, err)
+	t.Cleanup(client.Close)
+	return client
+}
+
+// erc20CallHandler answers eth_call requests with raw return data keyed by ERC20 ABI method name
+// Methods missing from the map revert
+func erc20CallHandler(t *testing.T, results map[string][]byte) rpcHandler {
+	parsedABI, err := abi.JSON(strings.NewReader(abis.Erc20))
+	require.NoError(t, err)
+
+	return func(params []json.RawMessage) (interface{}, error) {
+		var args rpcCallArgs
+		require.NoError(t, json.Unmarshal(params[0], &args))
+		data := args.Input
+		if len(data) == 0 {
+			data = args.Data
+		}
+
+		method, err := parsedABI.MethodById(data)
+		if err != nil {
+			return nil, err
+		}
+		result, ok := results[method.Name]
+		if !ok {
+			return nil, errExecutionReverted
+		}
+		return hexutil.Bytes(result), nil
+	}
+}
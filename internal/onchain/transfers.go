@@ -0,0 +1,58 @@
+package onchain
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+var transferEventTopic = crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+
+// ReadTransferredAmount returns the total amount of an ERC20 token sent to an account by the Transfer logs of a receipt
+func ReadTransferredAmount(receipt *types.Receipt, token common.Address, to common.Address) *big.Int {
+	amount := big.NewInt(0)
+	for _, log := range receipt.Logs {
+		if log.Address != token || len(log.Topics) != 3 || log.Topics[0] != transferEventTopic {
+			continue
+		}
+		if common.BytesToAddress(log.Topics[2].Bytes()) != to {
+			continue
+		}
+		amount.Add(amount, new(big.Int).SetBytes(log.Data))
+	}
+	return amount
+}
+
+// ReadNativeBalanceDelta returns how much the native balance of an account changed in the block of a receipt
+// The gas paid for the transaction is added back when the account sent it, so only the value it received is left
+// Other transactions of the same block touching the balance of the account are included in the delta
+func ReadNativeBalanceDelta(ctx context.Context, client *ethclient.Client, receipt *types.Receipt, account common.Address, sender common.Address) (*big.Int, error) {
+	balanceAfter, err := client.BalanceAt(ctx, account, receipt.BlockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read balance after the transaction: %v", err)
+	}
+
+	balanceBefore, err := client.BalanceAt(ctx, account, new(big.Int).Sub(receipt.BlockNumber, big.NewInt(1)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read balance before the transaction: %v", err)
+	}
+
+	delta := new(big.Int).Sub(balanceAfter, balanceBefore)
+	if account == sender {
+		delta.Add(delta, GetGasCost(receipt))
+	}
+	return delta, nil
+}
+
+// GetGasCost returns the amount of native tokens paid for the gas of a transaction
+func GetGasCost(receipt *types.Receipt) *big.Int {
+	if receipt.EffectiveGasPrice == nil {
+		return big.NewInt(0)
+	}
+	return new(big.Int).Mul(receipt.EffectiveGasPrice, new(big.Int).SetUint64(receipt.GasUsed))
+}
@@ -0,0 +1,77 @@
+package onchain
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func transferLog(token common.Address, from common.Address, to common.Address, amount int64) *types.Log {
+	return &types.Log{
+		Address: token,
+		Topics:  []common.Hash{transferEventTopic, common.BytesToHash(from.Bytes()), common.BytesToHash(to.Bytes())},
+		Data:    common.LeftPadBytes(big.NewInt(amount).Bytes(), 32),
+	}
+}
+
+func TestReadTransferredAmount(t *testing.T) {
+	token := common.HexToAddress("0x6b175474e89094c44da98b954eedeac495271d0f")
+	otherToken := common.HexToAddress("0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48")
+	router := common.HexToAddress("0x1111111254eeb25477b68fb85ed929f73a960582")
+	receiver := common.HexToAddress("0x2a5f0c2c4d3c38e0b0d2b3f8b0d4d8a0f1e6c9b7")
+
+	receipt := &types.Receipt{
+		Logs: []*types.Log{
+			transferLog(otherToken, receiver, router, 1000),
+			transferLog(token, router, receiver, 700),
+			transferLog(token, router, router, 50),
+			transferLog(token, router, receiver, 300),
+			// Approval events share the layout of transfers but not their topic
+			{Address: token, Topics: []common.Hash{common.HexToHash("0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925"), common.BytesToHash(router.Bytes()), common.BytesToHash(receiver.Bytes())}, Data: common.LeftPadBytes([]byte{1}, 32)},
+		},
+	}
+
+	require.Equal(t, big.NewInt(1000), ReadTransferredAmount(receipt, token, receiver))
+	require.Equal(t, big.NewInt(0), ReadTransferredAmount(receipt, otherToken, receiver))
+}
+
+func TestReadNativeBalanceDelta(t *testing.T) {
+	sender := common.HexToAddress("0x2a5f0c2c4d3c38e0b0d2b3f8b0d4d8a0f1e6c9b7")
+	receiver := common.HexToAddress("0x1111111254eeb25477b68fb85ed929f73a960582")
+
+	// The sender pays 21000 gas at 10 wei and both accounts end the block 5000 wei richer
+	balances := map[common.Address]map[string]int64{
+		sender:   {"0x9": 1000000, "0xa": 1000000 + 5000 - 210000},
+		receiver: {"0x9": 1000000, "0xa": 1000000 + 5000},
+	}
+	client := setupRpc(t, map[string]rpcHandler{
+		"eth_getBalance": func(params []json.RawMessage) (interface{}, error) {
+			var account common.Address
+			var block string
+			require.NoError(t, json.Unmarshal(params[0], &account))
+			require.NoError(t, json.Unmarshal(params[1], &block))
+			return (*hexutil.Big)(big.NewInt(balances[account][block])), nil
+		},
+	})
+	receipt := &types.Receipt{
+		BlockNumber:       big.NewInt(10),
+		GasUsed:           21000,
+		EffectiveGasPrice: big.NewInt(10),
+	}
+
+	delta, err := ReadNativeBalanceDelta(context.Background(), client, receipt, receiver, sender)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(5000), delta)
+
+	delta, err = ReadNativeBalanceDelta(context.Background(), client, receipt, sender, sender)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(5000), delta)
+
+	require.Equal(t, big.NewInt(210000), GetGasCost(receipt))
+}
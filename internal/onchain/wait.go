@@ -0,0 +1,210 @@
+package onchain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	"github.com/1inch/1inch-sdk-go/internal/web3"
+)
+
+const (
+	receiptPollInterval     = time.Second
+	receiptBackstopInterval = 15 * time.Second
+
+	// A transaction has to be missing for this many checks in a row before it is reported as dropped or replaced,
+	// which gives load balanced providers time to agree with each other
+	missingChecksBeforeFailure = 3
+)
+
+var (
+	ErrTransactionDropped  = errors.New("transaction was dropped before being mined")
+	ErrTransactionReplaced = errors.New("transaction was replaced by another transaction with the same nonce")
+	ErrTransactionReverted = errors.New("transaction reverted")
+)
+
+// WaitOptions controls when a transaction is considered final
+type WaitOptions struct {
+	// Confirmations is the number of blocks, counting the one including the transaction, to wait for (defaults to 1)
+	Confirmations uint64
+	// Timeout of the whole wait, there is none when zero
+	Timeout time.Duration
+	// PollInterval between two checks when no head watcher is available (defaults to 1 second)
+	PollInterval time.Duration
+}
+
+// WaitForTransaction waits until a transaction has the requested number of confirmations and returns its receipt
+// The block of the receipt must still be canonical once confirmed, a transaction reorged out is waited on again
+// ErrTransactionDropped, ErrTransactionReplaced and ErrTransactionReverted are wrapped in the errors of failed transactions
+// With a head watcher the checks run once per new block, otherwise they run every poll interval
+func WaitForTransaction(ctx context.Context, client *ethclient.Client, tx *types.Transaction, from common.Address, headWatcher *web3.HeadWatcher, options WaitOptions) (*types.Receipt, error) {
+	return WaitForSubmission(ctx, client, tx, from, headWatcher, options, Submission{})
+}
+
+// WaitForSubmission is WaitForTransaction for a transaction sent by a Broadcaster
+// A private transaction is unknown to public nodes until it is mined, so it is only reported as dropped once the last block
+// of the submission has passed
+func WaitForSubmission(ctx context.Context, client *ethclient.Client, tx *types.Transaction, from common.Address, headWatcher *web3.HeadWatcher, options WaitOptions, submission Submission) (*types.Receipt, error) {
+	if options.Confirmations == 0 {
+		options.Confirmations = 1
+	}
+	if options.PollInterval == 0 {
+		options.PollInterval = receiptPollInterval
+	}
+	if options.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, options.Timeout)
+		defer cancel()
+	}
+
+	var newHeads <-chan *types.Header
+	delay := options.PollInterval
+	if headWatcher != nil {
+		subscription := headWatcher.Subscribe()
+		defer subscription.Unsubscribe()
+		newHeads = subscription.Headers()
+		// The timer is only a backstop against a stalled subscription
+		delay = receiptBackstopInterval
+	}
+
+	var missingChecks int
+	for {
+		receipt, err := client.TransactionReceipt(ctx, tx.Hash())
+		switch {
+		case receipt != nil:
+			missingChecks = 0
+			final, err := isReceiptFinal(ctx, client, receipt, options.Confirmations)
+			if err != nil {
+				fmt.Printf("Failed to check confirmations: %v\n", err)
+				break
+			}
+			if !final {
+				break
+			}
+			if receipt.Status == types.ReceiptStatusFailed {
+				return receipt, fmt.Errorf("%w: %s in block %d", ErrTransactionReverted, tx.Hash().Hex(), receipt.BlockNumber)
+			}
+			fmt.Println("Transaction complete!")
+			return receipt, nil
+		case errors.Is(err, ethereum.NotFound):
+			state, err := getMissingTransactionState(ctx, client, tx, from, submission)
+			if err != nil {
+				fmt.Printf("Failed to check transaction status: %v\n", err)
+				break
+			}
+			if state == transactionPending {
+				missingChecks = 0
+				fmt.Println("Waiting for transaction to be mined")
+				break
+			}
+			missingChecks++
+			if missingChecks < missingChecksBeforeFailure {
+				break
+			}
+			if state == transactionReplaced {
+				return nil, fmt.Errorf("%w: %s", ErrTransactionReplaced, tx.Hash().Hex())
+			}
+			return nil, fmt.Errorf("%w: %s", ErrTransactionDropped, tx.Hash().Hex())
+		case err != nil:
+			fmt.Printf("Failed to get transaction receipt: %v\n", err)
+		}
+
+		select {
+		case _, ok := <-newHeads:
+			// A closed head watcher ends the subscription, the checks go back to running every poll interval
+			if !ok {
+				newHeads = nil
+				delay = options.PollInterval
+			}
+		case <-time.After(delay):
+		case <-ctx.Done():
+			fmt.Println("Context cancelled")
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// isReceiptFinal returns true once the block of the receipt has enough confirmations and is still part of the canonical chain
+func isReceiptFinal(ctx context.Context, client *ethclient.Client, receipt *types.Receipt, confirmations uint64) (bool, error) {
+	latestBlock, err := client.BlockNumber(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to get latest block: %v", err)
+	}
+	receiptBlock := receipt.BlockNumber.Uint64()
+	if latestBlock+1 < receiptBlock+confirmations {
+		fmt.Printf("Waiting for confirmations (%d/%d)\n", confirmationsOf(latestBlock, receiptBlock), confirmations)
+		return false, nil
+	}
+
+	header, err := client.HeaderByNumber(ctx, receipt.BlockNumber)
+	if err != nil {
+		return false, fmt.Errorf("failed to get block %d: %v", receiptBlock, err)
+	}
+	if header.Hash() != receipt.BlockHash {
+		fmt.Printf("Transaction was reorged out of block %d, waiting for it to be mined again\n", receiptBlock)
+		return false, nil
+	}
+	return true, nil
+}
+
+func confirmationsOf(latestBlock uint64, receiptBlock uint64) uint64 {
+	if latestBlock < receiptBlock {
+		return 0
+	}
+	return latestBlock - receiptBlock + 1
+}
+
+type missingTransactionState int
+
+const (
+	transactionPending missingTransactionState = iota
+	transactionDropped
+	transactionReplaced
+)
+
+// getMissingTransactionState explains why a transaction has no receipt
+// A transaction whose nonce was used by another one the node does not know about was replaced, one the node no longer knows about was dropped
+// A node whose receipt index lags its state has already moved the nonce past a mined transaction, which it still knows about
+// A private transaction is dropped once the relay can no longer include it
+func getMissingTransactionState(ctx context.Context, client *ethclient.Client, tx *types.Transaction, from common.Address, submission Submission) (missingTransactionState, error) {
+	nonce, err := client.NonceAt(ctx, from, nil)
+	if err != nil {
+		return transactionPending, fmt.Errorf("failed to get nonce: %v", err)
+	}
+	if nonce > tx.Nonce() {
+		_, _, err = client.TransactionByHash(ctx, tx.Hash())
+		if errors.Is(err, ethereum.NotFound) {
+			return transactionReplaced, nil
+		}
+		if err != nil {
+			return transactionPending, fmt.Errorf("failed to get transaction: %v", err)
+		}
+		return transactionPending, nil
+	}
+
+	if submission.Private {
+		latestBlock, err := client.BlockNumber(ctx)
+		if err != nil {
+			return transactionPending, fmt.Errorf("failed to get latest block: %v", err)
+		}
+		if latestBlock > submission.LastBlock {
+			return transactionDropped, nil
+		}
+		return transactionPending, nil
+	}
+
+	_, _, err = client.TransactionByHash(ctx, tx.Hash())
+	if errors.Is(err, ethereum.NotFound) {
+		return transactionDropped, nil
+	}
+	if err != nil {
+		return transactionPending, fmt.Errorf("failed to get transaction: %v", err)
+	}
+	return transactionPending, nil
+}
@@ -0,0 +1,292 @@
+package onchain
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/1inch/1inch-sdk-go/internal/web3"
+)
+
+func testHeader(number int64) *types.Header {
+	return &types.Header{Number: big.NewInt(number), Difficulty: big.NewInt(0)}
+}
+
+func testReceipt(blockNumber int64, blockHash common.Hash, status uint64) *types.Receipt {
+	return &types.Receipt{
+		Status:      status,
+		BlockNumber: big.NewInt(blockNumber),
+		BlockHash:   blockHash,
+		Logs:        []*types.Log{},
+	}
+}
+
+func TestWaitForTransaction(t *testing.T) {
+	privateKey, err := crypto.HexToECDSA(testKey)
+	require.NoError(t, err)
+	from := crypto.PubkeyToAddress(privateKey.PublicKey)
+	tx, err := types.SignTx(types.NewTx(&types.DynamicFeeTx{
+		ChainID:   big.NewInt(1),
+		Nonce:     7,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(1),
+		Gas:       21000,
+		To:        &from,
+		Value:     big.NewInt(0),
+	}), types.LatestSignerForChainID(big.NewInt(1)), privateKey)
+	require.NoError(t, err)
+
+	canonicalReceipt := func(blockNumber int64, status uint64) *types.Receipt {
+		return testReceipt(blockNumber, testHeader(blockNumber).Hash(), status)
+	}
+
+	testcases := []struct {
+		description         string
+		options             WaitOptions
+		submission          Submission
+		useHeadWatcher      bool
+		receipt             func(latestBlock int64) *types.Receipt
+		accountNonce        uint64
+		txKnown             bool
+		expectedBlockNumber int64
+		expectedMinLatest   int64
+		expectedError       error
+	}{
+		{
+			description:         "Receipt already final",
+			receipt:             func(int64) *types.Receipt { return canonicalReceipt(3, types.ReceiptStatusSuccessful) },
+			expectedBlockNumber: 3,
+		},
+		{
+			description:    "Receipt checked on new heads",
+			useHeadWatcher: true,
+			receipt: func(latestBlock int64) *types.Receipt {
+				if latestBlock < 4 {
+					return nil
+				}
+				return canonicalReceipt(4, types.ReceiptStatusSuccessful)
+			},
+			accountNonce:        7,
+			txKnown:             true,
+			expectedBlockNumber: 4,
+		},
+		{
+			description:         "Waits for confirmations",
+			options:             WaitOptions{Confirmations: 5},
+			receipt:             func(int64) *types.Receipt { return canonicalReceipt(3, types.ReceiptStatusSuccessful) },
+			expectedBlockNumber: 3,
+			expectedMinLatest:   7,
+		},
+		{
+			description: "Waits again after a reorg",
+			options:     WaitOptions{Confirmations: 2},
+			receipt: func(latestBlock int64) *types.Receipt {
+				// The first inclusion in block 3 is orphaned, the transaction is then mined again in block 6
+				if latestBlock < 6 {
+					return testReceipt(3, common.HexToHash("0xdead"), types.ReceiptStatusSuccessful)
+				}
+				return canonicalReceipt(6, types.ReceiptStatusSuccessful)
+			},
+			expectedBlockNumber: 6,
+			expectedMinLatest:   7,
+		},
+		{
+			description:   "Error - transaction reverted",
+			receipt:       func(int64) *types.Receipt { return canonicalReceipt(3, types.ReceiptStatusFailed) },
+			expectedError: ErrTransactionReverted,
+		},
+		{
+			description:   "Error - transaction dropped",
+			receipt:       func(int64) *types.Receipt { return nil },
+			accountNonce:  7,
+			txKnown:       false,
+			expectedError: ErrTransactionDropped,
+		},
+		{
+			description:   "Error - transaction replaced",
+			receipt:       func(int64) *types.Receipt { return nil },
+			accountNonce:  8,
+			txKnown:       false,
+			expectedError: ErrTransactionReplaced,
+		},
+		{
+			description:    "Mined transaction whose receipt lags the account nonce",
+			useHeadWatcher: true,
+			receipt: func(latestBlock int64) *types.Receipt {
+				if latestBlock < 10 {
+					return nil
+				}
+				return canonicalReceipt(10, types.ReceiptStatusSuccessful)
+			},
+			accountNonce:        8,
+			txKnown:             true,
+			expectedBlockNumber: 10,
+		},
+		{
+			description: "Private transaction unknown to the node until mined",
+			submission:  Submission{Private: true, LastBlock: 20},
+			receipt: func(latestBlock int64) *types.Receipt {
+				if latestBlock < 8 {
+					return nil
+				}
+				return canonicalReceipt(8, types.ReceiptStatusSuccessful)
+			},
+			accountNonce:        7,
+			txKnown:             false,
+			expectedBlockNumber: 8,
+		},
+		{
+			description:   "Error - private transaction dropped after its last block",
+			submission:    Submission{Private: true, LastBlock: 5},
+			receipt:       func(int64) *types.Receipt { return nil },
+			accountNonce:  7,
+			txKnown:       false,
+			expectedError: ErrTransactionDropped,
+		},
+		{
+			description:   "Error - timeout while pending",
+			options:       WaitOptions{Timeout: 100 * time.Millisecond},
+			receipt:       func(int64) *types.Receipt { return nil },
+			accountNonce:  7,
+			txKnown:       true,
+			expectedError: context.DeadlineExceeded,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.description, func(t *testing.T) {
+			// Every new head request advances the chain by one block
+			var latestBlock atomic.Int64
+			latestBlock.Store(2)
+			client := setupRpc(t, map[string]rpcHandler{
+				"eth_blockNumber": func(params []json.RawMessage) (interface{}, error) {
+					return hexutil.Uint64(latestBlock.Add(1)), nil
+				},
+				"eth_getBlockByNumber": func(params []json.RawMessage) (interface{}, error) {
+					var blockTag string
+					require.NoError(t, json.Unmarshal(params[0], &blockTag))
+					if blockTag == "latest" {
+						return testHeader(latestBlock.Add(1)), nil
+					}
+					number, err := strconv.ParseInt(strings.TrimPrefix(blockTag, "0x"), 16, 64)
+					require.NoError(t, err)
+					return testHeader(number), nil
+				},
+				"eth_getTransactionReceipt": func(params []json.RawMessage) (interface{}, error) {
+					receipt := tc.receipt(latestBlock.Load())
+					if receipt == nil {
+						return nil, nil
+					}
+					receipt.TxHash = tx.Hash()
+					return receipt, nil
+				},
+				"eth_getTransactionCount": func(params []json.RawMessage) (interface{}, error) {
+					return hexutil.Uint64(tc.accountNonce), nil
+				},
+				"eth_getTransactionByHash": func(params []json.RawMessage) (interface{}, error) {
+					if !tc.txKnown {
+						return nil, nil
+					}
+					return tx, nil
+				},
+			})
+
+			var headWatcher *web3.HeadWatcher
+			if tc.useHeadWatcher {
+				headWatcher = web3.NewHeadWatcher(1, nil, client, web3.HeadWatcherConfig{PollInterval: 10 * time.Millisecond})
+				t.Cleanup(headWatcher.Close)
+			}
+			if tc.options.PollInterval == 0 {
+				tc.options.PollInterval = 10 * time.Millisecond
+			}
+
+			receipt, err := WaitForSubmission(context.Background(), client, tx, from, headWatcher, tc.options, tc.submission)
+			if tc.expectedError != nil {
+				require.ErrorIs(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tx.Hash(), receipt.TxHash)
+			require.Equal(t, tc.expectedBlockNumber, receipt.BlockNumber.Int64())
+			require.GreaterOrEqual(t, latestBlock.Load(), tc.expectedMinLatest)
+		})
+	}
+}
+
+func TestWaitForTransactionAfterHeadWatcherClosed(t *testing.T) {
+	privateKey, err := crypto.HexToECDSA(testKey)
+	require.NoError(t, err)
+	from := crypto.PubkeyToAddress(privateKey.PublicKey)
+	tx, err := types.SignTx(types.NewTx(&types.DynamicFeeTx{
+		ChainID:   big.NewInt(1),
+		Nonce:     7,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(1),
+		Gas:       21000,
+		To:        &from,
+		Value:     big.NewInt(0),
+	}), types.LatestSignerForChainID(big.NewInt(1)), privateKey)
+	require.NoError(t, err)
+
+	var latestBlock, receiptRequests atomic.Int64
+	var mined atomic.Bool
+	latestBlock.Store(2)
+	client := setupRpc(t, map[string]rpcHandler{
+		"eth_blockNumber": func(params []json.RawMessage) (interface{}, error) {
+			return hexutil.Uint64(latestBlock.Load()), nil
+		},
+		"eth_getBlockByNumber": func(params []json.RawMessage) (interface{}, error) {
+			var blockTag string
+			require.NoError(t, json.Unmarshal(params[0], &blockTag))
+			if blockTag == "latest" {
+				return testHeader(latestBlock.Add(1)), nil
+			}
+			number, err := strconv.ParseInt(strings.TrimPrefix(blockTag, "0x"), 16, 64)
+			require.NoError(t, err)
+			return testHeader(number), nil
+		},
+		"eth_getTransactionReceipt": func(params []json.RawMessage) (interface{}, error) {
+			receiptRequests.Add(1)
+			if !mined.Load() {
+				return nil, nil
+			}
+			receipt := testReceipt(3, testHeader(3).Hash(), types.ReceiptStatusSuccessful)
+			receipt.TxHash = tx.Hash()
+			return receipt, nil
+		},
+		"eth_getTransactionCount": func(params []json.RawMessage) (interface{}, error) {
+			return hexutil.Uint64(7), nil
+		},
+		"eth_getTransactionByHash": func(params []json.RawMessage) (interface{}, error) {
+			return tx, nil
+		},
+	})
+
+	headWatcher := web3.NewHeadWatcher(1, nil, client, web3.HeadWatcherConfig{PollInterval: 10 * time.Millisecond})
+	var requestsAfterClose int64
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		headWatcher.Close()
+		closedAt := receiptRequests.Load()
+		time.Sleep(100 * time.Millisecond)
+		requestsAfterClose = receiptRequests.Load() - closedAt
+		mined.Store(true)
+	}()
+
+	receipt, err := WaitForTransaction(context.Background(), client, tx, from, headWatcher, WaitOptions{PollInterval: 10 * time.Millisecond, Timeout: 5 * time.Second})
+	require.NoError(t, err)
+	require.Equal(t, tx.Hash(), receipt.TxHash)
+	// Without a subscription the receipt is checked every poll interval rather than in a busy loop
+	require.Less(t, requestsAfterClose, int64(30))
+}
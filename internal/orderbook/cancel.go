@@ -0,0 +1,139 @@
+package orderbook
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/1inch/1inch-sdk-go/client/models"
+	"github.com/1inch/1inch-sdk-go/helpers"
+	"github.com/1inch/1inch-sdk-go/helpers/consts/abis"
+)
+
+// LimitOrderSeries is the series of the nonce checked by the predicate of every limit order posted by the SDK
+const LimitOrderSeries = 0
+
+// routerOrder mirrors the Order struct of AggregationRouterV5 so it can be ABI encoded
+type routerOrder struct {
+	Salt          *big.Int
+	MakerAsset    common.Address
+	TakerAsset    common.Address
+	Maker         common.Address
+	Receiver      common.Address
+	AllowedSender common.Address
+	MakingAmount  *big.Int
+	TakingAmount  *big.Int
+	Offsets       *big.Int
+	Interactions  []byte
+}
+
+func newRouterOrder(order models.OrderData) (routerOrder, error) {
+	salt, err := helpers.BigIntFromString(order.Salt)
+	if err != nil {
+		return routerOrder{}, fmt.Errorf("failed to convert salt to big.Int: %v", err)
+	}
+	makingAmount, err := helpers.BigIntFromString(order.MakingAmount)
+	if err != nil {
+		return routerOrder{}, fmt.Errorf("failed to convert making amount to big.Int: %v", err)
+	}
+	takingAmount, err := helpers.BigIntFromString(order.TakingAmount)
+	if err != nil {
+		return routerOrder{}, fmt.Errorf("failed to convert taking amount to big.Int: %v", err)
+	}
+	offsets, err := helpers.BigIntFromString(order.Offsets)
+	if err != nil {
+		return routerOrder{}, fmt.Errorf("failed to convert offsets to big.Int: %v", err)
+	}
+	return routerOrder{
+		Salt:          salt,
+		MakerAsset:    common.HexToAddress(order.MakerAsset),
+		TakerAsset:    common.HexToAddress(order.TakerAsset),
+		Maker:         common.HexToAddress(order.Maker),
+		Receiver:      common.HexToAddress(order.Receiver),
+		AllowedSender: common.HexToAddress(order.AllowedSender),
+		MakingAmount:  makingAmount,
+		TakingAmount:  takingAmount,
+		Offsets:       offsets,
+		Interactions:  common.FromHex(order.Interactions),
+	}, nil
+}
+
+// GetCancelOrderCalldata returns the calldata of a cancelOrder call on AggregationRouterV5, which only its maker can send
+func GetCancelOrderCalldata(order models.OrderData) ([]byte, error) {
+	parsedABI, err := abi.JSON(strings.NewReader(abis.AggregationRouterV5))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ABI: %v", err)
+	}
+
+	encodedOrder, err := newRouterOrder(order)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := parsedABI.Pack("cancelOrder", encodedOrder)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack data: %v", err)
+	}
+	return data, nil
+}
+
+// GetAdvanceNonceCalldata returns the calldata raising the limit order series nonce of the sender on the series nonce manager
+// by the increment, which invalidates every order whose predicate checks an older nonce
+func GetAdvanceNonceCalldata(increment uint8) ([]byte, error) {
+	if increment == 0 {
+		return nil, fmt.Errorf("nonce increment must be greater than 0")
+	}
+
+	parsedABI, err := abi.JSON(strings.NewReader(abis.SeriesNonceManager))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ABI: %v", err)
+	}
+
+	var data []byte
+	if increment == 1 {
+		data, err = parsedABI.Pack("increaseNonce", uint8(LimitOrderSeries))
+	} else {
+		data, err = parsedABI.Pack("advanceNonce", big.NewInt(LimitOrderSeries), big.NewInt(int64(increment)))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack data: %v", err)
+	}
+	return data, nil
+}
+
+// ConfirmCancelWithUser prints the summary of a cancellation and asks the user to confirm it in the terminal
+func ConfirmCancelWithUser(summary *models.Summary) (bool, error) {
+	stdOut := helpers.StdOutPrinter{}
+	return confirmCancelWithUser(summary, os.Stdin, stdOut)
+}
+
+func confirmCancelWithUser(summary *models.Summary, reader io.Reader, writer helpers.Printer) (bool, error) {
+	writer.Printf("Cancellation summary:\n")
+	writer.Printf("    %-30s %s\n", "Wallet:", summary.Wallet)
+	if summary.Kind == models.SummaryCancelAllOrders {
+		writer.Printf("    %-30s %s\n", "Orders:", "Every open order of the wallet on this chain")
+	} else {
+		writer.Printf("    %-30s %s\n", "Order hash:", summary.OrderHash)
+		writer.Printf("    %-30s %s\n", "Selling: ", summary.Selling)
+		writer.Printf("    %-30s %s\n", "Buying:", summary.Buying)
+	}
+	writer.Printf("\n")
+	writer.Printf("Would you like to send the cancellation transaction now? [y/N]: ")
+
+	inputReader := bufio.NewReader(reader)
+	input, _ := inputReader.ReadString('\n')
+	input = strings.ToLower(strings.TrimSpace(input))
+
+	switch input {
+	case "y":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
@@ -0,0 +1,155 @@
+package orderbook
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/1inch/1inch-sdk-go/client/models"
+	"github.com/1inch/1inch-sdk-go/helpers"
+	"github.com/1inch/1inch-sdk-go/helpers/consts/abis"
+	"github.com/1inch/1inch-sdk-go/helpers/consts/addresses"
+	"github.com/1inch/1inch-sdk-go/helpers/consts/tokens"
+)
+
+var testOrderData = models.OrderData{
+	MakerAsset:    tokens.PolygonWeth,
+	TakerAsset:    tokens.PolygonDai,
+	MakingAmount:  "1000000000000000000",
+	TakingAmount:  "3000000000000000000000",
+	Salt:          "1700000000000",
+	Maker:         "0x1111111111111111111111111111111111111111",
+	AllowedSender: addresses.Zero,
+	Receiver:      addresses.Zero,
+	Offsets:       "4",
+	Interactions:  "0xdeadbeef",
+}
+
+func TestGetCancelOrderCalldata(t *testing.T) {
+	data, err := GetCancelOrderCalldata(testOrderData)
+	require.NoError(t, err)
+
+	parsedABI, err := abi.JSON(strings.NewReader(abis.AggregationRouterV5))
+	require.NoError(t, err)
+	method, err := parsedABI.MethodById(data[:4])
+	require.NoError(t, err)
+	require.Equal(t, "cancelOrder", method.Name)
+
+	args, err := method.Inputs.Unpack(data[4:])
+	require.NoError(t, err)
+	var decoded struct{ Order routerOrder }
+	require.NoError(t, method.Inputs.Copy(&decoded, args))
+
+	require.Equal(t, routerOrder{
+		Salt:         big.NewInt(1700000000000),
+		MakerAsset:   common.HexToAddress(tokens.PolygonWeth),
+		TakerAsset:   common.HexToAddress(tokens.PolygonDai),
+		Maker:        common.HexToAddress("0x1111111111111111111111111111111111111111"),
+		MakingAmount: big.NewInt(1e18),
+		TakingAmount: new(big.Int).Mul(big.NewInt(3000), big.NewInt(1e18)),
+		Offsets:      big.NewInt(4),
+		Interactions: []byte{0xde, 0xad, 0xbe, 0xef},
+	}, decoded.Order)
+
+	invalidOrder := testOrderData
+	invalidOrder.Salt = "0x01"
+	_, err = GetCancelOrderCalldata(invalidOrder)
+	require.EqualError(t, err, "failed to convert salt to big.Int: failed to convert string (0x01) to big.Int")
+}
+
+func TestGetAdvanceNonceCalldata(t *testing.T) {
+	parsedABI, err := abi.JSON(strings.NewReader(abis.SeriesNonceManager))
+	require.NoError(t, err)
+
+	testcases := []struct {
+		description    string
+		increment      uint8
+		expectedMethod string
+		expectedArgs   string
+		expectedError  string
+	}{
+		{
+			description:    "Single increment",
+			increment:      1,
+			expectedMethod: "increaseNonce",
+			expectedArgs:   "[0]",
+		},
+		{
+			description:    "Larger increment",
+			increment:      10,
+			expectedMethod: "advanceNonce",
+			expectedArgs:   "[0 10]",
+		},
+		{
+			description:   "No increment",
+			increment:     0,
+			expectedError: "nonce increment must be greater than 0",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.description, func(t *testing.T) {
+			data, err := GetAdvanceNonceCalldata(tc.increment)
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+
+			method, err := parsedABI.MethodById(data[:4])
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedMethod, method.Name)
+			args, err := method.Inputs.Unpack(data[4:])
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedArgs, fmt.Sprint(args))
+		})
+	}
+}
+
+func TestConfirmCancelWithUser(t *testing.T) {
+	weth := &models.TokenInfo{Symbol: "WETH", Decimals: 18}
+	dai := &models.TokenInfo{Symbol: "DAI", Decimals: 18}
+
+	testcases := []struct {
+		description    string
+		summary        *models.Summary
+		userInput      string
+		expectedResult bool
+	}{
+		{
+			description: "Single order confirmed",
+			summary: &models.Summary{
+				Kind:      models.SummaryCancelOrder,
+				Wallet:    "0x1111111111111111111111111111111111111111",
+				OrderHash: "0xabc",
+				Selling:   &models.SummaryAmount{Token: weth, Amount: big.NewInt(1e18)},
+				Buying:    &models.SummaryAmount{Token: dai, Amount: new(big.Int).Mul(big.NewInt(3000), big.NewInt(1e18))},
+			},
+			userInput:      "y\n",
+			expectedResult: true,
+		},
+		{
+			description: "Every order rejected",
+			summary: &models.Summary{
+				Kind:   models.SummaryCancelAllOrders,
+				Wallet: "0x1111111111111111111111111111111111111111",
+			},
+			userInput:      "n\n",
+			expectedResult: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.description, func(t *testing.T) {
+			result, err := confirmCancelWithUser(tc.summary, bytes.NewBufferString(tc.userInput), helpers.NoOpPrinter{})
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
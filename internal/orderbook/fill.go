@@ -0,0 +1,143 @@
+package orderbook
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/1inch/1inch-sdk-go/client/models"
+	"github.com/1inch/1inch-sdk-go/helpers"
+	"github.com/1inch/1inch-sdk-go/helpers/consts/abis"
+)
+
+const (
+	FillOrderMethod             = "fillOrder"
+	FillOrderToMethod           = "fillOrderTo"
+	FillOrderToWithPermitMethod = "fillOrderToWithPermit"
+)
+
+// FillOrderConfig describes how a taker fills a limit order
+// Exactly one of MakingAmount and TakingAmount is set, the other one is computed by the router from the order. The threshold
+// is the largest taking amount the taker accepts to pay for a making amount, or the smallest making amount it accepts to
+// receive for a taking amount. The flag skipping the permit of the maker is never set, so the router always executes it
+type FillOrderConfig struct {
+	Order        models.OrderData
+	Signature    string
+	MakingAmount *big.Int
+	TakingAmount *big.Int
+	Threshold    *big.Int
+	Target       common.Address // Optional, receives the maker asset instead of the taker
+	Permit       string         // Optional, permit of the taker for the taker asset, prefixed with the address of the token
+}
+
+// GetFillOrderCalldata returns the router method filling an order with the config along with its calldata
+// fillOrderToWithPermit is used when the config has a permit, fillOrderTo when it has a target and fillOrder otherwise
+func GetFillOrderCalldata(config FillOrderConfig) (string, []byte, error) {
+	parsedABI, err := abi.JSON(strings.NewReader(abis.AggregationRouterV5))
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to parse ABI: %v", err)
+	}
+
+	order, err := newRouterOrder(config.Order)
+	if err != nil {
+		return "", nil, err
+	}
+
+	makingAmount := config.MakingAmount
+	if makingAmount == nil {
+		makingAmount = big.NewInt(0)
+	}
+	takingAmount := config.TakingAmount
+	if takingAmount == nil {
+		takingAmount = big.NewInt(0)
+	}
+	if (makingAmount.Sign() == 0) == (takingAmount.Sign() == 0) {
+		return "", nil, fmt.Errorf("exactly one of the making amount and the taking amount must be set")
+	}
+
+	signature := common.FromHex(config.Signature)
+	interaction := []byte{}
+
+	var method string
+	var data []byte
+	switch {
+	case config.Permit != "":
+		method = FillOrderToWithPermitMethod
+		data, err = parsedABI.Pack(method, order, signature, interaction, makingAmount, takingAmount, config.Threshold, config.Target, common.FromHex(config.Permit))
+	case config.Target != (common.Address{}):
+		method = FillOrderToMethod
+		data, err = parsedABI.Pack(method, order, signature, interaction, makingAmount, takingAmount, config.Threshold, config.Target)
+	default:
+		method = FillOrderMethod
+		data, err = parsedABI.Pack(method, order, signature, interaction, makingAmount, takingAmount, config.Threshold)
+	}
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to pack data: %v", err)
+	}
+	return method, data, nil
+}
+
+// DecodeFillOrderResult decodes the amounts a fill actually made and took, and the hash of the filled order, from the
+// return data of one of the fill methods
+func DecodeFillOrderResult(method string, result []byte) (*big.Int, *big.Int, common.Hash, error) {
+	parsedABI, err := abi.JSON(strings.NewReader(abis.AggregationRouterV5))
+	if err != nil {
+		return nil, nil, common.Hash{}, fmt.Errorf("failed to parse ABI: %v", err)
+	}
+
+	outputs, err := parsedABI.Unpack(method, result)
+	if err != nil {
+		return nil, nil, common.Hash{}, fmt.Errorf("failed to unpack %s result: %v", method, err)
+	}
+	makingAmount, ok := outputs[0].(*big.Int)
+	if !ok {
+		return nil, nil, common.Hash{}, fmt.Errorf("unexpected making amount type: %T", outputs[0])
+	}
+	takingAmount, ok := outputs[1].(*big.Int)
+	if !ok {
+		return nil, nil, common.Hash{}, fmt.Errorf("unexpected taking amount type: %T", outputs[1])
+	}
+	orderHash, ok := outputs[2].([32]byte)
+	if !ok {
+		return nil, nil, common.Hash{}, fmt.Errorf("unexpected order hash type: %T", outputs[2])
+	}
+	return makingAmount, takingAmount, orderHash, nil
+}
+
+// ConfirmFillOrderWithUser prints the summary of a fill and asks the user to confirm it in the terminal
+func ConfirmFillOrderWithUser(summary *models.Summary) (bool, error) {
+	stdOut := helpers.StdOutPrinter{}
+	return confirmFillOrderWithUser(summary, os.Stdin, stdOut)
+}
+
+func confirmFillOrderWithUser(summary *models.Summary, reader io.Reader, writer helpers.Printer) (bool, error) {
+	writer.Printf("Fill summary:\n")
+	writer.Printf("    %-30s %s\n", "Wallet:", summary.Wallet)
+	writer.Printf("    %-30s %s\n", "Order hash:", summary.OrderHash)
+	writer.Printf("    %-30s %s\n", "Paying: ", summary.Selling)
+	writer.Printf("    %-30s %s\n", "Receiving:", summary.Buying)
+	if summary.Receiver != "" {
+		writer.Printf("    %-30s %s\n", "Receiver:", summary.Receiver)
+	}
+	writer.Printf("\n")
+	writer.Printf("WARNING: Executing the fill is irreversible. The amounts above come from a simulation of the transaction " +
+		"and may change if the order is filled by someone else first\n")
+	writer.Printf("Would you like to fill this order now? [y/N]: ")
+
+	inputReader := bufio.NewReader(reader)
+	input, _ := inputReader.ReadString('\n')
+	input = strings.ToLower(strings.TrimSpace(input))
+
+	switch input {
+	case "y":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
@@ -0,0 +1,182 @@
+package orderbook
+
+import (
+	"bytes"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/1inch/1inch-sdk-go/client/models"
+	"github.com/1inch/1inch-sdk-go/helpers"
+	"github.com/1inch/1inch-sdk-go/helpers/consts/abis"
+	"github.com/1inch/1inch-sdk-go/helpers/consts/tokens"
+)
+
+func TestGetFillOrderCalldata(t *testing.T) {
+	parsedABI, err := abi.JSON(strings.NewReader(abis.AggregationRouterV5))
+	require.NoError(t, err)
+
+	receiver := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	permit := tokens.PolygonDai + "0000000000000000000000000000000000000000000000000000000000000001"
+
+	testcases := []struct {
+		description      string
+		config           FillOrderConfig
+		expectedMethod   string
+		expectedTarget   *common.Address
+		expectedPermit   []byte
+		expectedMaking   *big.Int
+		expectedTaking   *big.Int
+		expectedErrorMsg string
+	}{
+		{
+			description: "Making amount for the taker",
+			config: FillOrderConfig{
+				Order:        testOrderData,
+				Signature:    "0x1234",
+				MakingAmount: big.NewInt(5e17),
+				Threshold:    big.NewInt(1600),
+			},
+			expectedMethod: FillOrderMethod,
+			expectedMaking: big.NewInt(5e17),
+			expectedTaking: big.NewInt(0),
+		},
+		{
+			description: "Taking amount for a receiver",
+			config: FillOrderConfig{
+				Order:        testOrderData,
+				Signature:    "0x1234",
+				TakingAmount: big.NewInt(1500),
+				Threshold:    big.NewInt(4e17),
+				Target:       receiver,
+			},
+			expectedMethod: FillOrderToMethod,
+			expectedTarget: &receiver,
+			expectedMaking: big.NewInt(0),
+			expectedTaking: big.NewInt(1500),
+		},
+		{
+			description: "Taker permit",
+			config: FillOrderConfig{
+				Order:        testOrderData,
+				Signature:    "0x1234",
+				TakingAmount: big.NewInt(1500),
+				Threshold:    big.NewInt(4e17),
+				Target:       receiver,
+				Permit:       permit,
+			},
+			expectedMethod: FillOrderToWithPermitMethod,
+			expectedTarget: &receiver,
+			expectedPermit: common.FromHex(permit),
+			expectedMaking: big.NewInt(0),
+			expectedTaking: big.NewInt(1500),
+		},
+		{
+			description: "Both amounts",
+			config: FillOrderConfig{
+				Order:        testOrderData,
+				MakingAmount: big.NewInt(1),
+				TakingAmount: big.NewInt(1),
+				Threshold:    big.NewInt(1),
+			},
+			expectedErrorMsg: "exactly one of the making amount and the taking amount must be set",
+		},
+		{
+			description: "No amount",
+			config: FillOrderConfig{
+				Order:     testOrderData,
+				Threshold: big.NewInt(1),
+			},
+			expectedErrorMsg: "exactly one of the making amount and the taking amount must be set",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.description, func(t *testing.T) {
+			method, data, err := GetFillOrderCalldata(tc.config)
+			if tc.expectedErrorMsg != "" {
+				require.EqualError(t, err, tc.expectedErrorMsg)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedMethod, method)
+
+			abiMethod, err := parsedABI.MethodById(data[:4])
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedMethod, abiMethod.Name)
+			args, err := abiMethod.Inputs.Unpack(data[4:])
+			require.NoError(t, err)
+
+			require.Equal(t, []byte{0x12, 0x34}, args[1])
+			require.Empty(t, args[2])
+			require.Equal(t, tc.expectedMaking.String(), args[3].(*big.Int).String())
+			require.Equal(t, tc.expectedTaking.String(), args[4].(*big.Int).String())
+			require.Equal(t, tc.config.Threshold, args[5])
+			if tc.expectedTarget != nil {
+				require.Equal(t, *tc.expectedTarget, args[6])
+			} else {
+				require.Len(t, args, 6)
+			}
+			if tc.expectedPermit != nil {
+				require.Equal(t, tc.expectedPermit, args[7])
+			}
+		})
+	}
+}
+
+func TestDecodeFillOrderResult(t *testing.T) {
+	parsedABI, err := abi.JSON(strings.NewReader(abis.AggregationRouterV5))
+	require.NoError(t, err)
+
+	orderHash := common.HexToHash("0xabcdef")
+	output, err := parsedABI.Methods[FillOrderToMethod].Outputs.Pack(big.NewInt(5e17), big.NewInt(1500), orderHash)
+	require.NoError(t, err)
+
+	makingAmount, takingAmount, decodedHash, err := DecodeFillOrderResult(FillOrderToMethod, output)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(5e17), makingAmount)
+	require.Equal(t, big.NewInt(1500), takingAmount)
+	require.Equal(t, orderHash, decodedHash)
+
+	_, _, _, err = DecodeFillOrderResult(FillOrderMethod, []byte{})
+	require.ErrorContains(t, err, "failed to unpack fillOrder result")
+}
+
+func TestConfirmFillOrderWithUser(t *testing.T) {
+	summary := &models.Summary{
+		Kind:      models.SummaryFillOrder,
+		Wallet:    "0x1111111111111111111111111111111111111111",
+		OrderHash: "0xabc",
+		Selling:   &models.SummaryAmount{Token: &models.TokenInfo{Symbol: "DAI", Decimals: 18}, Amount: big.NewInt(1500)},
+		Buying:    &models.SummaryAmount{Token: &models.TokenInfo{Symbol: "WETH", Decimals: 18}, Amount: big.NewInt(5e17)},
+	}
+
+	testcases := []struct {
+		description    string
+		userInput      string
+		expectedResult bool
+	}{
+		{
+			description:    "User inputs 'y'",
+			userInput:      "y\n",
+			expectedResult: true,
+		},
+		{
+			description:    "User inputs nothing",
+			userInput:      "\n",
+			expectedResult: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.description, func(t *testing.T) {
+			result, err := confirmFillOrderWithUser(summary, bytes.NewBufferString(tc.userInput), helpers.NoOpPrinter{})
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
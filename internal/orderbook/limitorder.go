@@ -2,6 +2,7 @@ package orderbook
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"io"
 	"math/big"
@@ -22,6 +23,65 @@ import (
 )
 
 func CreateLimitOrderMessage(orderRequest models.CreateOrderParams, interactions []string) (*models.Order, error) {
+	orderData, challengeHash, err := hashLimitOrder(orderRequest, interactions)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, err := crypto.HexToECDSA(orderRequest.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("error converting private key to ECDSA: %v", err)
+	}
+
+	// Sign the challenge hash
+	signature, err := crypto.Sign(challengeHash.Bytes(), privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("error signing challenge hash: %v", err)
+	}
+
+	// add 27 to `v` value (last byte)
+	signature[64] += 27
+
+	// convert signature to hex string
+	signatureHex := fmt.Sprintf("0x%x", signature)
+
+	return &models.Order{
+		OrderHash: challengeHash.Hex(),
+		Signature: signatureHex,
+		Data:      orderData,
+	}, err
+}
+
+// CreateContractLimitOrderMessage creates an order whose maker is a smart contract wallet
+// The signature comes from the EIP-1271 signer of the request and is checked against isValidSignature of the maker before it is returned
+func CreateContractLimitOrderMessage(ctx context.Context, client *ethclient.Client, orderRequest models.CreateOrderParams, interactions []string) (*models.Order, error) {
+	orderData, challengeHash, err := hashLimitOrder(orderRequest, interactions)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := orderRequest.ContractSigner(ctx, challengeHash, orderData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign order with contract signer: %v", err)
+	}
+
+	valid, err := onchain.IsValidSignature(ctx, client, common.HexToAddress(orderRequest.Maker), challengeHash, signature)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify contract signature: %v", err)
+	}
+	if !valid {
+		return nil, fmt.Errorf("signature is not valid for contract maker %s", orderRequest.Maker)
+	}
+
+	return &models.Order{
+		OrderHash: challengeHash.Hex(),
+		Signature: fmt.Sprintf("0x%x", signature),
+		Data:      orderData,
+	}, nil
+}
+
+// hashLimitOrder builds the order data of a request and the EIP-712 hash its maker has to sign
+func hashLimitOrder(orderRequest models.CreateOrderParams, interactions []string) (models.OrderData, common.Hash, error) {
 
 	offsets := getOffsets(interactions)
 
@@ -40,7 +100,7 @@ func CreateLimitOrderMessage(orderRequest models.CreateOrderParams, interactions
 
 	aggregationRouter, err := contracts.Get1inchRouterFromChainId(orderRequest.ChainId)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get 1inch router address: %v", err)
+		return models.OrderData{}, common.Hash{}, fmt.Errorf("failed to get 1inch router address: %v", err)
 	}
 
 	// Set up the domain data
@@ -97,59 +157,38 @@ func CreateLimitOrderMessage(orderRequest models.CreateOrderParams, interactions
 
 	typedDataHash, err := typedData.HashStruct(typedData.PrimaryType, typedData.Message)
 	if err != nil {
-		return nil, fmt.Errorf("error hashing typed data: %v", err)
+		return models.OrderData{}, common.Hash{}, fmt.Errorf("error hashing typed data: %v", err)
 	}
 	domainSeparator, err := typedData.HashStruct("EIP712Domain", typedData.Domain.Map())
 	if err != nil {
-		return nil, fmt.Errorf("error hashing domain separator: %v", err)
+		return models.OrderData{}, common.Hash{}, fmt.Errorf("error hashing domain separator: %v", err)
 	}
 
 	// Add required prefix to the message
 	rawData := []byte(fmt.Sprintf("\x19\x01%s%s", string(domainSeparator), string(typedDataHash)))
 
-	challengeHash := crypto.Keccak256Hash(rawData)
-	challengeHashHex := challengeHash.Hex()
-
-	privateKey, err := crypto.HexToECDSA(orderRequest.PrivateKey)
-	if err != nil {
-		return nil, fmt.Errorf("error converting private key to ECDSA: %v", err)
-	}
-
-	// Sign the challenge hash
-	signature, err := crypto.Sign(challengeHash.Bytes(), privateKey)
-	if err != nil {
-		return nil, fmt.Errorf("error signing challenge hash: %v", err)
-	}
-
-	// add 27 to `v` value (last byte)
-	signature[64] += 27
-
-	// convert signature to hex string
-	signatureHex := fmt.Sprintf("0x%x", signature)
-
-	return &models.Order{
-		OrderHash: challengeHashHex,
-		Signature: signatureHex,
-		Data:      orderData,
-	}, err
+	return orderData, crypto.Keccak256Hash(rawData), nil
 }
 
-func GetInteractions(client *ethclient.Client, seriesNonceManager string, expiration int64, maker string, makerAsset string, permit string) ([]string, error) {
+// GetInteractions returns the interactions of a limit order. Its predicate holds until the expiration while the limit
+// order series nonce of the maker is unchanged, and also requires the extra predicate when one is given.
+func GetInteractions(client *ethclient.Client, seriesNonceManager string, expiration int64, maker string, makerAsset string, permit string, extraPredicate onchain.Predicate) ([]string, error) {
 
 	currentNonce, err := onchain.GetTimeSeriesManagerNonce(client, seriesNonceManager, maker)
 	if err != nil {
 		return nil, err
 	}
 
-	timeBelowAndNonceEqualsCalldata, err := onchain.GetTimestampBelowAndNonceEqualsCalldata(expiration, currentNonce, maker)
-
+	predicate, err := onchain.TimestampBelowAndNonceEquals(seriesNonceManager, expiration, currentNonce, maker)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get predicate calldata: %v", err)
 	}
 
-	predicate, err := onchain.GetPredicateCalldata(seriesNonceManager, timeBelowAndNonceEqualsCalldata)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get predicate calldata: %v", err)
+	if len(extraPredicate) > 0 {
+		predicate, err = onchain.And(predicate, extraPredicate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to combine predicates: %v", err)
+		}
 	}
 
 	makerAssetData := `0x`
@@ -164,7 +203,21 @@ func GetInteractions(client *ethclient.Client, seriesNonceManager string, expira
 		permit = makerAsset + Trim0x(permit)
 	}
 
-	return []string{makerAssetData, takerAssetData, getMakingAmount, getTakingAmount, fmt.Sprintf("0x%x", predicate), permit, preInteraction, postInteraction}, nil //TODO remove leading 0x from predicate
+	return []string{makerAssetData, takerAssetData, getMakingAmount, getTakingAmount, predicate.Hex(), permit, preInteraction, postInteraction}, nil //TODO remove leading 0x from predicate
+}
+
+// WithDynamicPricing replaces the empty getMakingAmount and getTakingAmount interactions of a fixed price order with the
+// getters of the pricing
+func WithDynamicPricing(interactions []string, pricing onchain.DynamicPricing, orderMakingAmount *big.Int) ([]string, error) {
+	getMakingAmount, getTakingAmount, err := pricing.Getters(orderMakingAmount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get amount getters: %v", err)
+	}
+
+	pricedInteractions := append([]string{}, interactions...)
+	pricedInteractions[2] = fmt.Sprintf("0x%x", getMakingAmount)
+	pricedInteractions[3] = fmt.Sprintf("0x%x", getTakingAmount)
+	return pricedInteractions, nil
 }
 
 func getOffsets(interactions []string) *big.Int {
@@ -192,36 +245,20 @@ func getOffsets(interactions []string) *big.Int {
 	return bytesAccumulator
 }
 
-func ConfirmLimitOrderWithUser(order *models.Order, ethClient *ethclient.Client) (bool, error) {
+// ConfirmLimitOrderWithUser prints the summary of a limit order and asks the user to confirm it in the terminal
+func ConfirmLimitOrderWithUser(summary *models.Summary) (bool, error) {
 	stdOut := helpers.StdOutPrinter{}
-	return confirmLimitOrderWithUser(order, ethClient, os.Stdin, stdOut)
+	return confirmLimitOrderWithUser(summary, os.Stdin, stdOut)
 }
 
-func confirmLimitOrderWithUser(order *models.Order, ethClient *ethclient.Client, reader io.Reader, writer helpers.Printer) (bool, error) {
-	makerTokenDecimals, err := onchain.ReadContractDecimals(ethClient, common.HexToAddress(order.Data.MakerAsset))
-	if err != nil {
-		return false, fmt.Errorf("failed to read decimals: %v", err)
-	}
-
-	makerTokenName, err := onchain.ReadContractSymbol(ethClient, common.HexToAddress(order.Data.MakerAsset))
-	if err != nil {
-		return false, fmt.Errorf("failed to read name: %v", err)
-	}
-
-	takerTokenDecimals, err := onchain.ReadContractDecimals(ethClient, common.HexToAddress(order.Data.TakerAsset))
-	if err != nil {
-		return false, fmt.Errorf("failed to read decimals: %v", err)
-	}
-
-	takerTokenName, err := onchain.ReadContractSymbol(ethClient, common.HexToAddress(order.Data.TakerAsset))
-	if err != nil {
-		return false, fmt.Errorf("failed to read name: %v", err)
-	}
-
+func confirmLimitOrderWithUser(summary *models.Summary, reader io.Reader, writer helpers.Printer) (bool, error) {
 	writer.Printf("Order summary:\n")
-	writer.Printf("    %-30s %s\n", "Wallet:", order.Data.Maker)
-	writer.Printf("    %-30s %s %s\n", "Selling: ", helpers.SimplifyValue(order.Data.MakingAmount, int(makerTokenDecimals)), makerTokenName)
-	writer.Printf("    %-30s %s %s\n", "Buying:", helpers.SimplifyValue(order.Data.TakingAmount, int(takerTokenDecimals)), takerTokenName)
+	writer.Printf("    %-30s %s\n", "Wallet:", summary.Wallet)
+	writer.Printf("    %-30s %s\n", "Selling: ", summary.Selling)
+	writer.Printf("    %-30s %s\n", "Buying:", summary.Buying)
+	if summary.DynamicPrice {
+		writer.Printf("    %-30s %s\n", "Pricing:", "Dynamic, buying is the current price and changes over time or with fills")
+	}
 	writer.Printf("\n")
 	writer.Printf("WARNING: This order will be officially posted to the 1inch Limit Order protocol where anyone will be able to execute in onchain immediately. " +
 		"Once executed, the results are irreversible. Make sure the proposed trade looks correct before continuing!\n")
@@ -239,26 +276,24 @@ func confirmLimitOrderWithUser(order *models.Order, ethClient *ethclient.Client,
 	}
 }
 
-func ConfirmApprovalWithUser(ethClient *ethclient.Client, publicAddress string, tokenAddress string) (bool, error) {
+// ConfirmApprovalWithUser prints the summary of the approval a limit order needs and asks the user to confirm it in the terminal
+func ConfirmApprovalWithUser(summary *models.Summary) (bool, error) {
 	stdOut := helpers.StdOutPrinter{}
-	return confirmApprovalWithUser(ethClient, publicAddress, tokenAddress, os.Stdin, stdOut)
+	return confirmApprovalWithUser(summary, os.Stdin, stdOut)
 }
 
-func confirmApprovalWithUser(ethClient *ethclient.Client, publicAddress string, tokenAddress string, reader io.Reader, writer helpers.Printer) (bool, error) {
-	tokenSymbol, err := onchain.ReadContractSymbol(ethClient, common.HexToAddress(tokenAddress))
-	if err != nil {
-		return false, fmt.Errorf("failed to read name: %v", err)
-	}
+func confirmApprovalWithUser(summary *models.Summary, reader io.Reader, writer helpers.Printer) (bool, error) {
+	approvalAmountDisplay := summary.Approval.String()
 
-	writer.Printf("The aggregator contract does not have enough allowance to execute the order! The SDK can give an " +
-		"unlimited approval on your behalf. If you would like to use custom approval amount instead, do that manually " +
-		"onchain, then run the SDK again\n")
+	writer.Printf("The aggregator contract does not have enough allowance to execute the order! The SDK can post an " +
+		"approval on your behalf using the approval policy of the request. If you would like to approve a different amount " +
+		"instead, change the approval policy or do that manually onchain, then run the SDK again\n")
 	writer.Printf("Approval summary:\n")
-	writer.Printf("    %-30s %s\n", "Wallet:", publicAddress)
-	writer.Printf("    %-30s %s\n", "Selling: ", tokenSymbol)
-	writer.Printf("    %-30s %s\n", "Approval amount: ", "unlimited")
+	writer.Printf("    %-30s %s\n", "Wallet:", summary.Wallet)
+	writer.Printf("    %-30s %s\n", "Selling: ", summary.Approval.Token.Symbol)
+	writer.Printf("    %-30s %s\n", "Approval amount: ", approvalAmountDisplay)
 	writer.Printf("\n")
-	writer.Printf("Would you like post an onchain unlimited approval now? [y/N]: ")
+	writer.Printf("Would you like post an onchain %s approval now? [y/N]: ", approvalAmountDisplay)
 
 	inputReader := bufio.NewReader(reader)
 	input, _ := inputReader.ReadString('\n')
@@ -2,22 +2,28 @@ package orderbook
 
 import (
 	"bytes"
+	"context"
+	"fmt"
 	"math/big"
+	"net/http/httptest"
 	"os"
 	"testing"
 	"time"
 
 	"github.com/1inch/1inch-sdk-go/client/models"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/1inch/1inch-sdk-go/helpers"
 	"github.com/1inch/1inch-sdk-go/helpers/consts/addresses"
-	"github.com/1inch/1inch-sdk-go/helpers/consts/amounts"
 	"github.com/1inch/1inch-sdk-go/helpers/consts/tokens"
 
 	"github.com/1inch/1inch-sdk-go/helpers/consts/chains"
+	"github.com/1inch/1inch-sdk-go/internal/onchain"
 )
 
 func TestTrim0x(t *testing.T) {
@@ -248,14 +254,11 @@ func TestCreateLimitOrder(t *testing.T) {
 
 func TestConfirmTradeWithUser(t *testing.T) {
 
-	order := &models.Order{
-		Data: models.OrderData{
-			MakerAsset:   tokens.EthereumUsdc,
-			TakerAsset:   tokens.EthereumDai,
-			MakingAmount: amounts.Ten6 + "1",
-			TakingAmount: amounts.Ten18,
-			Maker:        addresses.Vitalik,
-		},
+	summary := &models.Summary{
+		Kind:    models.SummaryLimitOrder,
+		Wallet:  addresses.Vitalik,
+		Selling: &models.SummaryAmount{Token: &models.TokenInfo{Address: tokens.EthereumUsdc, Symbol: "USDC", Decimals: 6}, Amount: big.NewInt(10000001)},
+		Buying:  &models.SummaryAmount{Token: &models.TokenInfo{Address: tokens.EthereumDai, Symbol: "DAI", Decimals: 18}, Amount: big.NewInt(1e18)},
 	}
 
 	tests := []struct {
@@ -293,11 +296,9 @@ func TestConfirmTradeWithUser(t *testing.T) {
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			ethClient, err := ethclient.Dial(os.Getenv("WEB_3_HTTP_PROVIDER_URL_WITH_KEY"))
-			require.NoError(t, err)
 			reader := bytes.NewBufferString(tc.userInput)
 			writer := helpers.NoOpPrinter{}
-			result, err := confirmLimitOrderWithUser(order, ethClient, reader, writer)
+			result, err := confirmLimitOrderWithUser(summary, reader, writer)
 
 			assert.NoError(t, err)
 			assert.Equal(t, tc.expectedResult, result)
@@ -305,6 +306,36 @@ func TestConfirmTradeWithUser(t *testing.T) {
 	}
 }
 
+func TestWithDynamicPricing(t *testing.T) {
+	auction := onchain.DutchAuction{
+		Calculator:        common.HexToAddress("0x3333333333333333333333333333333333333333"),
+		StartTime:         1700000000,
+		EndTime:           1700003600,
+		TakingAmountStart: big.NewInt(2000e6),
+		TakingAmountEnd:   big.NewInt(1600e6),
+	}
+	interactions := []string{"0x", "0x", "0x", "0x", "0x1234", "0x", "0x", "0x"}
+
+	pricedInteractions, err := WithDynamicPricing(interactions, auction, big.NewInt(1e18))
+	require.NoError(t, err)
+
+	getMakingAmount, getTakingAmount, err := auction.Getters(big.NewInt(1e18))
+	require.NoError(t, err)
+	require.Equal(t, fmt.Sprintf("0x%x", getMakingAmount), pricedInteractions[2])
+	require.Equal(t, fmt.Sprintf("0x%x", getTakingAmount), pricedInteractions[3])
+	require.Equal(t, "0x1234", pricedInteractions[4])
+	// The fixed price interactions are left untouched
+	require.Equal(t, "0x", interactions[2])
+
+	// The predicate now starts after both getters
+	gettersLength := int64(len(getMakingAmount) + len(getTakingAmount))
+	offsets := getOffsets(pricedInteractions)
+	require.Equal(t, gettersLength, new(big.Int).And(new(big.Int).Rsh(offsets, 96), big.NewInt(1<<32-1)).Int64())
+
+	_, err = WithDynamicPricing(interactions, onchain.RangePricing{}, big.NewInt(1e18))
+	require.EqualError(t, err, "failed to get amount getters: calculator is required")
+}
+
 func TestConcatenateInteractions(t *testing.T) {
 
 	tests := []struct {
@@ -351,3 +382,106 @@ func TestConcatenateInteractions(t *testing.T) {
 		})
 	}
 }
+
+// erc1271Service answers eth_getCode and isValidSignature calls for a contract maker that accepts a single signature
+type erc1271Service struct {
+	code              hexutil.Bytes
+	acceptedSignature []byte
+}
+
+type erc1271CallArgs struct {
+	Data  hexutil.Bytes `json:"data"`
+	Input hexutil.Bytes `json:"input"`
+}
+
+func (s *erc1271Service) GetCode(address common.Address, block string) hexutil.Bytes {
+	return s.code
+}
+
+func (s *erc1271Service) Call(args erc1271CallArgs, block string) (hexutil.Bytes, error) {
+	data := args.Input
+	if len(data) == 0 {
+		data = args.Data
+	}
+	result := make([]byte, 32)
+	if bytes.Contains(data, s.acceptedSignature) {
+		copy(result, onchain.Erc1271MagicValue[:])
+	}
+	return result, nil
+}
+
+func TestCreateContractLimitOrder(t *testing.T) {
+	originalGenerateSalt := GenerateSalt
+	GenerateSalt = func() string { return "100000000" }
+	defer func() {
+		GenerateSalt = originalGenerateSalt
+	}()
+
+	safeSignature := bytes.Repeat([]byte{0xab}, 65)
+	orderRequest := models.CreateOrderParams{
+		ChainId:      chains.Polygon,
+		MakerAsset:   "0x8f3Cf7ad23Cd3CaDbD9735AFf958023239c6A063",
+		TakerAsset:   "0x7ceb23fd6bc0add59e62ac25578270cff1b9f619",
+		MakingAmount: "1000000",
+		TakingAmount: "1000000000",
+		Maker:        "0x2c9b2DBdbA8A9c969Ac24153f5C1c23CB0e63914",
+		Taker:        "0x0000000000000000000000000000000000000000",
+	}
+	interactions := []string{"0x", "0x", "0x", "0x", "0xbf15fcd8000000000000000000000000a5eb255ef45dfb48b5d133d08833def69871691d000000000000000000000000000000000000000000000000000000000000004000000000000000000000000000000000000000000000000000000000000000242cc2878d0071150dff0000000000000050c5df26654b5efbdd0c54a062dfa6012933defe00000000000000000000000000000000000000000000000000000000", "0x", "0x", "0x"}
+
+	tests := []struct {
+		name          string
+		code          []byte
+		signature     []byte
+		expectedError string
+	}{
+		{
+			name:      "Signature accepted by the maker contract",
+			code:      []byte{0x60, 0x80},
+			signature: safeSignature,
+		},
+		{
+			name:          "Error - signature rejected by the maker contract",
+			code:          []byte{0x60, 0x80},
+			signature:     bytes.Repeat([]byte{0xcd}, 65),
+			expectedError: "signature is not valid for contract maker",
+		},
+		{
+			name:          "Error - maker is not a contract",
+			signature:     safeSignature,
+			expectedError: "is not a contract",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			rpcServer := rpc.NewServer()
+			require.NoError(t, rpcServer.RegisterName("eth", &erc1271Service{code: tc.code, acceptedSignature: safeSignature}))
+			server := httptest.NewServer(rpcServer)
+			t.Cleanup(server.Close)
+			t.Cleanup(rpcServer.Stop)
+			ethClient, err := ethclient.Dial(server.URL)
+			require.NoError(t, err)
+			t.Cleanup(ethClient.Close)
+
+			var signedHash common.Hash
+			request := orderRequest
+			request.ContractSigner = func(ctx context.Context, orderHash common.Hash, orderData models.OrderData) ([]byte, error) {
+				signedHash = orderHash
+				require.Equal(t, orderRequest.Maker, orderData.Maker)
+				return tc.signature, nil
+			}
+
+			order, err := CreateContractLimitOrderMessage(context.Background(), ethClient, request, interactions)
+			if tc.expectedError != "" {
+				require.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			// The contract signs the same EIP-712 hash an EOA maker would
+			require.Equal(t, "0xdc9344cfa6d3b4da5a2ad3283e02826d3f569b4472443390d3e1cfe86cacd13f", order.OrderHash)
+			require.Equal(t, order.OrderHash, signedHash.Hex())
+			require.Equal(t, fmt.Sprintf("0x%x", safeSignature), order.Signature)
+		})
+	}
+}
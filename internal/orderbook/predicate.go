@@ -0,0 +1,46 @@
+package orderbook
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	"github.com/1inch/1inch-sdk-go/client/models"
+	"github.com/1inch/1inch-sdk-go/helpers/consts/abis"
+)
+
+// CheckPredicate calls checkPredicate on AggregationRouterV5 to tell whether the predicate of the order holds at the
+// latest block. Orders without a predicate always hold.
+func CheckPredicate(ctx context.Context, client *ethclient.Client, router common.Address, order models.OrderData) (bool, error) {
+	parsedABI, err := abi.JSON(strings.NewReader(abis.AggregationRouterV5))
+	if err != nil {
+		return false, fmt.Errorf("failed to parse ABI: %v", err)
+	}
+
+	encodedOrder, err := newRouterOrder(order)
+	if err != nil {
+		return false, err
+	}
+
+	data, err := parsedABI.Pack("checkPredicate", encodedOrder)
+	if err != nil {
+		return false, fmt.Errorf("failed to pack data: %v", err)
+	}
+
+	result, err := client.CallContract(ctx, ethereum.CallMsg{To: &router, Data: data}, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to check predicate: %v", err)
+	}
+
+	var holds bool
+	err = parsedABI.UnpackIntoInterface(&holds, "checkPredicate", result)
+	if err != nil {
+		return false, fmt.Errorf("failed to unpack predicate result: %v", err)
+	}
+	return holds, nil
+}
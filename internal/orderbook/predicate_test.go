@@ -0,0 +1,343 @@
+package orderbook
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/1inch/1inch-sdk-go/client/models"
+	"github.com/1inch/1inch-sdk-go/helpers/consts/abis"
+	"github.com/1inch/1inch-sdk-go/helpers/consts/chains"
+	"github.com/1inch/1inch-sdk-go/internal/onchain"
+)
+
+var (
+	predicateRouter             = common.HexToAddress("0x1111111254EEB25477B68fb85Ed929f73A960582")
+	predicateSeriesNonceManager = common.HexToAddress("0xa5eb255EF45dFb48B5d133d08833DEF69871691D")
+	predicateAggregator         = common.HexToAddress("0xF9680D99D6C9589e2a93a78A04A279e509205945")
+	predicateMaker              = common.HexToAddress("0x2c9b2DBdbA8A9c969Ac24153f5C1c23CB0e63914")
+)
+
+// predicateChain simulates the part of a chain checkPredicate touches: the predicate helpers of AggregationRouterV5,
+// the series nonce manager and a Chainlink aggregator. The router bytecode is not part of the SDK, so its predicate
+// helpers are modeled after the contract instead of executed.
+type predicateChain struct {
+	t            *testing.T
+	router       abi.ABI
+	nonceManager abi.ABI
+	timestamp    int64
+	routerNonce  int64
+	seriesNonce  int64
+	answer       *big.Int // nil makes latestAnswer revert
+}
+
+type predicateCallArgs struct {
+	To    common.Address `json:"to"`
+	Data  hexutil.Bytes  `json:"data"`
+	Input hexutil.Bytes  `json:"input"`
+}
+
+func (c *predicateChain) Call(args predicateCallArgs, block string) (hexutil.Bytes, error) {
+	data := args.Input
+	if len(data) == 0 {
+		data = args.Data
+	}
+	if args.To == predicateRouter {
+		method, err := c.router.MethodById(data[:4])
+		require.NoError(c.t, err)
+		if method.Name == "checkPredicate" {
+			order := c.unpack(c.router, data)[0].(struct {
+				Salt          *big.Int       `json:"salt"`
+				MakerAsset    common.Address `json:"makerAsset"`
+				TakerAsset    common.Address `json:"takerAsset"`
+				Maker         common.Address `json:"maker"`
+				Receiver      common.Address `json:"receiver"`
+				AllowedSender common.Address `json:"allowedSender"`
+				MakingAmount  *big.Int       `json:"makingAmount"`
+				TakingAmount  *big.Int       `json:"takingAmount"`
+				Offsets       *big.Int       `json:"offsets"`
+				Interactions  []byte         `json:"interactions"`
+			})
+			// The predicate is the fifth interaction, it starts where the fourth one ends
+			start := uint32(new(big.Int).Rsh(order.Offsets, 96).Uint64())
+			end := uint32(new(big.Int).Rsh(order.Offsets, 128).Uint64())
+			holds := start != end
+			if holds {
+				success, result := c.selfStaticCall(order.Interactions[start:end])
+				holds = success && result.Cmp(big.NewInt(1)) == 0
+			}
+			return c.router.Methods["checkPredicate"].Outputs.Pack(holds)
+		}
+	}
+	success, result := c.staticCall(args.To, data)
+	if !success {
+		return nil, errors.New("execution reverted")
+	}
+	return math.U256Bytes(result), nil
+}
+
+// selfStaticCall mirrors _selfStaticCall of the router, which calls one of its own view methods
+func (c *predicateChain) selfStaticCall(data []byte) (bool, *big.Int) {
+	return c.staticCall(predicateRouter, data)
+}
+
+func (c *predicateChain) staticCall(target common.Address, data []byte) (bool, *big.Int) {
+	switch target {
+	case predicateRouter:
+		method, err := c.router.MethodById(data[:4])
+		if err != nil {
+			return false, nil
+		}
+		args := c.unpack(c.router, data)
+		switch method.Name {
+		case "and", "or":
+			offsets, calls := args[0].(*big.Int), args[1].([]byte)
+			previous := uint32(0)
+			for i := uint(0); i < 256; i += 32 {
+				current := uint32(new(big.Int).Rsh(offsets, i).Uint64())
+				if current == 0 {
+					break
+				}
+				success, result := c.selfStaticCall(calls[previous:current])
+				holds := success && result.Cmp(big.NewInt(1)) == 0
+				if method.Name == "and" && !holds {
+					return true, big.NewInt(0)
+				}
+				if method.Name == "or" && holds {
+					return true, big.NewInt(1)
+				}
+				previous = current
+			}
+			return true, boolToInt(method.Name == "and")
+		case "eq", "lt", "gt":
+			value := args[0].(*big.Int)
+			success, result := c.selfStaticCall(args[1].([]byte))
+			if !success {
+				return true, big.NewInt(0)
+			}
+			comparison := map[string]int{"eq": 0, "lt": -1, "gt": 1}[method.Name]
+			return true, boolToInt(result.Cmp(value) == comparison)
+		case "timestampBelow":
+			return true, boolToInt(big.NewInt(c.timestamp).Cmp(args[0].(*big.Int)) < 0)
+		case "nonceEquals":
+			return true, boolToInt(args[0].(common.Address) == predicateMaker && args[1].(*big.Int).Int64() == c.routerNonce)
+		case "arbitraryStaticCall":
+			// A reverting call reverts the whole predicate
+			return c.staticCall(args[0].(common.Address), args[1].([]byte))
+		}
+	case predicateSeriesNonceManager:
+		method, err := c.nonceManager.MethodById(data[:4])
+		require.NoError(c.t, err)
+		args := c.unpack(c.nonceManager, data)
+		switch method.Name {
+		case "nonce":
+			return true, big.NewInt(c.seriesNonce)
+		case "timestampBelowAndNonceEquals":
+			packed := args[0].(*big.Int)
+			timestamp := new(big.Int).Rsh(packed, 216)
+			nonce := new(big.Int).And(new(big.Int).Rsh(packed, 176), big.NewInt(1<<40-1))
+			account := common.BigToAddress(new(big.Int).And(packed, new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 160), big.NewInt(1))))
+			holds := big.NewInt(c.timestamp).Cmp(timestamp) < 0 && nonce.Int64() == c.seriesNonce && account == predicateMaker
+			return true, boolToInt(holds)
+		}
+	case predicateAggregator:
+		if c.answer == nil {
+			return false, nil
+		}
+		// latestAnswer is an int256, the router reads it as a uint256
+		return true, math.U256(new(big.Int).Set(c.answer))
+	}
+	return false, nil
+}
+
+func (c *predicateChain) unpack(contract abi.ABI, data []byte) []interface{} {
+	method, err := contract.MethodById(data[:4])
+	require.NoError(c.t, err)
+	args, err := method.Inputs.Unpack(data[4:])
+	require.NoError(c.t, err)
+	return args
+}
+
+func boolToInt(value bool) *big.Int {
+	if value {
+		return big.NewInt(1)
+	}
+	return big.NewInt(0)
+}
+
+func TestCheckPredicate(t *testing.T) {
+	routerABI, err := abi.JSON(strings.NewReader(abis.AggregationRouterV5))
+	require.NoError(t, err)
+	nonceManagerABI, err := abi.JSON(strings.NewReader(abis.SeriesNonceManager))
+	require.NoError(t, err)
+
+	expiration := int64(1700000000)
+	build := func(predicate onchain.Predicate, err error) onchain.Predicate {
+		require.NoError(t, err)
+		return predicate
+	}
+	priceAbove := build(onchain.ChainlinkAnswerAbove(predicateAggregator, big.NewInt(180000000000)))
+	priceBelow := build(onchain.ChainlinkAnswerBelow(predicateAggregator, big.NewInt(150000000000)))
+	earlyExpiry := build(onchain.TimestampBelow(expiration - 100))
+
+	testcases := []struct {
+		description string
+		predicate   onchain.Predicate
+		timestamp   int64
+		seriesNonce int64
+		routerNonce int64
+		answer      *big.Int
+		expected    bool
+	}{
+		{
+			description: "Default predicate before expiration",
+			timestamp:   expiration - 1,
+			expected:    true,
+		},
+		{
+			description: "Default predicate after expiration",
+			timestamp:   expiration,
+			expected:    false,
+		},
+		{
+			description: "Default predicate after the series nonce advanced",
+			timestamp:   expiration - 1,
+			seriesNonce: 1,
+			expected:    false,
+		},
+		{
+			description: "Price above the threshold",
+			predicate:   priceAbove,
+			timestamp:   expiration - 1,
+			answer:      big.NewInt(190000000000),
+			expected:    true,
+		},
+		{
+			description: "Price at the threshold",
+			predicate:   priceAbove,
+			timestamp:   expiration - 1,
+			answer:      big.NewInt(180000000000),
+			expected:    false,
+		},
+		{
+			description: "Stop-loss below the threshold",
+			predicate:   priceBelow,
+			timestamp:   expiration - 1,
+			answer:      big.NewInt(140000000000),
+			expected:    true,
+		},
+		{
+			description: "Stop-loss with a negative answer",
+			predicate:   priceBelow,
+			timestamp:   expiration - 1,
+			answer:      big.NewInt(-1),
+			expected:    false,
+		},
+		{
+			description: "Not of the price above the threshold",
+			predicate:   build(onchain.Not(priceAbove)),
+			timestamp:   expiration - 1,
+			answer:      big.NewInt(170000000000),
+			expected:    true,
+		},
+		{
+			description: "Not of a comparison with a reverting oracle",
+			predicate:   build(onchain.Not(priceAbove)),
+			timestamp:   expiration - 1,
+			expected:    true,
+		},
+		{
+			description: "Reverting oracle",
+			predicate:   priceAbove,
+			timestamp:   expiration - 1,
+			expected:    false,
+		},
+		{
+			description: "Or holds when a later predicate holds",
+			predicate:   build(onchain.Or(priceAbove, priceBelow)),
+			timestamp:   expiration - 1,
+			answer:      big.NewInt(100000000000),
+			expected:    true,
+		},
+		{
+			description: "Or fails when no predicate holds",
+			predicate:   build(onchain.Or(priceAbove, priceBelow)),
+			timestamp:   expiration - 1,
+			answer:      big.NewInt(160000000000),
+			expected:    false,
+		},
+		{
+			description: "And with an earlier expiration",
+			predicate:   build(onchain.And(earlyExpiry, priceAbove)),
+			timestamp:   expiration - 100,
+			answer:      big.NewInt(190000000000),
+			expected:    false,
+		},
+		{
+			description: "Router nonce equals",
+			predicate:   build(onchain.NonceEquals(predicateMaker, big.NewInt(2))),
+			timestamp:   expiration - 1,
+			routerNonce: 2,
+			expected:    true,
+		},
+		{
+			description: "Router nonce changed",
+			predicate:   build(onchain.NonceEquals(predicateMaker, big.NewInt(2))),
+			timestamp:   expiration - 1,
+			routerNonce: 3,
+			expected:    false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.description, func(t *testing.T) {
+			chain := &predicateChain{
+				t:            t,
+				router:       routerABI,
+				nonceManager: nonceManagerABI,
+				timestamp:    tc.timestamp,
+				routerNonce:  tc.routerNonce,
+				answer:       tc.answer,
+			}
+			rpcServer := rpc.NewServer()
+			require.NoError(t, rpcServer.RegisterName("eth", chain))
+			server := httptest.NewServer(rpcServer)
+			t.Cleanup(server.Close)
+			t.Cleanup(rpcServer.Stop)
+			ethClient, err := ethclient.Dial(server.URL)
+			require.NoError(t, err)
+			t.Cleanup(ethClient.Close)
+
+			// The order is built against the nonce the maker had when it was created
+			interactions, err := GetInteractions(ethClient, predicateSeriesNonceManager.Hex(), expiration, predicateMaker.Hex(), "0x8f3Cf7ad23Cd3CaDbD9735AFf958023239c6A063", "0x", tc.predicate)
+			require.NoError(t, err)
+			chain.seriesNonce = tc.seriesNonce
+
+			orderData, _, err := hashLimitOrder(models.CreateOrderParams{
+				ChainId:      chains.Polygon,
+				MakerAsset:   "0x8f3Cf7ad23Cd3CaDbD9735AFf958023239c6A063",
+				TakerAsset:   "0x7ceb23fd6bc0add59e62ac25578270cff1b9f619",
+				MakingAmount: "1000000",
+				TakingAmount: "1000000000",
+				Maker:        predicateMaker.Hex(),
+				Taker:        "0x0000000000000000000000000000000000000000",
+			}, interactions)
+			require.NoError(t, err)
+
+			holds, err := CheckPredicate(context.Background(), ethClient, predicateRouter, orderData)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, holds)
+		})
+	}
+}
@@ -0,0 +1,269 @@
+package policy
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/1inch/1inch-sdk-go/client/models"
+	"github.com/1inch/1inch-sdk-go/helpers"
+	"github.com/1inch/1inch-sdk-go/helpers/consts/addresses"
+)
+
+// DailyWindow is the rolling period the daily USD limits of a policy apply to
+const DailyWindow = 24 * time.Hour
+
+// Engine evaluates summaries against a policy and keeps the trades it reserved to enforce the daily limits
+// The trades are only kept in memory, so the daily limits start over when the client is created again
+type Engine struct {
+	policy models.Policy
+	mu     sync.Mutex
+	trades map[int][]*trade
+}
+
+type trade struct {
+	at       time.Time
+	usdValue float64
+}
+
+// Reservation holds the USD value of an approved trade against the daily limit of its chain while the trade executes
+// It must end with Record once the trade may have gone through, or with Release when it certainly did not
+// A nil Reservation, returned for summaries that do not count towards a daily limit, ignores both
+type Reservation struct {
+	engine  *Engine
+	chainId int
+	trade   *trade
+	settled bool
+}
+
+func NewEngine(policy models.Policy) *Engine {
+	return &Engine{
+		policy: policy,
+		trades: make(map[int][]*trade),
+	}
+}
+
+// NeedsUsdValues reports whether the rules of a chain limit the USD value of trades
+func (e *Engine) NeedsUsdValues(chainId int) bool {
+	rules, ok := e.policy.Chains[chainId]
+	return ok && (rules.MaxTradeUsd > 0 || rules.MaxDailyUsd > 0)
+}
+
+// Evaluate checks a summary against the rules of its chain and returns a PolicyError listing every rule it breaks
+// Revocations and cancellations only reduce what the wallet exposes, so they are always allowed
+func (e *Engine) Evaluate(summary *models.Summary, now time.Time) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.evaluate(summary, now)
+}
+
+// Reserve evaluates a summary like Evaluate and, when it is allowed, reserves the USD value of the trade against the daily
+// limit of its chain in the same step, so trades running at the same time cannot exceed the limit together
+func (e *Engine) Reserve(summary *models.Summary, now time.Time) (*Reservation, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	err := e.evaluate(summary, now)
+	if err != nil {
+		return nil, err
+	}
+	if !isTrade(summary) || summary.Selling == nil || summary.Selling.UsdValue == nil {
+		return nil, nil
+	}
+	reserved := &trade{at: now, usdValue: *summary.Selling.UsdValue}
+	e.trades[summary.ChainId] = append(e.pruneTrades(summary.ChainId, now), reserved)
+	return &Reservation{engine: e, chainId: summary.ChainId, trade: reserved}, nil
+}
+
+// Record keeps the reserved value counted towards the daily limit, later calls to Release are ignored
+func (r *Reservation) Record() {
+	if r == nil {
+		return
+	}
+	r.engine.mu.Lock()
+	defer r.engine.mu.Unlock()
+	r.settled = true
+}
+
+// Release gives the reserved value back to the daily limit unless the reservation was recorded
+func (r *Reservation) Release() {
+	if r == nil {
+		return
+	}
+	r.engine.mu.Lock()
+	defer r.engine.mu.Unlock()
+	if r.settled {
+		return
+	}
+	r.settled = true
+	trades := r.engine.trades[r.chainId]
+	for i, reserved := range trades {
+		if reserved == r.trade {
+			r.engine.trades[r.chainId] = append(trades[:i:i], trades[i+1:]...)
+			return
+		}
+	}
+}
+
+// evaluate is Evaluate, it must be called with the lock held
+func (e *Engine) evaluate(summary *models.Summary, now time.Time) error {
+	switch summary.Kind {
+	case models.SummaryRevoke, models.SummaryCancelOrder, models.SummaryCancelAllOrders:
+		return nil
+	}
+
+	rules, ok := e.policy.Chains[summary.ChainId]
+	if !ok {
+		return &models.PolicyError{Kind: summary.Kind, Violations: []models.PolicyViolation{{
+			Rule:   models.PolicyRuleChain,
+			Reason: fmt.Sprintf("no rules for chain %d", summary.ChainId),
+		}}}
+	}
+
+	var violations []models.PolicyViolation
+	violations = append(violations, checkTokens(rules, summary)...)
+	if isTrade(summary) {
+		violations = append(violations, e.checkNotional(rules, summary, now)...)
+	}
+	if summary.Kind == models.SummarySwap && rules.MaxSlippage > 0 && summary.Slippage > rules.MaxSlippage {
+		violations = append(violations, models.PolicyViolation{
+			Rule:   models.PolicyRuleMaxSlippage,
+			Reason: fmt.Sprintf("slippage of %v%% exceeds the limit of %v%%", summary.Slippage, rules.MaxSlippage),
+		})
+	}
+	if summary.Kind != models.SummaryApproval {
+		receiver := summary.Receiver
+		if receiver == "" || strings.EqualFold(receiver, addresses.Zero) {
+			receiver = summary.Wallet
+		}
+		if !strings.EqualFold(receiver, summary.Wallet) && !containsAddress(rules.AllowedReceivers, receiver) {
+			violations = append(violations, models.PolicyViolation{
+				Rule:   models.PolicyRuleAllowedReceivers,
+				Reason: fmt.Sprintf("receiver %s is not allowed", receiver),
+			})
+		}
+	}
+	if summary.Kind == models.SummaryApproval && summary.Approval != nil {
+		violations = append(violations, checkApproval(rules, summary.Approval)...)
+	}
+
+	if len(violations) > 0 {
+		return &models.PolicyError{Kind: summary.Kind, Violations: violations}
+	}
+	return nil
+}
+
+// pruneTrades drops the trades of a chain that left the daily window, it must be called with the lock held
+func (e *Engine) pruneTrades(chainId int, now time.Time) []*trade {
+	trades := e.trades[chainId]
+	for len(trades) > 0 && now.Sub(trades[0].at) >= DailyWindow {
+		trades = trades[1:]
+	}
+	e.trades[chainId] = trades
+	return trades
+}
+
+// checkNotional checks the USD value of a trade against the limits of its chain, it must be called with the lock held
+func (e *Engine) checkNotional(rules models.ChainPolicy, summary *models.Summary, now time.Time) []models.PolicyViolation {
+	if rules.MaxTradeUsd == 0 && rules.MaxDailyUsd == 0 {
+		return nil
+	}
+	if summary.Selling == nil || summary.Selling.UsdValue == nil {
+		rule := models.PolicyRuleMaxTradeUsd
+		if rules.MaxTradeUsd == 0 {
+			rule = models.PolicyRuleMaxDailyUsd
+		}
+		return []models.PolicyViolation{{Rule: rule, Reason: "the USD value of the trade is unknown"}}
+	}
+
+	var violations []models.PolicyViolation
+	usdValue := *summary.Selling.UsdValue
+	if rules.MaxTradeUsd > 0 && usdValue > rules.MaxTradeUsd {
+		violations = append(violations, models.PolicyViolation{
+			Rule:   models.PolicyRuleMaxTradeUsd,
+			Reason: fmt.Sprintf("trade of $%.2f exceeds the limit of $%.2f", usdValue, rules.MaxTradeUsd),
+		})
+	}
+	if rules.MaxDailyUsd > 0 {
+		total := usdValue
+		for _, trade := range e.pruneTrades(summary.ChainId, now) {
+			total += trade.usdValue
+		}
+		if total > rules.MaxDailyUsd {
+			violations = append(violations, models.PolicyViolation{
+				Rule:   models.PolicyRuleMaxDailyUsd,
+				Reason: fmt.Sprintf("trade of $%.2f brings the volume of the last 24 hours to $%.2f, above the limit of $%.2f", usdValue, total, rules.MaxDailyUsd),
+			})
+		}
+	}
+	return violations
+}
+
+func checkTokens(rules models.ChainPolicy, summary *models.Summary) []models.PolicyViolation {
+	var violations []models.PolicyViolation
+	seen := make(map[string]bool)
+	for _, amount := range []*models.SummaryAmount{summary.Selling, summary.Buying, summary.Approval} {
+		if amount == nil || amount.Token == nil || seen[strings.ToLower(amount.Token.Address)] {
+			continue
+		}
+		seen[strings.ToLower(amount.Token.Address)] = true
+
+		if containsAddress(rules.DeniedTokens, amount.Token.Address) {
+			violations = append(violations, models.PolicyViolation{
+				Rule:   models.PolicyRuleDeniedTokens,
+				Reason: fmt.Sprintf("token %s (%s) is denied", amount.Token.Symbol, amount.Token.Address),
+			})
+		} else if len(rules.AllowedTokens) > 0 && !containsAddress(rules.AllowedTokens, amount.Token.Address) {
+			violations = append(violations, models.PolicyViolation{
+				Rule:   models.PolicyRuleAllowedTokens,
+				Reason: fmt.Sprintf("token %s (%s) is not allowed", amount.Token.Symbol, amount.Token.Address),
+			})
+		}
+	}
+	return violations
+}
+
+func checkApproval(rules models.ChainPolicy, approval *models.SummaryAmount) []models.PolicyViolation {
+	for token, approvalCap := range rules.ApprovalCaps {
+		if !strings.EqualFold(token, approval.Token.Address) {
+			continue
+		}
+		maxApproval, err := helpers.BigIntFromString(approvalCap)
+		if err != nil {
+			return []models.PolicyViolation{{
+				Rule:   models.PolicyRuleApprovalCaps,
+				Reason: fmt.Sprintf("approval cap of %s is invalid: %v", approval.Token.Symbol, err),
+			}}
+		}
+		if approval.Amount == nil || approval.Amount.Cmp(maxApproval) > 0 {
+			return []models.PolicyViolation{{
+				Rule: models.PolicyRuleApprovalCaps,
+				Reason: fmt.Sprintf("approval of %s exceeds the cap of %s %s", approval.String(),
+					helpers.SimplifyValue(maxApproval.String(), int(approval.Token.Decimals)), approval.Token.Symbol),
+			}}
+		}
+		return nil
+	}
+
+	if approval.Amount == nil && !rules.AllowUnlimitedApprovals {
+		return []models.PolicyViolation{{
+			Rule:   models.PolicyRuleApprovalCaps,
+			Reason: fmt.Sprintf("unlimited approvals of %s are not allowed", approval.Token.Symbol),
+		}}
+	}
+	return nil
+}
+
+func isTrade(summary *models.Summary) bool {
+	return summary.Kind == models.SummarySwap || summary.Kind == models.SummaryLimitOrder || summary.Kind == models.SummaryFillOrder
+}
+
+func containsAddress(list []string, address string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, address) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,248 @@
+package policy
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/1inch/1inch-sdk-go/client/models"
+	"github.com/1inch/1inch-sdk-go/helpers/consts/chains"
+)
+
+const (
+	wallet   = "0x1111111111111111111111111111111111111111"
+	treasury = "0x2222222222222222222222222222222222222222"
+)
+
+var (
+	usdc = &models.TokenInfo{Address: "0x3c499c542Cef5E3811e1192ce70d8cC03d5c3359", Symbol: "USDC", Decimals: 6}
+	weth = &models.TokenInfo{Address: "0x7ceB23fD6bC0adD59E62ac25578270cFf1b9f619", Symbol: "WETH", Decimals: 18}
+	pol  = &models.TokenInfo{Address: "0x0000000000000000000000000000000000001010", Symbol: "POL", Decimals: 18}
+)
+
+var testPolicy = models.Policy{
+	Chains: map[int]models.ChainPolicy{
+		chains.Polygon: {
+			AllowedTokens:    []string{"0x3c499c542cef5e3811e1192ce70d8cc03d5c3359", "0x7ceb23fd6bc0add59e62ac25578270cff1b9f619"},
+			DeniedTokens:     []string{"0x0000000000000000000000000000000000001010"},
+			MaxTradeUsd:      1000,
+			MaxDailyUsd:      1500,
+			MaxSlippage:      1,
+			AllowedReceivers: []string{treasury},
+			ApprovalCaps:     map[string]string{"0x3c499c542cef5e3811e1192ce70d8cc03d5c3359": "1000000000"},
+		},
+		chains.Ethereum: {
+			AllowUnlimitedApprovals: true,
+		},
+	},
+}
+
+func usdAmount(token *models.TokenInfo, amount int64, usdValue float64) *models.SummaryAmount {
+	return &models.SummaryAmount{Token: token, Amount: big.NewInt(amount), UsdValue: &usdValue}
+}
+
+func TestEvaluate(t *testing.T) {
+	testcases := []struct {
+		description        string
+		summary            *models.Summary
+		expectedViolations []models.PolicyViolation
+	}{
+		{
+			description: "Allowed swap",
+			summary: &models.Summary{
+				Kind:     models.SummarySwap,
+				ChainId:  chains.Polygon,
+				Wallet:   wallet,
+				Selling:  usdAmount(usdc, 500000000, 500),
+				Buying:   &models.SummaryAmount{Token: weth, Amount: big.NewInt(2e17)},
+				Receiver: treasury,
+				Slippage: 0.5,
+			},
+		},
+		{
+			description: "Swap breaking every rule",
+			summary: &models.Summary{
+				Kind:     models.SummarySwap,
+				ChainId:  chains.Polygon,
+				Wallet:   wallet,
+				Selling:  usdAmount(pol, 3e18, 2000),
+				Buying:   &models.SummaryAmount{Token: &models.TokenInfo{Address: "0x9999999999999999999999999999999999999999", Symbol: "SCAM"}},
+				Receiver: "0x3333333333333333333333333333333333333333",
+				Slippage: 5,
+			},
+			expectedViolations: []models.PolicyViolation{
+				{Rule: models.PolicyRuleDeniedTokens, Reason: "token POL (0x0000000000000000000000000000000000001010) is denied"},
+				{Rule: models.PolicyRuleAllowedTokens, Reason: "token SCAM (0x9999999999999999999999999999999999999999) is not allowed"},
+				{Rule: models.PolicyRuleMaxTradeUsd, Reason: "trade of $2000.00 exceeds the limit of $1000.00"},
+				{Rule: models.PolicyRuleMaxDailyUsd, Reason: "trade of $2000.00 brings the volume of the last 24 hours to $2000.00, above the limit of $1500.00"},
+				{Rule: models.PolicyRuleMaxSlippage, Reason: "slippage of 5% exceeds the limit of 1%"},
+				{Rule: models.PolicyRuleAllowedReceivers, Reason: "receiver 0x3333333333333333333333333333333333333333 is not allowed"},
+			},
+		},
+		{
+			description: "Limit order without USD value",
+			summary: &models.Summary{
+				Kind:     models.SummaryLimitOrder,
+				ChainId:  chains.Polygon,
+				Wallet:   wallet,
+				Selling:  &models.SummaryAmount{Token: weth, Amount: big.NewInt(1e18)},
+				Buying:   &models.SummaryAmount{Token: usdc, Amount: big.NewInt(3000000000)},
+				Receiver: "0x0000000000000000000000000000000000000000",
+			},
+			expectedViolations: []models.PolicyViolation{
+				{Rule: models.PolicyRuleMaxTradeUsd, Reason: "the USD value of the trade is unknown"},
+			},
+		},
+		{
+			description: "Approval above cap",
+			summary: &models.Summary{
+				Kind:     models.SummaryApproval,
+				ChainId:  chains.Polygon,
+				Wallet:   wallet,
+				Approval: &models.SummaryAmount{Token: usdc, Amount: big.NewInt(2000000000)},
+			},
+			expectedViolations: []models.PolicyViolation{
+				{Rule: models.PolicyRuleApprovalCaps, Reason: "approval of 2000 USDC exceeds the cap of 1000 USDC"},
+			},
+		},
+		{
+			description: "Unlimited approval without cap",
+			summary: &models.Summary{
+				Kind:     models.SummaryApproval,
+				ChainId:  chains.Polygon,
+				Wallet:   wallet,
+				Approval: &models.SummaryAmount{Token: weth},
+			},
+			expectedViolations: []models.PolicyViolation{
+				{Rule: models.PolicyRuleApprovalCaps, Reason: "unlimited approvals of WETH are not allowed"},
+			},
+		},
+		{
+			description: "Unlimited approval allowed",
+			summary: &models.Summary{
+				Kind:     models.SummaryApproval,
+				ChainId:  chains.Ethereum,
+				Wallet:   wallet,
+				Approval: &models.SummaryAmount{Token: weth},
+			},
+		},
+		{
+			description: "Wrap on chain without rules",
+			summary: &models.Summary{
+				Kind:    models.SummaryWrap,
+				ChainId: chains.Arbitrum,
+				Wallet:  wallet,
+			},
+			expectedViolations: []models.PolicyViolation{
+				{Rule: models.PolicyRuleChain, Reason: "no rules for chain 42161"},
+			},
+		},
+		{
+			description: "Revocation on chain without rules",
+			summary: &models.Summary{
+				Kind:    models.SummaryRevoke,
+				ChainId: chains.Arbitrum,
+				Wallet:  wallet,
+			},
+		},
+		{
+			description: "Cancellation on chain without rules",
+			summary: &models.Summary{
+				Kind:    models.SummaryCancelAllOrders,
+				ChainId: chains.Arbitrum,
+				Wallet:  wallet,
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := NewEngine(testPolicy).Evaluate(tc.summary, time.Now())
+			if len(tc.expectedViolations) == 0 {
+				require.NoError(t, err)
+				return
+			}
+			var policyError *models.PolicyError
+			require.True(t, errors.As(err, &policyError))
+			require.Equal(t, tc.summary.Kind, policyError.Kind)
+			require.Equal(t, tc.expectedViolations, policyError.Violations)
+		})
+	}
+}
+
+func TestDailyLimit(t *testing.T) {
+	engine := NewEngine(testPolicy)
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	trade := func(usdValue float64) *models.Summary {
+		return &models.Summary{Kind: models.SummarySwap, ChainId: chains.Polygon, Wallet: wallet, Selling: usdAmount(usdc, 1, usdValue)}
+	}
+
+	reservation, err := engine.Reserve(trade(900), start)
+	require.NoError(t, err)
+	reservation.Record()
+	reservation, err = engine.Reserve(trade(600), start.Add(time.Hour))
+	require.NoError(t, err)
+	reservation.Record()
+	// Releasing a recorded trade leaves it counted
+	reservation.Release()
+
+	require.EqualError(t, engine.Evaluate(trade(100), start.Add(2*time.Hour)),
+		"swap denied by policy: trade of $100.00 brings the volume of the last 24 hours to $1600.00, above the limit of $1500.00 (maxDailyUsd)")
+
+	// The first trade leaves the window a day after it was made
+	require.NoError(t, engine.Evaluate(trade(100), start.Add(DailyWindow)))
+}
+
+func TestDailyLimitReservations(t *testing.T) {
+	engine := NewEngine(testPolicy)
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	trade := func(usdValue float64) *models.Summary {
+		return &models.Summary{Kind: models.SummarySwap, ChainId: chains.Polygon, Wallet: wallet, Selling: usdAmount(usdc, 1, usdValue)}
+	}
+
+	// A pending trade holds its value until it is settled
+	pending, err := engine.Reserve(trade(1000), now)
+	require.NoError(t, err)
+	_, err = engine.Reserve(trade(600), now)
+	require.Error(t, err)
+
+	// A trade that did not go through gives its value back
+	pending.Release()
+	pending.Release()
+	reservation, err := engine.Reserve(trade(600), now)
+	require.NoError(t, err)
+	reservation.Record()
+	require.NoError(t, engine.Evaluate(trade(900), now))
+
+	// Summaries that are not trades reserve nothing
+	reservation, err = engine.Reserve(&models.Summary{Kind: models.SummaryWrap, ChainId: chains.Polygon, Wallet: wallet}, now)
+	require.NoError(t, err)
+	require.Nil(t, reservation)
+	reservation.Release()
+}
+
+func TestDailyLimitConcurrentReservations(t *testing.T) {
+	engine := NewEngine(testPolicy)
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// Each trade passes the limit alone, but only two of them fit in it together
+	var wg sync.WaitGroup
+	var approved atomic.Int32
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := engine.Reserve(&models.Summary{Kind: models.SummarySwap, ChainId: chains.Polygon, Wallet: wallet, Selling: usdAmount(usdc, 1, 700)}, now)
+			if err == nil {
+				approved.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	require.Equal(t, int32(2), approved.Load())
+}
@@ -0,0 +1,190 @@
+package safe
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	"github.com/1inch/1inch-sdk-go/helpers/consts/abis"
+)
+
+type Operation uint8
+
+const (
+	Call         Operation = 0
+	DelegateCall Operation = 1
+)
+
+var (
+	// Type hashes used by Safe contracts since v1.3.0
+	domainSeparatorTypeHash = crypto.Keccak256Hash([]byte("EIP712Domain(uint256 chainId,address verifyingContract)"))
+	safeTxTypeHash          = crypto.Keccak256Hash([]byte("SafeTx(address to,uint256 value,bytes data,uint8 operation,uint256 safeTxGas,uint256 baseGas,uint256 gasPrice,address gasToken,address refundReceiver,uint256 nonce)"))
+)
+
+// SafeCall is a single call made by a Safe
+type SafeCall struct {
+	To    common.Address
+	Value *big.Int
+	Data  []byte
+}
+
+// SafeTx is a transaction executed by a Safe once enough owners signed its hash
+// Gas refunds are not used, so SafeTxGas, BaseGas, GasPrice, GasToken and RefundReceiver are left at zero by NewSafeTx
+type SafeTx struct {
+	To             common.Address
+	Value          *big.Int
+	Data           []byte
+	Operation      Operation
+	SafeTxGas      *big.Int
+	BaseGas        *big.Int
+	GasPrice       *big.Int
+	GasToken       common.Address
+	RefundReceiver common.Address
+	Nonce          *big.Int
+}
+
+// NewSafeTx returns a Safe transaction making all the calls
+// A single call is made directly, several calls are batched into a delegate call to the MultiSendCallOnly contract
+func NewSafeTx(calls []SafeCall, nonce *big.Int, multiSendCallOnly common.Address) (*SafeTx, error) {
+	if len(calls) == 0 {
+		return nil, fmt.Errorf("no calls to make")
+	}
+
+	safeTx := &SafeTx{
+		Operation: Call,
+		SafeTxGas: big.NewInt(0),
+		BaseGas:   big.NewInt(0),
+		GasPrice:  big.NewInt(0),
+		Nonce:     nonce,
+	}
+
+	if len(calls) == 1 {
+		safeTx.To = calls[0].To
+		safeTx.Value = valueOrZero(calls[0].Value)
+		safeTx.Data = calls[0].Data
+		return safeTx, nil
+	}
+
+	data, err := GetMultiSendCalldata(calls)
+	if err != nil {
+		return nil, err
+	}
+	safeTx.To = multiSendCallOnly
+	safeTx.Value = big.NewInt(0)
+	safeTx.Data = data
+	safeTx.Operation = DelegateCall
+	return safeTx, nil
+}
+
+// GetMultiSendCalldata returns the calldata of multiSend for the calls
+// Each call is packed as operation (1 byte), to (20 bytes), value (32 bytes), data length (32 bytes) and data
+// MultiSendCallOnly only accepts plain calls, so the operation is always Call
+func GetMultiSendCalldata(calls []SafeCall) ([]byte, error) {
+	var packed bytes.Buffer
+	for _, call := range calls {
+		packed.WriteByte(byte(Call))
+		packed.Write(call.To.Bytes())
+		packed.Write(math.U256Bytes(new(big.Int).Set(valueOrZero(call.Value))))
+		var length [32]byte
+		binary.BigEndian.PutUint64(length[24:], uint64(len(call.Data)))
+		packed.Write(length[:])
+		packed.Write(call.Data)
+	}
+
+	parsedABI, err := abi.JSON(strings.NewReader(abis.MultiSend))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse multisend abi: %v", err)
+	}
+
+	data, err := parsedABI.Pack("multiSend", packed.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack multisend calldata: %v", err)
+	}
+	return data, nil
+}
+
+// Hash returns the EIP-712 safeTxHash that the owners of the Safe sign
+func (tx *SafeTx) Hash(chainId *big.Int, safeAddress common.Address) common.Hash {
+	domainSeparator := crypto.Keccak256Hash(
+		domainSeparatorTypeHash.Bytes(),
+		math.U256Bytes(new(big.Int).Set(chainId)),
+		common.LeftPadBytes(safeAddress.Bytes(), 32),
+	)
+
+	structHash := crypto.Keccak256Hash(
+		safeTxTypeHash.Bytes(),
+		common.LeftPadBytes(tx.To.Bytes(), 32),
+		math.U256Bytes(new(big.Int).Set(valueOrZero(tx.Value))),
+		crypto.Keccak256(tx.Data),
+		common.LeftPadBytes([]byte{byte(tx.Operation)}, 32),
+		math.U256Bytes(new(big.Int).Set(valueOrZero(tx.SafeTxGas))),
+		math.U256Bytes(new(big.Int).Set(valueOrZero(tx.BaseGas))),
+		math.U256Bytes(new(big.Int).Set(valueOrZero(tx.GasPrice))),
+		common.LeftPadBytes(tx.GasToken.Bytes(), 32),
+		common.LeftPadBytes(tx.RefundReceiver.Bytes(), 32),
+		math.U256Bytes(new(big.Int).Set(valueOrZero(tx.Nonce))),
+	)
+
+	return crypto.Keccak256Hash([]byte{0x19, 0x01}, domainSeparator.Bytes(), structHash.Bytes())
+}
+
+// SignSafeTxHash signs a safeTxHash as an owner of the Safe
+// Safe contracts expect the recovery id of ECDSA owner signatures to be 27 or 28
+func SignSafeTxHash(safeTxHash common.Hash, privateKey string) ([]byte, error) {
+	key, err := crypto.HexToECDSA(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert private key: %v", err)
+	}
+
+	signature, err := crypto.Sign(safeTxHash.Bytes(), key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign safe transaction hash: %v", err)
+	}
+	signature[64] += 27
+	return signature, nil
+}
+
+// ReadSafeNonce returns the nonce of the next transaction the Safe will execute
+func ReadSafeNonce(ctx context.Context, client *ethclient.Client, safeAddress common.Address) (*big.Int, error) {
+	parsedABI, err := abi.JSON(strings.NewReader(abis.Safe))
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := parsedABI.Pack("nonce")
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := client.CallContract(ctx, ethereum.CallMsg{To: &safeAddress, Data: data}, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("%s is not a safe", safeAddress.Hex())
+	}
+
+	var nonce *big.Int
+	err = parsedABI.UnpackIntoInterface(&nonce, "nonce", result)
+	if err != nil {
+		return nil, err
+	}
+	return nonce, nil
+}
+
+func valueOrZero(value *big.Int) *big.Int {
+	if value == nil {
+		return big.NewInt(0)
+	}
+	return value
+}
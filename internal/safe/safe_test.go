@@ -0,0 +1,258 @@
+package safe
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/stretchr/testify/require"
+)
+
+const testKey = "4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318"
+
+var (
+	testSafe      = common.HexToAddress("0x2a5f0c2c4d3c38e0b0d2b3f8b0d4d8a0f1e6c9b7")
+	testToken     = common.HexToAddress("0x6b175474e89094c44da98b954eedeac495271d0f")
+	testRouter    = common.HexToAddress("0x1111111254eeb25477b68fb85ed929f73a960582")
+	testMultiSend = common.HexToAddress("0x40A2aCCbd92BCA938b02010E17A5b8929b49130D")
+)
+
+func TestSafeTxTypeHash(t *testing.T) {
+	require.Equal(t, "0xbb8310d486368db6bd6f849402fdd73ad53d316b5a4b2644ad6efe0f941286d8", safeTxTypeHash.Hex())
+	require.Equal(t, "0x47e79534a245952e8b16893a336b85a3d9ea9fa8c573f3d803afb92a79469218", domainSeparatorTypeHash.Hex())
+}
+
+func TestNewSafeTx(t *testing.T) {
+	approveData := hexutil.MustDecode("0x095ea7b30000000000000000000000001111111254eeb25477b68fb85ed929f73a960582ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	swapData := hexutil.MustDecode("0x12aa3caf")
+
+	testcases := []struct {
+		description       string
+		calls             []SafeCall
+		expectedTo        common.Address
+		expectedOperation Operation
+		expectedError     string
+	}{
+		{
+			description:       "Single call is made directly",
+			calls:             []SafeCall{{To: testToken, Data: approveData}},
+			expectedTo:        testToken,
+			expectedOperation: Call,
+		},
+		{
+			description: "Several calls are batched through MultiSendCallOnly",
+			calls: []SafeCall{
+				{To: testToken, Data: approveData},
+				{To: testRouter, Value: big.NewInt(5), Data: swapData},
+			},
+			expectedTo:        testMultiSend,
+			expectedOperation: DelegateCall,
+		},
+		{
+			description:   "Error - no calls",
+			expectedError: "no calls to make",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.description, func(t *testing.T) {
+			safeTx, err := NewSafeTx(tc.calls, big.NewInt(3), testMultiSend)
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedTo, safeTx.To)
+			require.Equal(t, tc.expectedOperation, safeTx.Operation)
+			require.Equal(t, int64(3), safeTx.Nonce.Int64())
+			require.Equal(t, int64(0), safeTx.Value.Int64())
+		})
+	}
+}
+
+func TestGetMultiSendCalldata(t *testing.T) {
+	calls := []SafeCall{
+		{To: testToken, Data: []byte{0xaa, 0xbb}},
+		{To: testRouter, Value: big.NewInt(5), Data: []byte{0xcc}},
+	}
+
+	data, err := GetMultiSendCalldata(calls)
+	require.NoError(t, err)
+
+	// multiSend(bytes), then the offset and the length of the packed transactions
+	require.Equal(t, "8d80ff0a", common.Bytes2Hex(data[:4]))
+	packedLength := new(big.Int).SetBytes(data[36:68]).Int64()
+	require.Equal(t, int64(2*(1+20+32+32)+2+1), packedLength)
+
+	packed := data[68 : 68+packedLength]
+	first := packed[:1+20+32+32+2]
+	require.Equal(t, byte(Call), first[0])
+	require.Equal(t, testToken.Bytes(), first[1:21])
+	require.Equal(t, int64(0), new(big.Int).SetBytes(first[21:53]).Int64())
+	require.Equal(t, int64(2), new(big.Int).SetBytes(first[53:85]).Int64())
+	require.Equal(t, []byte{0xaa, 0xbb}, first[85:])
+
+	second := packed[len(first):]
+	require.Equal(t, byte(Call), second[0])
+	require.Equal(t, testRouter.Bytes(), second[1:21])
+	require.Equal(t, int64(5), new(big.Int).SetBytes(second[21:53]).Int64())
+	require.Equal(t, int64(1), new(big.Int).SetBytes(second[53:85]).Int64())
+	require.Equal(t, []byte{0xcc}, second[85:])
+}
+
+func TestSafeTxHash(t *testing.T) {
+	safeTx := &SafeTx{
+		To:             testMultiSend,
+		Value:          big.NewInt(0),
+		Data:           hexutil.MustDecode("0x8d80ff0a1234"),
+		Operation:      DelegateCall,
+		SafeTxGas:      big.NewInt(0),
+		BaseGas:        big.NewInt(0),
+		GasPrice:       big.NewInt(0),
+		RefundReceiver: common.Address{},
+		Nonce:          big.NewInt(42),
+	}
+
+	// The hash has to match a generic EIP-712 encoder fed with the SafeTx types
+	typedData := apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": {
+				{Name: "chainId", Type: "uint256"},
+				{Name: "verifyingContract", Type: "address"},
+			},
+			"SafeTx": {
+				{Name: "to", Type: "address"},
+				{Name: "value", Type: "uint256"},
+				{Name: "data", Type: "bytes"},
+				{Name: "operation", Type: "uint8"},
+				{Name: "safeTxGas", Type: "uint256"},
+				{Name: "baseGas", Type: "uint256"},
+				{Name: "gasPrice", Type: "uint256"},
+				{Name: "gasToken", Type: "address"},
+				{Name: "refundReceiver", Type: "address"},
+				{Name: "nonce", Type: "uint256"},
+			},
+		},
+		PrimaryType: "SafeTx",
+		Domain: apitypes.TypedDataDomain{
+			ChainId:           (*math.HexOrDecimal256)(big.NewInt(137)),
+			VerifyingContract: testSafe.Hex(),
+		},
+		Message: apitypes.TypedDataMessage{
+			"to":             safeTx.To.Hex(),
+			"value":          "0",
+			"data":           safeTx.Data,
+			"operation":      "1",
+			"safeTxGas":      "0",
+			"baseGas":        "0",
+			"gasPrice":       "0",
+			"gasToken":       common.Address{}.Hex(),
+			"refundReceiver": common.Address{}.Hex(),
+			"nonce":          "42",
+		},
+	}
+	expectedHash, _, err := apitypes.TypedDataAndHash(typedData)
+	require.NoError(t, err)
+
+	require.Equal(t, common.BytesToHash(expectedHash), safeTx.Hash(big.NewInt(137), testSafe))
+	require.NotEqual(t, safeTx.Hash(big.NewInt(1), testSafe), safeTx.Hash(big.NewInt(137), testSafe))
+}
+
+func TestSignSafeTxHash(t *testing.T) {
+	hash := crypto.Keccak256Hash([]byte("safe transaction"))
+	signature, err := SignSafeTxHash(hash, testKey)
+	require.NoError(t, err)
+	require.Len(t, signature, 65)
+	require.Contains(t, []byte{27, 28}, signature[64])
+
+	recoverable := append([]byte{}, signature...)
+	recoverable[64] -= 27
+	publicKey, err := crypto.SigToPub(hash.Bytes(), recoverable)
+	require.NoError(t, err)
+
+	key, err := crypto.HexToECDSA(testKey)
+	require.NoError(t, err)
+	require.Equal(t, crypto.PubkeyToAddress(key.PublicKey), crypto.PubkeyToAddress(*publicKey))
+}
+
+func TestProposeTransaction(t *testing.T) {
+	safeTx, err := NewSafeTx([]SafeCall{{To: testToken, Data: []byte{0x01}}}, big.NewInt(7), testMultiSend)
+	require.NoError(t, err)
+	safeTxHash := safeTx.Hash(big.NewInt(1), testSafe)
+	request := NewProposeTransactionRequest(safeTx, safeTxHash, testRouter, []byte{0x02}, "1inch SDK")
+
+	testcases := []struct {
+		description   string
+		statusCode    int
+		expectedError string
+	}{
+		{
+			description: "Proposal accepted",
+			statusCode:  http.StatusCreated,
+		},
+		{
+			description:   "Error - proposal rejected",
+			statusCode:    http.StatusUnprocessableEntity,
+			expectedError: "transaction service rejected the proposal - response code: 422 - response body: {\"nonFieldErrors\":[\"invalid signature\"]}",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.description, func(t *testing.T) {
+			var received ProposeTransactionRequest
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, http.MethodPost, r.Method)
+				require.Equal(t, "/api/v1/safes/"+testSafe.Hex()+"/multisig-transactions/", r.URL.Path)
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+				w.WriteHeader(tc.statusCode)
+				if tc.statusCode != http.StatusCreated {
+					_, _ = w.Write([]byte(`{"nonFieldErrors":["invalid signature"]}`))
+				}
+			}))
+			defer server.Close()
+
+			err := ProposeTransaction(context.Background(), server.Client(), server.URL+"/", testSafe, request)
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, request, received)
+			require.Equal(t, "7", received.Nonce)
+			require.Equal(t, safeTxHash.Hex(), received.ContractTransactionHash)
+		})
+	}
+}
+
+func TestNewTransactionBuilderBatch(t *testing.T) {
+	batch := NewTransactionBuilderBatch(big.NewInt(1), testSafe, "Swap", "", 1700000000000, []SafeCall{
+		{To: testToken, Data: []byte{0xaa}},
+		{To: testRouter, Value: big.NewInt(5), Data: []byte{0xbb}},
+	})
+
+	encoded, err := json.Marshal(batch)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"version": "1.0",
+		"chainId": "1",
+		"createdAt": 1700000000000,
+		"meta": {
+			"name": "Swap",
+			"description": "",
+			"txBuilderVersion": "1.16.5",
+			"createdFromSafeAddress": "`+testSafe.Hex()+`"
+		},
+		"transactions": [
+			{"to": "`+testToken.Hex()+`", "value": "0", "data": "0xaa", "contractMethod": null, "contractInputsValues": null},
+			{"to": "`+testRouter.Hex()+`", "value": "5", "data": "0xbb", "contractMethod": null, "contractInputsValues": null}
+		]
+	}`, string(encoded))
+}
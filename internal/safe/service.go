@@ -0,0 +1,144 @@
+package safe
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const transactionBuilderVersion = "1.16.5"
+
+// TransactionBuilderBatch is the batch file format imported by the Transaction Builder app of the Safe interface
+type TransactionBuilderBatch struct {
+	Version      string                          `json:"version"`
+	ChainId      string                          `json:"chainId"`
+	CreatedAt    int64                           `json:"createdAt"`
+	Meta         TransactionBuilderMeta          `json:"meta"`
+	Transactions []TransactionBuilderTransaction `json:"transactions"`
+}
+
+type TransactionBuilderMeta struct {
+	Name                   string `json:"name"`
+	Description            string `json:"description"`
+	TxBuilderVersion       string `json:"txBuilderVersion"`
+	CreatedFromSafeAddress string `json:"createdFromSafeAddress"`
+}
+
+type TransactionBuilderTransaction struct {
+	To                   string      `json:"to"`
+	Value                string      `json:"value"`
+	Data                 string      `json:"data"`
+	ContractMethod       interface{} `json:"contractMethod"`
+	ContractInputsValues interface{} `json:"contractInputsValues"`
+}
+
+// NewTransactionBuilderBatch returns the calls as a Transaction Builder batch, the app batches them through MultiSend on its own
+func NewTransactionBuilderBatch(chainId *big.Int, safeAddress common.Address, name string, description string, createdAt int64, calls []SafeCall) TransactionBuilderBatch {
+	transactions := make([]TransactionBuilderTransaction, 0, len(calls))
+	for _, call := range calls {
+		transactions = append(transactions, TransactionBuilderTransaction{
+			To:    call.To.Hex(),
+			Value: valueOrZero(call.Value).String(),
+			Data:  hexutil.Encode(call.Data),
+		})
+	}
+
+	return TransactionBuilderBatch{
+		Version:   "1.0",
+		ChainId:   chainId.String(),
+		CreatedAt: createdAt,
+		Meta: TransactionBuilderMeta{
+			Name:                   name,
+			Description:            description,
+			TxBuilderVersion:       transactionBuilderVersion,
+			CreatedFromSafeAddress: safeAddress.Hex(),
+		},
+		Transactions: transactions,
+	}
+}
+
+// ProposeTransactionRequest is the body of a multisig transaction proposal to the Safe Transaction Service
+type ProposeTransactionRequest struct {
+	To                      string `json:"to"`
+	Value                   string `json:"value"`
+	Data                    string `json:"data"`
+	Operation               uint8  `json:"operation"`
+	SafeTxGas               string `json:"safeTxGas"`
+	BaseGas                 string `json:"baseGas"`
+	GasPrice                string `json:"gasPrice"`
+	GasToken                string `json:"gasToken"`
+	RefundReceiver          string `json:"refundReceiver"`
+	Nonce                   string `json:"nonce"`
+	ContractTransactionHash string `json:"contractTransactionHash"`
+	Sender                  string `json:"sender"`
+	Signature               string `json:"signature"`
+	Origin                  string `json:"origin,omitempty"`
+}
+
+// NewProposeTransactionRequest returns the proposal of a Safe transaction signed by one of the owners
+func NewProposeTransactionRequest(tx *SafeTx, safeTxHash common.Hash, sender common.Address, signature []byte, origin string) ProposeTransactionRequest {
+	return ProposeTransactionRequest{
+		To:                      tx.To.Hex(),
+		Value:                   valueOrZero(tx.Value).String(),
+		Data:                    hexutil.Encode(tx.Data),
+		Operation:               uint8(tx.Operation),
+		SafeTxGas:               valueOrZero(tx.SafeTxGas).String(),
+		BaseGas:                 valueOrZero(tx.BaseGas).String(),
+		GasPrice:                valueOrZero(tx.GasPrice).String(),
+		GasToken:                tx.GasToken.Hex(),
+		RefundReceiver:          tx.RefundReceiver.Hex(),
+		Nonce:                   valueOrZero(tx.Nonce).String(),
+		ContractTransactionHash: safeTxHash.Hex(),
+		Sender:                  sender.Hex(),
+		Signature:               hexutil.Encode(signature),
+		Origin:                  origin,
+	}
+}
+
+// ProposeTransaction posts a signed Safe transaction to a Safe Transaction Service so the other owners can confirm it
+// serviceUrl is the root of the service (e.g. https://safe-transaction-mainnet.safe.global)
+func ProposeTransaction(ctx context.Context, httpClient *http.Client, serviceUrl string, safeAddress common.Address, request ProposeTransactionRequest) error {
+	base, err := url.Parse(strings.TrimSuffix(serviceUrl, "/"))
+	if err != nil {
+		return fmt.Errorf("failed to parse transaction service url: %v", err)
+	}
+	base.Path += fmt.Sprintf("/api/v1/safes/%s/multisig-transactions/", safeAddress.Hex())
+
+	requestMarshaled, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("failed to marshal proposal: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, base.String(), bytes.NewReader(requestMarshaled))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post proposal: %v", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		data, err := io.ReadAll(res.Body)
+		if err != nil {
+			return fmt.Errorf("failed to read response body: %v", err)
+		}
+		return fmt.Errorf("transaction service rejected the proposal - response code: %d - response body: %s", res.StatusCode, strings.TrimSpace(string(data)))
+	}
+	return nil
+}
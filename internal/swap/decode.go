@@ -0,0 +1,174 @@
+package swap
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/1inch/1inch-sdk-go/client/models"
+	"github.com/1inch/1inch-sdk-go/helpers/consts/abis"
+)
+
+// Flag bits set on the SwapDescription of generic router swaps
+const (
+	swapFlagPartialFillBit      = 0
+	swapFlagRequiresExtraEthBit = 1
+)
+
+// Flag bits set on the pools of unoswap and uniswapV3Swap routes
+const (
+	unoswapReverseBit = 255
+	unoswapWethBit    = 254
+	uniswapV3ZeroBit  = 255
+	uniswapV3WethBit  = 253
+)
+
+var poolAddressMask = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 160), big.NewInt(1))
+
+type swapDescription struct {
+	SrcToken        common.Address
+	DstToken        common.Address
+	SrcReceiver     common.Address
+	DstReceiver     common.Address
+	Amount          *big.Int
+	MinReturnAmount *big.Int
+	Flags           *big.Int
+}
+
+// DecodeSwapCalldata parses the calldata of an AggregationRouterV5 swap transaction
+func DecodeSwapCalldata(calldata []byte) (*models.DecodedSwap, error) {
+	if len(calldata) < 4 {
+		return nil, errors.New("calldata is too short to contain a method selector")
+	}
+
+	parsedABI, err := abi.JSON(strings.NewReader(abis.AggregationRouterV5))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ABI: %v", err)
+	}
+
+	method, err := parsedABI.MethodById(calldata[:4])
+	if err != nil {
+		return nil, fmt.Errorf("unrecognized method selector 0x%x: %v", calldata[:4], err)
+	}
+
+	args := make(map[string]interface{})
+	err = method.Inputs.UnpackIntoMap(args, calldata[4:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpack %s calldata: %v", method.Name, err)
+	}
+
+	decoded := &models.DecodedSwap{
+		Method: method.Name,
+	}
+
+	switch method.Name {
+	case "swap":
+		desc, ok := abi.ConvertType(args["desc"], new(swapDescription)).(*swapDescription)
+		if !ok {
+			return nil, errors.New("failed to convert swap description")
+		}
+		decoded.Executor = addressArg(args, "executor")
+		decoded.SrcToken = desc.SrcToken.Hex()
+		decoded.DstToken = desc.DstToken.Hex()
+		decoded.SrcReceiver = desc.SrcReceiver.Hex()
+		decoded.Receiver = desc.DstReceiver.Hex()
+		decoded.Amount = desc.Amount
+		decoded.MinReturn = desc.MinReturnAmount
+		decoded.Flags = desc.Flags
+		decoded.PartialFill = desc.Flags.Bit(swapFlagPartialFillBit) == 1
+		decoded.RequiresExtraEth = desc.Flags.Bit(swapFlagRequiresExtraEthBit) == 1
+		decoded.Permit = bytesArg(args, "permit")
+		decoded.ExecutorData = bytesArg(args, "data")
+	case "unoswap", "unoswapTo", "unoswapToWithPermit":
+		decoded.SrcToken = addressArg(args, "srcToken")
+		decoded.Receiver = addressArg(args, "recipient")
+		decoded.Amount = bigIntArg(args, "amount")
+		decoded.MinReturn = bigIntArg(args, "minReturn")
+		decoded.Pools = decodeUnoswapPools(bigIntSliceArg(args, "pools"))
+		decoded.Permit = bytesArg(args, "permit")
+	case "uniswapV3Swap", "uniswapV3SwapTo", "uniswapV3SwapToWithPermit":
+		decoded.SrcToken = addressArg(args, "srcToken")
+		decoded.Receiver = addressArg(args, "recipient")
+		decoded.Amount = bigIntArg(args, "amount")
+		decoded.MinReturn = bigIntArg(args, "minReturn")
+		decoded.Pools = decodeUniswapV3Pools(bigIntSliceArg(args, "pools"))
+		decoded.Permit = bytesArg(args, "permit")
+	case "clipperSwap", "clipperSwapTo", "clipperSwapToWithPermit":
+		decoded.Executor = addressArg(args, "clipperExchange")
+		decoded.SrcToken = addressArg(args, "srcToken")
+		decoded.DstToken = addressArg(args, "dstToken")
+		decoded.Receiver = addressArg(args, "recipient")
+		decoded.Amount = bigIntArg(args, "inputAmount")
+		decoded.MinReturn = bigIntArg(args, "outputAmount")
+		decoded.Permit = bytesArg(args, "permit")
+	default:
+		return nil, fmt.Errorf("method %s is not a supported swap method", method.Name)
+	}
+
+	return decoded, nil
+}
+
+func decodeUnoswapPools(pools []*big.Int) []models.DecodedPool {
+	decodedPools := make([]models.DecodedPool, 0, len(pools))
+	for _, pool := range pools {
+		numerator := new(big.Int).Rsh(pool, 160)
+		numerator.And(numerator, big.NewInt(0xffffffff))
+		decodedPools = append(decodedPools, models.DecodedPool{
+			Address:    common.BigToAddress(new(big.Int).And(pool, poolAddressMask)).Hex(),
+			Raw:        pool,
+			Reverse:    pool.Bit(unoswapReverseBit) == 1,
+			UnwrapWeth: pool.Bit(unoswapWethBit) == 1,
+			Numerator:  uint32(numerator.Uint64()),
+		})
+	}
+	return decodedPools
+}
+
+func decodeUniswapV3Pools(pools []*big.Int) []models.DecodedPool {
+	decodedPools := make([]models.DecodedPool, 0, len(pools))
+	for _, pool := range pools {
+		decodedPools = append(decodedPools, models.DecodedPool{
+			Address:    common.BigToAddress(new(big.Int).And(pool, poolAddressMask)).Hex(),
+			Raw:        pool,
+			Reverse:    pool.Bit(uniswapV3ZeroBit) == 1,
+			UnwrapWeth: pool.Bit(uniswapV3WethBit) == 1,
+		})
+	}
+	return decodedPools
+}
+
+func addressArg(args map[string]interface{}, name string) string {
+	value, ok := args[name].(common.Address)
+	if !ok {
+		return ""
+	}
+	return value.Hex()
+}
+
+func bigIntArg(args map[string]interface{}, name string) *big.Int {
+	value, ok := args[name].(*big.Int)
+	if !ok {
+		return nil
+	}
+	return value
+}
+
+func bigIntSliceArg(args map[string]interface{}, name string) []*big.Int {
+	value, ok := args[name].([]*big.Int)
+	if !ok {
+		return nil
+	}
+	return value
+}
+
+func bytesArg(args map[string]interface{}, name string) []byte {
+	value, ok := args[name].([]byte)
+	if !ok {
+		return nil
+	}
+	return value
+}
@@ -0,0 +1,176 @@
+package swap
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/1inch/1inch-sdk-go/helpers/consts/abis"
+	"github.com/1inch/1inch-sdk-go/helpers/consts/tokens"
+)
+
+func TestDecodeSwapCalldata(t *testing.T) {
+	parsedABI, err := abi.JSON(strings.NewReader(abis.AggregationRouterV5))
+	require.NoError(t, err)
+
+	executor := common.HexToAddress("0xe37e799d5077682fa0a244d46e5649f71457bd09")
+	wallet := common.HexToAddress("0x2a250893f86Dc8497E131508f680338ac647B498")
+	srcToken := common.HexToAddress(tokens.PolygonDai)
+	dstToken := common.HexToAddress(tokens.PolygonUsdc)
+	amount := big.NewInt(10000000000000000)
+	minReturn := big.NewInt(9950)
+
+	pair := common.HexToAddress("0x4a35582a710e1f4b2030a3f826da20bfb6703c09")
+	unoswapPool := new(big.Int).SetBytes(pair.Bytes())
+	unoswapPool.Or(unoswapPool, new(big.Int).Lsh(big.NewInt(997), 160))
+	unoswapPool.SetBit(unoswapPool, 255, 1)
+	unoswapPool.SetBit(unoswapPool, 254, 1)
+
+	v3Pool := new(big.Int).SetBytes(pair.Bytes())
+	v3Pool.SetBit(v3Pool, 253, 1)
+
+	pack := func(method string, args ...interface{}) []byte {
+		data, err := parsedABI.Pack(method, args...)
+		require.NoError(t, err)
+		return data
+	}
+
+	testcases := []struct {
+		description   string
+		calldata      []byte
+		expectedError string
+		method        string
+		srcToken      string
+		dstToken      string
+		receiver      string
+		executor      string
+		poolCount     int
+	}{
+		{
+			description: "swap",
+			calldata: pack("swap", executor, swapDescription{
+				SrcToken:        srcToken,
+				DstToken:        dstToken,
+				SrcReceiver:     executor,
+				DstReceiver:     wallet,
+				Amount:          amount,
+				MinReturnAmount: minReturn,
+				Flags:           big.NewInt(3),
+			}, []byte{}, []byte{0x01, 0x02}),
+			method:   "swap",
+			srcToken: srcToken.Hex(),
+			dstToken: dstToken.Hex(),
+			receiver: wallet.Hex(),
+			executor: executor.Hex(),
+		},
+		{
+			description: "unoswap",
+			calldata:    pack("unoswap", srcToken, amount, minReturn, []*big.Int{unoswapPool}),
+			method:      "unoswap",
+			srcToken:    srcToken.Hex(),
+			poolCount:   1,
+		},
+		{
+			description: "unoswapTo",
+			calldata:    pack("unoswapTo", wallet, srcToken, amount, minReturn, []*big.Int{unoswapPool}),
+			method:      "unoswapTo",
+			srcToken:    srcToken.Hex(),
+			receiver:    wallet.Hex(),
+			poolCount:   1,
+		},
+		{
+			description: "uniswapV3Swap",
+			calldata:    pack("uniswapV3Swap", amount, minReturn, []*big.Int{v3Pool, v3Pool}),
+			method:      "uniswapV3Swap",
+			poolCount:   2,
+		},
+		{
+			description: "uniswapV3SwapToWithPermit",
+			calldata:    pack("uniswapV3SwapToWithPermit", wallet, srcToken, amount, minReturn, []*big.Int{v3Pool}, []byte{0xaa}),
+			method:      "uniswapV3SwapToWithPermit",
+			srcToken:    srcToken.Hex(),
+			receiver:    wallet.Hex(),
+			poolCount:   1,
+		},
+		{
+			description: "clipperSwapTo",
+			calldata:    pack("clipperSwapTo", executor, wallet, srcToken, dstToken, amount, minReturn, big.NewInt(1704250835), [32]byte{}, [32]byte{}),
+			method:      "clipperSwapTo",
+			srcToken:    srcToken.Hex(),
+			dstToken:    dstToken.Hex(),
+			receiver:    wallet.Hex(),
+			executor:    executor.Hex(),
+		},
+		{
+			description:   "Error - not a swap method",
+			calldata:      pack("increaseNonce"),
+			expectedError: "method increaseNonce is not a supported swap method",
+		},
+		{
+			description:   "Error - calldata too short",
+			calldata:      []byte{0x12},
+			expectedError: "calldata is too short to contain a method selector",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.description, func(t *testing.T) {
+			decoded, err := DecodeSwapCalldata(tc.calldata)
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				require.Equal(t, tc.expectedError, err.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.method, decoded.Method)
+			require.Equal(t, tc.srcToken, decoded.SrcToken)
+			require.Equal(t, tc.dstToken, decoded.DstToken)
+			require.Equal(t, tc.receiver, decoded.Receiver)
+			require.Equal(t, tc.executor, decoded.Executor)
+			require.Equal(t, amount, decoded.Amount)
+			require.Equal(t, minReturn, decoded.MinReturn)
+			require.Len(t, decoded.Pools, tc.poolCount)
+		})
+	}
+}
+
+func TestDecodeSwapCalldataFlagsAndPools(t *testing.T) {
+	parsedABI, err := abi.JSON(strings.NewReader(abis.AggregationRouterV5))
+	require.NoError(t, err)
+
+	pair := common.HexToAddress("0x4a35582a710e1f4b2030a3f826da20bfb6703c09")
+	pool := new(big.Int).SetBytes(pair.Bytes())
+	pool.Or(pool, new(big.Int).Lsh(big.NewInt(997000000), 160))
+	pool.SetBit(pool, 255, 1)
+	pool.SetBit(pool, 254, 1)
+
+	calldata, err := parsedABI.Pack("unoswap", common.HexToAddress(tokens.PolygonDai), big.NewInt(1), big.NewInt(1), []*big.Int{pool})
+	require.NoError(t, err)
+
+	decoded, err := DecodeSwapCalldata(calldata)
+	require.NoError(t, err)
+	require.Equal(t, pair.Hex(), decoded.Pools[0].Address)
+	require.True(t, decoded.Pools[0].Reverse)
+	require.True(t, decoded.Pools[0].UnwrapWeth)
+	require.Equal(t, uint32(997000000), decoded.Pools[0].Numerator)
+
+	calldata, err = parsedABI.Pack("swap", common.HexToAddress(tokens.PolygonDai), swapDescription{
+		SrcToken:        common.HexToAddress(tokens.PolygonDai),
+		DstToken:        common.HexToAddress(tokens.PolygonUsdc),
+		SrcReceiver:     common.HexToAddress(tokens.PolygonDai),
+		DstReceiver:     common.HexToAddress(tokens.PolygonDai),
+		Amount:          big.NewInt(1),
+		MinReturnAmount: big.NewInt(1),
+		Flags:           big.NewInt(2),
+	}, []byte{}, []byte{})
+	require.NoError(t, err)
+
+	decoded, err = DecodeSwapCalldata(calldata)
+	require.NoError(t, err)
+	require.False(t, decoded.PartialFill)
+	require.True(t, decoded.RequiresExtraEth)
+}
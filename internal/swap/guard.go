@@ -0,0 +1,140 @@
+package swap
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+
+	"github.com/1inch/1inch-sdk-go/client/models"
+	"github.com/1inch/1inch-sdk-go/helpers"
+	"github.com/1inch/1inch-sdk-go/helpers/consts/addresses"
+	"github.com/1inch/1inch-sdk-go/helpers/consts/tokens"
+	"github.com/1inch/1inch-sdk-go/internal/onchain"
+)
+
+const slippageBasisPoints = 10000
+
+// GuardSwapTransaction verifies that the swap transaction data returned by the API matches the swap that was requested
+// It is run before any signing happens to protect against compromised API responses
+func GuardSwapTransaction(config *models.ExecuteSwapConfig, aggregationRouter string) (*models.DecodedSwap, error) {
+
+	if config.TransactionTo == "" {
+		return nil, errors.New("transaction to is required to verify the router of the swap")
+	}
+	if !strings.EqualFold(config.TransactionTo, aggregationRouter) {
+		return nil, &models.SwapGuardError{
+			Violation: models.SwapGuardViolationRouter,
+			Expected:  aggregationRouter,
+			Actual:    config.TransactionTo,
+		}
+	}
+
+	hexData, err := hex.DecodeString(onchain.Remove0xPrefix(config.TransactionData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode swap data: %v", err)
+	}
+
+	decoded, err := DecodeSwapCalldata(hexData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode swap calldata: %v", err)
+	}
+
+	if !isAllowedReceiver(decoded.Receiver, config.PublicAddress, config.AllowedReceivers) {
+		return nil, &models.SwapGuardError{
+			Violation: models.SwapGuardViolationReceiver,
+			Expected:  strings.Join(append([]string{config.PublicAddress}, config.AllowedReceivers...), ", "),
+			Actual:    decoded.Receiver,
+		}
+	}
+
+	amount, err := helpers.BigIntFromString(config.Amount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert amount to big.Int: %v", err)
+	}
+	if decoded.Amount == nil || decoded.Amount.Cmp(amount) != 0 {
+		return nil, &models.SwapGuardError{
+			Violation: models.SwapGuardViolationAmount,
+			Expected:  amount.String(),
+			Actual:    fmt.Sprintf("%v", decoded.Amount),
+		}
+	}
+
+	if config.EstimatedAmountOut == "" {
+		return nil, errors.New("estimated amount out is required to verify the minimum return of the swap")
+	}
+	estimatedAmountOut, err := helpers.BigIntFromString(config.EstimatedAmountOut)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert estimated amount out to big.Int: %v", err)
+	}
+	minReturn := GetMinReturn(estimatedAmountOut, config.Slippage)
+	if decoded.MinReturn == nil || decoded.MinReturn.Cmp(minReturn) < 0 {
+		return nil, &models.SwapGuardError{
+			Violation: models.SwapGuardViolationMinReturn,
+			Expected:  fmt.Sprintf("at least %s", minReturn.String()),
+			Actual:    fmt.Sprintf("%v", decoded.MinReturn),
+		}
+	}
+
+	if config.TransactionValue == "" {
+		return nil, errors.New("transaction value is required to verify the native amount sent with the swap")
+	}
+	expectedValue := big.NewInt(0)
+	if config.FromToken != nil && config.FromToken.Address == tokens.NativeToken {
+		expectedValue = amount
+	}
+	value, err := helpers.BigIntFromString(config.TransactionValue)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert transaction value to big.Int: %v", err)
+	}
+	if value.Cmp(expectedValue) != 0 {
+		return nil, &models.SwapGuardError{
+			Violation: models.SwapGuardViolationValue,
+			Expected:  expectedValue.String(),
+			Actual:    value.String(),
+		}
+	}
+
+	return decoded, nil
+}
+
+// GetMinReturn returns the lowest output amount a swap can accept given the estimated output and a slippage percentage
+// One unit of rounding tolerance is allowed to account for the API rounding the slippage calculation
+func GetMinReturn(estimatedAmountOut *big.Int, slippage float32) *big.Int {
+	slippageBps := int64(math.Round(float64(slippage) * 100))
+	minReturn := new(big.Int).Mul(estimatedAmountOut, big.NewInt(slippageBasisPoints-slippageBps))
+	minReturn.Div(minReturn, big.NewInt(slippageBasisPoints))
+	if minReturn.Sign() > 0 {
+		minReturn.Sub(minReturn, big.NewInt(1))
+	}
+	return minReturn
+}
+
+// GetRealizedSlippage returns how far below the estimated output amount, in percent, the amount received by a swap is
+// The slippage is negative when more than the estimation was received
+func GetRealizedSlippage(estimatedAmountOut *big.Int, amountReceived *big.Int) float64 {
+	if estimatedAmountOut.Sign() == 0 {
+		return 0
+	}
+	shortfall := new(big.Float).SetInt(new(big.Int).Sub(estimatedAmountOut, amountReceived))
+	slippage, _ := new(big.Float).Quo(shortfall.Mul(shortfall, big.NewFloat(100)), new(big.Float).SetInt(estimatedAmountOut)).Float64()
+	return slippage
+}
+
+func isAllowedReceiver(receiver string, publicAddress string, allowedReceivers []string) bool {
+	// An empty or zero receiver means the router sends the output to the transaction sender
+	if receiver == "" || strings.EqualFold(receiver, addresses.Zero) {
+		return true
+	}
+	if strings.EqualFold(receiver, publicAddress) {
+		return true
+	}
+	for _, allowedReceiver := range allowedReceivers {
+		if strings.EqualFold(receiver, allowedReceiver) {
+			return true
+		}
+	}
+	return false
+}
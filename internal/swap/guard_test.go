@@ -0,0 +1,271 @@
+package swap
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/1inch/1inch-sdk-go/client/models"
+	"github.com/1inch/1inch-sdk-go/helpers/consts/abis"
+	"github.com/1inch/1inch-sdk-go/helpers/consts/contracts"
+	"github.com/1inch/1inch-sdk-go/helpers/consts/tokens"
+)
+
+func TestGuardSwapTransaction(t *testing.T) {
+	parsedABI, err := abi.JSON(strings.NewReader(abis.AggregationRouterV5))
+	require.NoError(t, err)
+
+	wallet := "0x2a250893f86Dc8497E131508f680338ac647B498"
+	otherWallet := "0x50c5df26654b5efbdd0c54a062dfa6012933defe"
+	executor := common.HexToAddress("0xe37e799d5077682fa0a244d46e5649f71457bd09")
+
+	swapCalldata := func(srcToken string, receiver string, amount int64, minReturn int64) string {
+		data, err := parsedABI.Pack("swap", executor, swapDescription{
+			SrcToken:        common.HexToAddress(srcToken),
+			DstToken:        common.HexToAddress(tokens.PolygonUsdc),
+			SrcReceiver:     executor,
+			DstReceiver:     common.HexToAddress(receiver),
+			Amount:          big.NewInt(amount),
+			MinReturnAmount: big.NewInt(minReturn),
+			Flags:           big.NewInt(0),
+		}, []byte{}, []byte{})
+		require.NoError(t, err)
+		return fmt.Sprintf("0x%x", data)
+	}
+
+	testcases := []struct {
+		description       string
+		config            models.ExecuteSwapConfig
+		expectedViolation models.SwapGuardViolation
+		expectedError     string
+	}{
+		{
+			description: "Valid ERC20 swap",
+			config: models.ExecuteSwapConfig{
+				PublicAddress:      wallet,
+				FromToken:          &models.TokenInfo{Address: tokens.PolygonDai},
+				Amount:             "1000",
+				Slippage:           1,
+				EstimatedAmountOut: "2000",
+				TransactionData:    swapCalldata(tokens.PolygonDai, wallet, 1000, 1980),
+				TransactionTo:      contracts.AggregationRouterV5,
+				TransactionValue:   "0",
+			},
+		},
+		{
+			description: "Valid native swap to an allowed receiver",
+			config: models.ExecuteSwapConfig{
+				PublicAddress:      wallet,
+				FromToken:          &models.TokenInfo{Address: tokens.NativeToken},
+				Amount:             "1000",
+				Slippage:           0.5,
+				EstimatedAmountOut: "2000",
+				TransactionData:    swapCalldata(tokens.NativeToken, otherWallet, 1000, 1990),
+				TransactionTo:      contracts.AggregationRouterV5,
+				TransactionValue:   "1000",
+				AllowedReceivers:   []string{otherWallet},
+			},
+		},
+		{
+			description: "Error - unknown router",
+			config: models.ExecuteSwapConfig{
+				PublicAddress:      wallet,
+				FromToken:          &models.TokenInfo{Address: tokens.PolygonDai},
+				Amount:             "1000",
+				Slippage:           1,
+				EstimatedAmountOut: "2000",
+				TransactionData:    swapCalldata(tokens.PolygonDai, wallet, 1000, 1980),
+				TransactionTo:      otherWallet,
+				TransactionValue:   "0",
+			},
+			expectedViolation: models.SwapGuardViolationRouter,
+		},
+		{
+			description: "Error - receiver is not the wallet",
+			config: models.ExecuteSwapConfig{
+				PublicAddress:      wallet,
+				FromToken:          &models.TokenInfo{Address: tokens.PolygonDai},
+				Amount:             "1000",
+				Slippage:           1,
+				EstimatedAmountOut: "2000",
+				TransactionData:    swapCalldata(tokens.PolygonDai, otherWallet, 1000, 1980),
+				TransactionTo:      contracts.AggregationRouterV5,
+				TransactionValue:   "0",
+			},
+			expectedViolation: models.SwapGuardViolationReceiver,
+		},
+		{
+			description: "Error - amount does not match",
+			config: models.ExecuteSwapConfig{
+				PublicAddress:      wallet,
+				FromToken:          &models.TokenInfo{Address: tokens.PolygonDai},
+				Amount:             "1000",
+				Slippage:           1,
+				EstimatedAmountOut: "2000",
+				TransactionData:    swapCalldata(tokens.PolygonDai, wallet, 5000, 1980),
+				TransactionTo:      contracts.AggregationRouterV5,
+				TransactionValue:   "0",
+			},
+			expectedViolation: models.SwapGuardViolationAmount,
+		},
+		{
+			description: "Error - min return below slippage",
+			config: models.ExecuteSwapConfig{
+				PublicAddress:      wallet,
+				FromToken:          &models.TokenInfo{Address: tokens.PolygonDai},
+				Amount:             "1000",
+				Slippage:           1,
+				EstimatedAmountOut: "2000",
+				TransactionData:    swapCalldata(tokens.PolygonDai, wallet, 1000, 1000),
+				TransactionTo:      contracts.AggregationRouterV5,
+				TransactionValue:   "0",
+			},
+			expectedViolation: models.SwapGuardViolationMinReturn,
+		},
+		{
+			description: "Error - value sent with ERC20 swap",
+			config: models.ExecuteSwapConfig{
+				PublicAddress:      wallet,
+				FromToken:          &models.TokenInfo{Address: tokens.PolygonDai},
+				Amount:             "1000",
+				Slippage:           1,
+				EstimatedAmountOut: "2000",
+				TransactionData:    swapCalldata(tokens.PolygonDai, wallet, 1000, 1980),
+				TransactionTo:      contracts.AggregationRouterV5,
+				TransactionValue:   "1000",
+			},
+			expectedViolation: models.SwapGuardViolationValue,
+		},
+		{
+			description: "Error - native swap value does not match amount",
+			config: models.ExecuteSwapConfig{
+				PublicAddress:      wallet,
+				FromToken:          &models.TokenInfo{Address: tokens.NativeToken},
+				Amount:             "1000",
+				Slippage:           1,
+				EstimatedAmountOut: "2000",
+				TransactionData:    swapCalldata(tokens.NativeToken, wallet, 1000, 1980),
+				TransactionTo:      contracts.AggregationRouterV5,
+				TransactionValue:   "1",
+			},
+			expectedViolation: models.SwapGuardViolationValue,
+		},
+		{
+			description: "Error - missing router address",
+			config: models.ExecuteSwapConfig{
+				PublicAddress:      wallet,
+				FromToken:          &models.TokenInfo{Address: tokens.PolygonDai},
+				Amount:             "1000",
+				Slippage:           1,
+				EstimatedAmountOut: "2000",
+				TransactionData:    swapCalldata(tokens.PolygonDai, wallet, 1000, 1980),
+				TransactionValue:   "0",
+			},
+			expectedError: "transaction to is required to verify the router of the swap",
+		},
+		{
+			description: "Error - missing value",
+			config: models.ExecuteSwapConfig{
+				PublicAddress:      wallet,
+				FromToken:          &models.TokenInfo{Address: tokens.NativeToken},
+				Amount:             "1000",
+				Slippage:           1,
+				EstimatedAmountOut: "2000",
+				TransactionData:    swapCalldata(tokens.NativeToken, wallet, 1000, 1980),
+				TransactionTo:      contracts.AggregationRouterV5,
+			},
+			expectedError: "transaction value is required to verify the native amount sent with the swap",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.description, func(t *testing.T) {
+			_, err := GuardSwapTransaction(&tc.config, contracts.AggregationRouterV5)
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			if tc.expectedViolation == "" {
+				require.NoError(t, err)
+				return
+			}
+			var guardErr *models.SwapGuardError
+			require.True(t, errors.As(err, &guardErr), "expected a SwapGuardError, got %v", err)
+			require.Equal(t, tc.expectedViolation, guardErr.Violation)
+		})
+	}
+}
+
+func TestGetMinReturn(t *testing.T) {
+	testcases := []struct {
+		description        string
+		estimatedAmountOut *big.Int
+		slippage           float32
+		expected           *big.Int
+	}{
+		{
+			description:        "One percent",
+			estimatedAmountOut: big.NewInt(10000),
+			slippage:           1,
+			expected:           big.NewInt(9899),
+		},
+		{
+			description:        "Half percent",
+			estimatedAmountOut: big.NewInt(10000),
+			slippage:           0.5,
+			expected:           big.NewInt(9949),
+		},
+		{
+			description:        "Zero output",
+			estimatedAmountOut: big.NewInt(0),
+			slippage:           1,
+			expected:           big.NewInt(0),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.description, func(t *testing.T) {
+			require.Equal(t, tc.expected, GetMinReturn(tc.estimatedAmountOut, tc.slippage))
+		})
+	}
+}
+
+func TestGetRealizedSlippage(t *testing.T) {
+	testcases := []struct {
+		description        string
+		estimatedAmountOut *big.Int
+		amountReceived     *big.Int
+		expected           float64
+	}{
+		{
+			description:        "Received less than estimated",
+			estimatedAmountOut: big.NewInt(10000),
+			amountReceived:     big.NewInt(9950),
+			expected:           0.5,
+		},
+		{
+			description:        "Received more than estimated",
+			estimatedAmountOut: big.NewInt(10000),
+			amountReceived:     big.NewInt(10100),
+			expected:           -1,
+		},
+		{
+			description:        "Zero estimation",
+			estimatedAmountOut: big.NewInt(0),
+			amountReceived:     big.NewInt(5),
+			expected:           0,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.description, func(t *testing.T) {
+			require.InDelta(t, tc.expected, GetRealizedSlippage(tc.estimatedAmountOut, tc.amountReceived), 1e-9)
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package swap
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/1inch/1inch-sdk-go/client/models"
+	"github.com/1inch/1inch-sdk-go/helpers"
+)
+
+// GetPriceImpact returns how much worse, in percent, the rate of a swap is than the rate of a reference quote for a small
+// amount of the same tokens. It is negative when the swap gets the better rate
+func GetPriceImpact(amount *big.Int, amountOut *big.Int, referenceAmount *big.Int, referenceAmountOut *big.Int) float64 {
+	if amount.Sign() == 0 || referenceAmount.Sign() == 0 || referenceAmountOut.Sign() == 0 {
+		return 0
+	}
+
+	// rate / referenceRate = (amountOut * referenceAmount) / (amount * referenceAmountOut)
+	numerator := new(big.Float).SetInt(new(big.Int).Mul(amountOut, referenceAmount))
+	denominator := new(big.Float).SetInt(new(big.Int).Mul(amount, referenceAmountOut))
+	ratio, _ := new(big.Float).Quo(numerator, denominator).Float64()
+	return (1 - ratio) * 100
+}
+
+// CheckSwapLimits returns a SwapLimitError for the first limit the estimated cost of a swap exceeds
+func CheckSwapLimits(limits models.SwapLimits, estimate *models.SwapCostEstimate) error {
+	maxFeeNative, err := helpers.BigIntFromString(limits.MaxFeeNative)
+	if err != nil {
+		return fmt.Errorf("failed to convert max native fee to big.Int: %v", err)
+	}
+	if estimate.GasCost.Cmp(maxFeeNative) > 0 {
+		return &models.SwapLimitError{
+			Limit:  models.SwapLimitFeeNative,
+			Max:    limits.MaxFeeNative,
+			Actual: estimate.GasCost.String(),
+		}
+	}
+
+	maxFeeQuote, err := helpers.BigIntFromString(limits.MaxFeeQuote)
+	if err != nil {
+		return fmt.Errorf("failed to convert max quote fee to big.Int: %v", err)
+	}
+	if estimate.GasCostQuote.Cmp(maxFeeQuote) > 0 {
+		return &models.SwapLimitError{
+			Limit:  models.SwapLimitFeeQuote,
+			Max:    limits.MaxFeeQuote,
+			Actual: estimate.GasCostQuote.String(),
+		}
+	}
+
+	if estimate.PriceImpact > float64(limits.MaxPriceImpact) {
+		return &models.SwapLimitError{
+			Limit:  models.SwapLimitPriceImpact,
+			Max:    fmt.Sprintf("%v%%", limits.MaxPriceImpact),
+			Actual: fmt.Sprintf("%.2f%%", estimate.PriceImpact),
+		}
+	}
+	return nil
+}
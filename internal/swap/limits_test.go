@@ -0,0 +1,108 @@
+package swap
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/1inch/1inch-sdk-go/client/models"
+)
+
+func TestGetPriceImpact(t *testing.T) {
+	testcases := []struct {
+		description        string
+		amount             *big.Int
+		amountOut          *big.Int
+		referenceAmount    *big.Int
+		referenceAmountOut *big.Int
+		expected           float64
+	}{
+		{
+			description:        "Worse rate than the reference quote",
+			amount:             big.NewInt(1000000),
+			amountOut:          big.NewInt(1960000),
+			referenceAmount:    big.NewInt(1000),
+			referenceAmountOut: big.NewInt(2000),
+			expected:           2,
+		},
+		{
+			description:        "Same rate as the reference quote",
+			amount:             big.NewInt(1000000),
+			amountOut:          big.NewInt(2000000),
+			referenceAmount:    big.NewInt(1000),
+			referenceAmountOut: big.NewInt(2000),
+			expected:           0,
+		},
+		{
+			description:        "Better rate than the reference quote",
+			amount:             big.NewInt(1000000),
+			amountOut:          big.NewInt(2020000),
+			referenceAmount:    big.NewInt(1000),
+			referenceAmountOut: big.NewInt(2000),
+			expected:           -1,
+		},
+		{
+			description:        "Empty reference quote",
+			amount:             big.NewInt(1000000),
+			amountOut:          big.NewInt(2000000),
+			referenceAmount:    big.NewInt(1000),
+			referenceAmountOut: big.NewInt(0),
+			expected:           0,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.description, func(t *testing.T) {
+			require.InDelta(t, tc.expected, GetPriceImpact(tc.amount, tc.amountOut, tc.referenceAmount, tc.referenceAmountOut), 1e-9)
+		})
+	}
+}
+
+func TestCheckSwapLimits(t *testing.T) {
+	limits := models.SwapLimits{
+		MaxFeeNative:   "1000",
+		MaxFeeQuote:    "50",
+		QuoteToken:     "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48",
+		MaxPriceImpact: 1.5,
+	}
+
+	testcases := []struct {
+		description   string
+		estimate      *models.SwapCostEstimate
+		expectedError string
+	}{
+		{
+			description: "Within every limit",
+			estimate:    &models.SwapCostEstimate{GasCost: big.NewInt(1000), GasCostQuote: big.NewInt(50), PriceImpact: 1.5},
+		},
+		{
+			description:   "Error - native fee above the limit",
+			estimate:      &models.SwapCostEstimate{GasCost: big.NewInt(1001), GasCostQuote: big.NewInt(50), PriceImpact: 0},
+			expectedError: "swap exceeds maxFeeNative: limit is 1000, estimated 1001",
+		},
+		{
+			description:   "Error - quote fee above the limit",
+			estimate:      &models.SwapCostEstimate{GasCost: big.NewInt(900), GasCostQuote: big.NewInt(51), PriceImpact: 0},
+			expectedError: "swap exceeds maxFeeQuote: limit is 50, estimated 51",
+		},
+		{
+			description:   "Error - price impact above the limit",
+			estimate:      &models.SwapCostEstimate{GasCost: big.NewInt(900), GasCostQuote: big.NewInt(40), PriceImpact: 2.25},
+			expectedError: "swap exceeds maxPriceImpact: limit is 1.5%, estimated 2.25%",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := CheckSwapLimits(limits, tc.estimate)
+			if tc.expectedError != "" {
+				var limitErr *models.SwapLimitError
+				require.ErrorAs(t, err, &limitErr)
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
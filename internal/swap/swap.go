@@ -8,31 +8,31 @@ import (
 	"strings"
 
 	"github.com/1inch/1inch-sdk-go/client/models"
-	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/ethclient"
-
 	"github.com/1inch/1inch-sdk-go/helpers"
-	"github.com/1inch/1inch-sdk-go/internal/onchain"
 )
 
-func ConfirmExecuteSwapWithUser(config *models.ExecuteSwapConfig) (bool, error) {
+// ConfirmExecuteSwapWithUser prints the summary of a swap and asks the user to confirm it in the terminal
+func ConfirmExecuteSwapWithUser(summary *models.Summary) (bool, error) {
 	stdOut := helpers.StdOutPrinter{}
-	return confirmExecuteSwapWithUser(config, os.Stdin, stdOut)
+	return confirmExecuteSwapWithUser(summary, os.Stdin, stdOut)
 }
 
-func confirmExecuteSwapWithUser(config *models.ExecuteSwapConfig, reader io.Reader, writer helpers.Printer) (bool, error) {
-	var permitType string
-	if config.IsPermitSwap {
-		permitType = "Permit1"
-	} else {
-		permitType = "Contract approval"
-	}
-
+func confirmExecuteSwapWithUser(summary *models.Summary, reader io.Reader, writer helpers.Printer) (bool, error) {
 	writer.Printf("Swap summary:\n")
-	writer.Printf("    %-30s %s %s\n", "Selling: ", helpers.SimplifyValue(config.Amount, int(config.FromToken.Decimals)), config.FromToken.Symbol)
-	writer.Printf("    %-30s %s %s\n", "Buying (estimation):", helpers.SimplifyValue(config.EstimatedAmountOut, int(config.ToToken.Decimals)), config.ToToken.Symbol)
-	writer.Printf("    %-30s %v%s\n", "Slippage:", config.Slippage, "%")
-	writer.Printf("    %-30s %s\n", "Permision type:", permitType)
+	writer.Printf("    %-30s %s\n", "Selling: ", summary.Selling)
+	writer.Printf("    %-30s %s\n", "Buying (estimation):", summary.Buying)
+	writer.Printf("    %-30s %v%s\n", "Slippage:", summary.Slippage, "%")
+	writer.Printf("    %-30s %s\n", "Permision type:", summary.ApprovalType)
+	if summary.GasCost != nil {
+		gasCost := summary.GasCost.String()
+		if summary.GasCostQuote != nil {
+			gasCost += fmt.Sprintf(" (~%s)", summary.GasCostQuote)
+		}
+		writer.Printf("    %-30s %s\n", "Estimated gas cost:", gasCost)
+	}
+	if summary.PriceImpact != nil {
+		writer.Printf("    %-30s %.2f%s\n", "Price impact:", *summary.PriceImpact, "%")
+	}
 	writer.Printf("\n")
 	writer.Printf("WARNING: This swap will be executed onchain next. The results are irreversible. Make sure the proposed trade looks correct before continuing!\n")
 	writer.Printf("Would you like to execute this swap onchain now? [y/N]: ")
@@ -65,26 +65,24 @@ func confirmSwapDataWithUser(swapResponse *models.SwapResponse, fromAmount strin
 	return nil
 }
 
-func ConfirmApprovalWithUser(ethClient *ethclient.Client, publicAddress string, tokenAddress string) (bool, error) {
+// ConfirmApprovalWithUser prints the summary of the approval a swap needs and asks the user to confirm it in the terminal
+func ConfirmApprovalWithUser(summary *models.Summary) (bool, error) {
 	stdOut := helpers.StdOutPrinter{}
-	return confirmApprovalWithUser(ethClient, publicAddress, tokenAddress, os.Stdin, stdOut)
+	return confirmApprovalWithUser(summary, os.Stdin, stdOut)
 }
 
-func confirmApprovalWithUser(ethClient *ethclient.Client, publicAddress string, tokenAddress string, reader io.Reader, writer helpers.Printer) (bool, error) {
-	tokenName, err := onchain.ReadContractSymbol(ethClient, common.HexToAddress(tokenAddress))
-	if err != nil {
-		return false, fmt.Errorf("failed to read name: %v", err)
-	}
+func confirmApprovalWithUser(summary *models.Summary, reader io.Reader, writer helpers.Printer) (bool, error) {
+	approvalAmountDisplay := summary.Approval.String()
 
-	writer.Printf("The aggregator contract does not have enough allowance to execute this swap! The SDK can give an " +
-		"unlimited approval on your behalf. If you would like to use custom approval amount instead, do that manually " +
-		"onchain, then run the SDK again\n")
+	writer.Printf("The aggregator contract does not have enough allowance to execute this swap! The SDK can post an " +
+		"approval on your behalf using the approval policy of the request. If you would like to approve a different amount " +
+		"instead, change the approval policy or do that manually onchain, then run the SDK again\n")
 	writer.Printf("Approval summary:\n")
-	writer.Printf("    %-30s %s\n", "Wallet:", publicAddress)
-	writer.Printf("    %-30s %s\n", "Swapping: ", tokenName)
-	writer.Printf("    %-30s %s\n", "Approval amount: ", "unlimited")
+	writer.Printf("    %-30s %s\n", "Wallet:", summary.Wallet)
+	writer.Printf("    %-30s %s\n", "Swapping: ", summary.Approval.Token.Symbol)
+	writer.Printf("    %-30s %s\n", "Approval amount: ", approvalAmountDisplay)
 	writer.Printf("\n")
-	writer.Printf("Would you like post an onchain unlimited approval now? [y/N]: ")
+	writer.Printf("Would you like post an onchain %s approval now? [y/N]: ", approvalAmountDisplay)
 
 	inputReader := bufio.NewReader(reader)
 	input, _ := inputReader.ReadString('\n')
@@ -0,0 +1,87 @@
+package swap
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/1inch/1inch-sdk-go/client/models"
+)
+
+type recordingPrinter struct {
+	output strings.Builder
+}
+
+func (p *recordingPrinter) Printf(format string, a ...interface{}) {
+	p.output.WriteString(fmt.Sprintf(format, a...))
+}
+
+func TestConfirmExecuteSwapWithUser(t *testing.T) {
+	weth := &models.TokenInfo{Symbol: "WETH", Decimals: 18}
+	usdc := &models.TokenInfo{Symbol: "USDC", Decimals: 6}
+	priceImpact := 0.4321
+	usdValue := 3000.0
+
+	testcases := []struct {
+		description     string
+		userInput       string
+		summary         *models.Summary
+		expectedResult  bool
+		expectedLines   []string
+		unexpectedLines []string
+	}{
+		{
+			description:    "Summary shows the estimated gas cost and price impact",
+			userInput:      "y\n",
+			expectedResult: true,
+			summary: &models.Summary{
+				Kind:         models.SummarySwap,
+				Selling:      &models.SummaryAmount{Token: weth, Amount: big.NewInt(1e18), UsdValue: &usdValue},
+				Buying:       &models.SummaryAmount{Token: usdc, Amount: big.NewInt(2000000000)},
+				Slippage:     0.5,
+				ApprovalType: "Contract approval",
+				GasCost:      &models.SummaryAmount{Token: &models.TokenInfo{Symbol: "ETH", Decimals: 18}, Amount: big.NewInt(3500000000000000)},
+				GasCostQuote: &models.SummaryAmount{Token: usdc, Amount: big.NewInt(7250000)},
+				PriceImpact:  &priceImpact,
+			},
+			expectedLines: []string{
+				"1 WETH (~$3000.00)",
+				"2000 USDC",
+				"0.0035 ETH (~7.25 USDC)",
+				"0.43%",
+			},
+		},
+		{
+			description:    "Summary without gas cost",
+			userInput:      "n\n",
+			expectedResult: false,
+			summary: &models.Summary{
+				Kind:         models.SummarySwap,
+				Selling:      &models.SummaryAmount{Token: weth, Amount: big.NewInt(1e18)},
+				Buying:       &models.SummaryAmount{Token: usdc, Amount: big.NewInt(2000000000)},
+				Slippage:     0.5,
+				ApprovalType: "Permit1",
+			},
+			unexpectedLines: []string{"Estimated gas cost:", "Price impact:"},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.description, func(t *testing.T) {
+			writer := &recordingPrinter{}
+			result, err := confirmExecuteSwapWithUser(tc.summary, bytes.NewBufferString(tc.userInput), writer)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedResult, result)
+			for _, line := range tc.expectedLines {
+				require.Contains(t, writer.output.String(), line)
+			}
+			for _, line := range tc.unexpectedLines {
+				require.NotContains(t, writer.output.String(), line)
+			}
+		})
+	}
+}
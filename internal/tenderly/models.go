@@ -1,6 +1,9 @@
 package tenderly
 
-import "time"
+import (
+	"math/big"
+	"time"
+)
 
 type contextKey int
 
@@ -22,6 +25,7 @@ type SwapConfig struct {
 	Value           string
 	TransactionData string
 	ApproveFirst    bool
+	ApprovalAmount  *big.Int
 }
 
 type RunConfiguration struct {
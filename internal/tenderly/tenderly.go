@@ -32,8 +32,12 @@ func SimulateSwap(config SwapConfig) (*SimulationResponse, error) {
 
 		fmt.Printf("Tenderly: Non-ETH token is being swapped! Executing request to approve %s for swapping\n", config.FromToken)
 
-		// Static calldata that approves a large ERC20 spend limit for the v5 router
-		const ApproveErc20Calldata = `0x095ea7b30000000000000000000000001111111254eeb25477b68fb85ed929f73a960582000000000000000000000000000000000000000c9f2c9cd04674edea3fffffff`
+		approvalAmount := config.ApprovalAmount
+		if approvalAmount == nil {
+			// Defaults to a large ERC20 spend limit for the v5 router
+			approvalAmount, _ = new(big.Int).SetString("c9f2c9cd04674edea3fffffff", 16)
+		}
+		approveErc20Calldata := getApproveCalldata(common.HexToAddress(contracts.AggregationRouterV5), approvalAmount)
 		const ApproveErc20GasLimitStatic = 2000000
 
 		fmt.Println("Tenderly: Simulating token approval on Tenderly")
@@ -41,7 +45,7 @@ func SimulateSwap(config SwapConfig) (*SimulationResponse, error) {
 		tokenApprovalSimulationRequest := &SimulateRequest{
 			From:               config.PublicAddress,
 			To:                 config.FromToken,
-			Input:              ApproveErc20Calldata,
+			Input:              approveErc20Calldata,
 			Gas:                ApproveErc20GasLimitStatic,
 			GasPrice:           "1806564247",
 			Value:              "0",
@@ -90,6 +94,12 @@ func SimulateSwap(config SwapConfig) (*SimulationResponse, error) {
 	return swapSimulationResponse, nil
 }
 
+// getApproveCalldata builds the calldata of an ERC20 approve(spender, amount) call
+func getApproveCalldata(spender common.Address, amount *big.Int) string {
+	selector := crypto.Keccak256([]byte("approve(address,uint256)"))[:4]
+	return fmt.Sprintf("0x%x%x%x", selector, common.LeftPadBytes(spender.Bytes(), 32), common.LeftPadBytes(amount.Bytes(), 32))
+}
+
 func ExecuteTenderlySimulationRequest(tenderlyApiKey string, forkId string, request *SimulateRequest) (*SimulationResponse, error) {
 
 	base, err := url.Parse("https://api.tenderly.co")
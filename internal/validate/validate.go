@@ -177,6 +177,28 @@ func CheckSlippage(parameter interface{}, variableName string) error {
 	return nil
 }
 
+func CheckPriceImpactRequired(parameter interface{}, variableName string) error {
+	value, ok := parameter.(float32)
+	if !ok {
+		return fmt.Errorf("for parameter '%v' to be validated as '%v', it must be a float32", variableName, "PriceImpact")
+	}
+	if value == 0 {
+		return NewParameterMissingError(variableName)
+	}
+	return CheckPriceImpact(value, variableName)
+}
+
+func CheckPriceImpact(parameter interface{}, variableName string) error {
+	value, ok := parameter.(float32)
+	if !ok {
+		return fmt.Errorf("for parameter '%v' to be validated as '%v', it must be a float32", variableName, "PriceImpact")
+	}
+	if value < 0 || value > 100 {
+		return NewParameterValidationError(variableName, fmt.Sprintf("invalid price impact value (%v) - only values 0-100 are allowed", value))
+	}
+	return nil
+}
+
 func CheckPage(parameter interface{}, variableName string) error {
 	value, ok := parameter.(float32)
 	if !ok {
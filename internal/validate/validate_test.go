@@ -323,7 +323,7 @@ func TestApprovalType(t *testing.T) {
 			approvalType: 2,
 		},
 		{
-			description:  "Invalid approval type",
+			description:  "Invalid approval type 3 (Permit2 is not supported by the router)",
 			approvalType: 3,
 			expectError:  true,
 		},
@@ -420,6 +420,49 @@ func TestSlippage(t *testing.T) {
 	}
 }
 
+func TestCheckPriceImpactRequired(t *testing.T) {
+	testcases := []struct {
+		description string
+		value       float32
+		expectError bool
+	}{
+		{
+			description: "Valid price impact value",
+			value:       1.5,
+		},
+		{
+			description: "Valid price impact value - upper boundary",
+			value:       100,
+		},
+		{
+			description: "Invalid price impact value - zero",
+			value:       0,
+			expectError: true,
+		},
+		{
+			description: "Invalid price impact value - negative",
+			value:       -1,
+			expectError: true,
+		},
+		{
+			description: "Invalid price impact value - above upper boundary",
+			value:       101,
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := CheckPriceImpactRequired(tc.value, "testValue")
+			if tc.expectError {
+				require.Error(t, err, fmt.Sprintf("%s should have caused an error", tc.description))
+			} else {
+				require.NoError(t, err, fmt.Sprintf("%s should not have caused an error", tc.description))
+			}
+		})
+	}
+}
+
 func TestCheckPage(t *testing.T) {
 	testcases := []struct {
 		description string
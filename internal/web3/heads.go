@@ -0,0 +1,262 @@
+package web3
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+const (
+	defaultHeadPollInterval  = time.Second
+	defaultReconnectDelay    = time.Second
+	defaultMaxReconnectDelay = 30 * time.Second
+
+	headSubscriptionBuffer = 16
+)
+
+// HeadWatcherConfig controls how a HeadWatcher follows the chain
+type HeadWatcherConfig struct {
+	// PollInterval between two header requests while no WebSocket subscription is active (defaults to 1 second)
+	PollInterval time.Duration
+	// ReconnectDelay before the first WebSocket reconnect attempt, doubled after every failure (defaults to 1 second)
+	ReconnectDelay time.Duration
+	// MaxReconnectDelay caps the delay between WebSocket reconnect attempts (defaults to 30 seconds)
+	MaxReconnectDelay time.Duration
+}
+
+// HeadWatcher follows the new blocks of a chain and fans their headers out to every subscriber
+// Headers come from a newHeads WebSocket subscription when one is available, otherwise the HTTP client is polled
+// The chain is only followed while there is at least one subscriber
+type HeadWatcher struct {
+	chainId    int
+	wsUrls     []string
+	httpClient *ethclient.Client
+	config     HeadWatcherConfig
+
+	mu          sync.Mutex
+	subscribers map[*HeadSubscription]struct{}
+	cancel      context.CancelFunc
+	done        chan struct{}
+	lastHash    common.Hash
+	lastError   error
+
+	subscribed atomic.Bool
+}
+
+// HeadSubscription receives the headers seen by a HeadWatcher until it is unsubscribed
+// Headers are dropped rather than blocking the watcher when the subscriber falls behind
+type HeadSubscription struct {
+	headers chan *types.Header
+	watcher *HeadWatcher
+	once    sync.Once
+}
+
+// NewHeadWatcher creates a watcher for a chain, the WebSocket URLs are tried in order and may be empty
+func NewHeadWatcher(chainId int, wsUrls []string, httpClient *ethclient.Client, config HeadWatcherConfig) *HeadWatcher {
+	if config.PollInterval == 0 {
+		config.PollInterval = defaultHeadPollInterval
+	}
+	if config.ReconnectDelay == 0 {
+		config.ReconnectDelay = defaultReconnectDelay
+	}
+	if config.MaxReconnectDelay == 0 {
+		config.MaxReconnectDelay = defaultMaxReconnectDelay
+	}
+
+	return &HeadWatcher{
+		chainId:     chainId,
+		wsUrls:      wsUrls,
+		httpClient:  httpClient,
+		config:      config,
+		subscribers: make(map[*HeadSubscription]struct{}),
+	}
+}
+
+// Subscribe returns a subscription to the new headers of the chain
+func (w *HeadWatcher) Subscribe() *HeadSubscription {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	subscription := &HeadSubscription{
+		headers: make(chan *types.Header, headSubscriptionBuffer),
+		watcher: w,
+	}
+	w.subscribers[subscription] = struct{}{}
+
+	if w.cancel == nil {
+		ctx, cancel := context.WithCancel(context.Background())
+		w.cancel = cancel
+		w.done = make(chan struct{})
+		go w.run(ctx, w.done)
+	}
+	return subscription
+}
+
+// Headers returns the channel the headers are delivered on, it is closed once the subscription ends
+func (s *HeadSubscription) Headers() <-chan *types.Header {
+	return s.headers
+}
+
+// Unsubscribe stops the delivery of headers, the watcher stops following the chain when no subscriber is left
+func (s *HeadSubscription) Unsubscribe() {
+	s.once.Do(func() {
+		w := s.watcher
+		w.mu.Lock()
+		delete(w.subscribers, s)
+		close(s.headers)
+		var done chan struct{}
+		if len(w.subscribers) == 0 && w.cancel != nil {
+			w.cancel()
+			w.cancel = nil
+			done = w.done
+		}
+		w.mu.Unlock()
+
+		if done != nil {
+			<-done
+		}
+	})
+}
+
+// Subscribed reports whether the headers currently come from a WebSocket subscription rather than polling
+func (w *HeadWatcher) Subscribed() bool {
+	return w.subscribed.Load()
+}
+
+// LastError returns the reason the last WebSocket subscription ended, if any
+func (w *HeadWatcher) LastError() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.lastError
+}
+
+// Close ends every subscription and stops following the chain
+func (w *HeadWatcher) Close() {
+	w.mu.Lock()
+	subscriptions := make([]*HeadSubscription, 0, len(w.subscribers))
+	for subscription := range w.subscribers {
+		subscriptions = append(subscriptions, subscription)
+	}
+	w.mu.Unlock()
+
+	for _, subscription := range subscriptions {
+		subscription.Unsubscribe()
+	}
+}
+
+func (w *HeadWatcher) run(ctx context.Context, done chan struct{}) {
+	defer close(done)
+
+	if len(w.wsUrls) == 0 {
+		w.poll(ctx, nil)
+		return
+	}
+
+	reconnectDelay := w.config.ReconnectDelay
+	for urlIndex := 0; ctx.Err() == nil; urlIndex = (urlIndex + 1) % len(w.wsUrls) {
+		subscribedAt := time.Now()
+		err := w.subscribe(ctx, w.wsUrls[urlIndex])
+		if ctx.Err() != nil {
+			return
+		}
+		w.mu.Lock()
+		w.lastError = err
+		w.mu.Unlock()
+
+		// A subscription that lived for a while was healthy, the next failure starts the backoff over
+		if time.Since(subscribedAt) > w.config.MaxReconnectDelay {
+			reconnectDelay = w.config.ReconnectDelay
+		}
+
+		// Keep delivering headers over HTTP until it is time to try the WebSocket again
+		w.poll(ctx, time.After(reconnectDelay))
+		reconnectDelay *= 2
+		if reconnectDelay > w.config.MaxReconnectDelay {
+			reconnectDelay = w.config.MaxReconnectDelay
+		}
+	}
+}
+
+// subscribe delivers the headers of a newHeads subscription until it fails or the context is cancelled
+func (w *HeadWatcher) subscribe(ctx context.Context, wsUrl string) error {
+	wsClient, err := ethclient.DialContext(ctx, wsUrl)
+	if err != nil {
+		return fmt.Errorf("failed to dial %s: %v", RedactUrl(wsUrl), err)
+	}
+	defer wsClient.Close()
+
+	chainId, err := wsClient.ChainID(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get chain id from %s: %v", RedactUrl(wsUrl), err)
+	}
+	if chainId.Cmp(big.NewInt(int64(w.chainId))) != 0 {
+		return &ChainIdMismatchError{Url: RedactUrl(wsUrl), Expected: w.chainId, Actual: chainId}
+	}
+
+	headers := make(chan *types.Header, headSubscriptionBuffer)
+	subscription, err := wsClient.SubscribeNewHead(ctx, headers)
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to new heads on %s: %v", RedactUrl(wsUrl), err)
+	}
+	defer subscription.Unsubscribe()
+
+	w.subscribed.Store(true)
+	defer w.subscribed.Store(false)
+
+	for {
+		select {
+		case header := <-headers:
+			w.broadcast(header)
+		case err := <-subscription.Err():
+			return fmt.Errorf("new heads subscription on %s failed: %v", RedactUrl(wsUrl), err)
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// poll delivers the latest header over HTTP every poll interval until the context is cancelled or until is triggered
+func (w *HeadWatcher) poll(ctx context.Context, until <-chan time.Time) {
+	ticker := time.NewTicker(w.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		header, err := w.httpClient.HeaderByNumber(ctx, nil)
+		if err == nil {
+			w.broadcast(header)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-until:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *HeadWatcher) broadcast(header *types.Header) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	// Polling returns the same header until a new block is produced
+	if header.Hash() == w.lastHash {
+		return
+	}
+	w.lastHash = header.Hash()
+
+	for subscription := range w.subscribers {
+		select {
+		case subscription.headers <- header:
+		default:
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package web3
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+var fastHeadWatcherConfig = HeadWatcherConfig{
+	PollInterval:      10 * time.Millisecond,
+	ReconnectDelay:    50 * time.Millisecond,
+	MaxReconnectDelay: 100 * time.Millisecond,
+}
+
+// setupHttpHeads starts a JSON-RPC server whose latest block advances on every eth_getBlockByNumber request
+func setupHttpHeads(t *testing.T) *ethclient.Client {
+	var blockNumber atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Id     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		require.Equal(t, "eth_getBlockByNumber", request.Method)
+
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      request.Id,
+			"result":  testHeader(blockNumber.Add(1)),
+		}))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := ethclient.Dial(server.URL)
+	require.NoError(t, err)
+	t.Cleanup(client.Close)
+	return client
+}
+
+func testHeader(number int64) *types.Header {
+	return &types.Header{
+		Number:     big.NewInt(number),
+		Difficulty: big.NewInt(0),
+	}
+}
+
+// wsEthService serves eth_chainId and newHeads subscriptions through a go-ethereum RPC server
+type wsEthService struct {
+	chainId uint64
+	headers chan *types.Header
+}
+
+func (s *wsEthService) ChainId() hexutil.Uint64 {
+	return hexutil.Uint64(s.chainId)
+}
+
+func (s *wsEthService) NewHeads(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, _ := rpc.NotifierFromContext(ctx)
+	subscription := notifier.CreateSubscription()
+	go func() {
+		for {
+			select {
+			case header := <-s.headers:
+				_ = notifier.Notify(subscription.ID, header)
+			case <-subscription.Err():
+				return
+			}
+		}
+	}()
+	return subscription, nil
+}
+
+func setupWsHeads(t *testing.T, service *wsEthService) (string, *rpc.Server) {
+	rpcServer := rpc.NewServer()
+	require.NoError(t, rpcServer.RegisterName("eth", service))
+	server := httptest.NewServer(rpcServer.WebsocketHandler([]string{"*"}))
+	t.Cleanup(server.Close)
+	t.Cleanup(rpcServer.Stop)
+	return "ws" + strings.TrimPrefix(server.URL, "http"), rpcServer
+}
+
+func receiveHeader(t *testing.T, subscription *HeadSubscription) *types.Header {
+	select {
+	case header, ok := <-subscription.Headers():
+		require.True(t, ok, "subscription closed")
+		return header
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "no header received")
+		return nil
+	}
+}
+
+func TestHeadWatcherPolling(t *testing.T) {
+	watcher := NewHeadWatcher(1, nil, setupHttpHeads(t), fastHeadWatcherConfig)
+	t.Cleanup(watcher.Close)
+
+	subscription := watcher.Subscribe()
+	first := receiveHeader(t, subscription)
+	second := receiveHeader(t, subscription)
+	require.Greater(t, second.Number.Int64(), first.Number.Int64())
+	require.False(t, watcher.Subscribed())
+
+	subscription.Unsubscribe()
+	_, ok := <-subscription.Headers()
+	require.False(t, ok)
+}
+
+func TestHeadWatcherWebSocket(t *testing.T) {
+	service := &wsEthService{chainId: 1, headers: make(chan *types.Header)}
+	wsUrl, rpcServer := setupWsHeads(t, service)
+
+	watcher := NewHeadWatcher(1, []string{wsUrl}, setupHttpHeads(t), fastHeadWatcherConfig)
+	t.Cleanup(watcher.Close)
+
+	subscription := watcher.Subscribe()
+	t.Cleanup(subscription.Unsubscribe)
+	require.Eventually(t, watcher.Subscribed, 5*time.Second, 10*time.Millisecond)
+
+	// Drain headers polled before the subscription was established
+	for len(subscription.Headers()) > 0 {
+		<-subscription.Headers()
+	}
+	service.headers <- testHeader(1000)
+	require.Equal(t, int64(1000), receiveHeader(t, subscription).Number.Int64())
+
+	// Headers keep coming over HTTP once the WebSocket goes away
+	rpcServer.Stop()
+	require.Eventually(t, func() bool { return !watcher.Subscribed() }, 5*time.Second, 10*time.Millisecond)
+	require.Error(t, watcher.LastError())
+	receiveHeader(t, subscription)
+}
+
+func TestHeadWatcherWebSocketOnAnotherChain(t *testing.T) {
+	service := &wsEthService{chainId: 56, headers: make(chan *types.Header)}
+	wsUrl, _ := setupWsHeads(t, service)
+
+	watcher := NewHeadWatcher(1, []string{wsUrl}, setupHttpHeads(t), fastHeadWatcherConfig)
+	t.Cleanup(watcher.Close)
+
+	subscription := watcher.Subscribe()
+	t.Cleanup(subscription.Unsubscribe)
+
+	receiveHeader(t, subscription)
+	require.Eventually(t, func() bool { return watcher.LastError() != nil }, 5*time.Second, 10*time.Millisecond)
+	require.ErrorContains(t, watcher.LastError(), "is on chain id 56 but is configured for chain id 1")
+	require.False(t, watcher.Subscribed())
+}
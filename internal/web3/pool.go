@@ -0,0 +1,401 @@
+package web3
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	defaultHealthCheckInterval = 30 * time.Second
+	defaultHealthCheckTimeout  = 5 * time.Second
+	defaultMaxBlockLag         = 5
+)
+
+// ProviderConfig describes a single RPC endpoint of a chain
+type ProviderConfig struct {
+	Url string
+	// Priority orders providers, lower values are tried first
+	Priority int
+	// Weight spreads requests between healthy providers sharing a priority (defaults to 1)
+	Weight int
+}
+
+// HealthCheckConfig controls the background checks that decide which providers receive requests
+type HealthCheckConfig struct {
+	// Interval between two checks (defaults to 30 seconds), when checks are disabled it is how long a provider that
+	// failed a request is skipped before it is tried again
+	Interval time.Duration
+	// Timeout of the requests made by a single check (defaults to 5 seconds)
+	Timeout time.Duration
+	// MaxLatency marks providers slower than this as unhealthy (disabled when zero)
+	MaxLatency time.Duration
+	// MaxBlockLag marks providers further behind the highest block seen as unhealthy (defaults to 5)
+	MaxBlockLag uint64
+	// Disabled turns off background checks, failed requests still mark a provider as unhealthy until it answers a
+	// request again
+	Disabled bool
+}
+
+// ProviderStatus is a snapshot of the health of a provider
+type ProviderStatus struct {
+	Url         string
+	Priority    int
+	Weight      int
+	Healthy     bool
+	Latency     time.Duration
+	BlockNumber uint64
+	LastError   error
+	LastChecked time.Time
+}
+
+type provider struct {
+	config    ProviderConfig
+	url       *url.URL
+	rpcClient *rpc.Client
+
+	// chainIdVerified is set once the provider reported the expected chain id, chainIdErr once it reported another one
+	chainIdVerified bool
+	chainIdErr      error
+
+	healthy     bool
+	latency     time.Duration
+	blockNumber uint64
+	lastError   error
+	lastChecked time.Time
+	failedAt    time.Time
+}
+
+// Pool spreads the JSON-RPC requests of a chain over several providers and fails over when one of them misbehaves
+// It is used as the HTTP transport of a regular eth client so callers are unaware of which provider answered
+type Pool struct {
+	chainId     int
+	providers   []*provider
+	healthCheck HealthCheckConfig
+	transport   http.RoundTripper
+	ethClient   *ethclient.Client
+
+	mu       sync.RWMutex
+	stop     chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+// NewPool creates a pool for the providers of a chain, Start must be called to run the background health checks
+func NewPool(chainId int, providerConfigs []ProviderConfig, healthCheck HealthCheckConfig) (*Pool, error) {
+	if len(providerConfigs) == 0 {
+		return nil, fmt.Errorf("no web3 providers for chain id %d", chainId)
+	}
+
+	if healthCheck.Interval == 0 {
+		healthCheck.Interval = defaultHealthCheckInterval
+	}
+	if healthCheck.Timeout == 0 {
+		healthCheck.Timeout = defaultHealthCheckTimeout
+	}
+	if healthCheck.MaxBlockLag == 0 {
+		healthCheck.MaxBlockLag = defaultMaxBlockLag
+	}
+
+	pool := &Pool{
+		chainId:     chainId,
+		healthCheck: healthCheck,
+		transport:   http.DefaultTransport,
+		stop:        make(chan struct{}),
+	}
+
+	for _, providerConfig := range providerConfigs {
+		providerUrl, err := url.Parse(providerConfig.Url)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse web3 provider URL %s: %v", RedactUrl(providerConfig.Url), err)
+		}
+		if providerConfig.Weight == 0 {
+			providerConfig.Weight = 1
+		}
+
+		rpcClient, err := rpc.Dial(providerConfig.Url)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create rpc client for %s: %v", RedactUrl(providerConfig.Url), err)
+		}
+
+		pool.providers = append(pool.providers, &provider{
+			config:    providerConfig,
+			url:       providerUrl,
+			rpcClient: rpcClient,
+			healthy:   true,
+		})
+	}
+
+	// The endpoint is never contacted, every request is rewritten to one of the providers by RoundTrip
+	rpcClient, err := rpc.DialOptions(context.Background(), fmt.Sprintf("http://chain-%d.web3-providers.invalid", chainId), rpc.WithHTTPClient(&http.Client{Transport: pool}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create rpc client: %v", err)
+	}
+	pool.ethClient = ethclient.NewClient(rpcClient)
+
+	return pool, nil
+}
+
+// EthClient returns an eth client whose requests are served by the providers of the pool
+func (p *Pool) EthClient() *ethclient.Client {
+	return p.ethClient
+}
+
+// Start runs the health checks in the background until Close is called
+// Pools with a single provider are not checked since there is nothing to fail over to
+func (p *Pool) Start() {
+	if p.healthCheck.Disabled || len(p.providers) < 2 {
+		return
+	}
+
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+
+		ticker := time.NewTicker(p.healthCheck.Interval)
+		defer ticker.Stop()
+		for {
+			p.CheckHealth(context.Background())
+			select {
+			case <-p.stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Close stops the health checks and closes the connections to every provider
+func (p *Pool) Close() {
+	p.stopOnce.Do(func() {
+		close(p.stop)
+		p.wg.Wait()
+		p.ethClient.Close()
+		for _, provider := range p.providers {
+			provider.rpcClient.Close()
+		}
+	})
+}
+
+// CheckHealth queries every provider for its chain id and latest block and updates which ones are healthy
+func (p *Pool) CheckHealth(ctx context.Context) {
+	type result struct {
+		latency     time.Duration
+		blockNumber uint64
+		err         error
+	}
+	results := make([]result, len(p.providers))
+
+	var wg sync.WaitGroup
+	for i := range p.providers {
+		wg.Add(1)
+		go func(i int, provider *provider) {
+			defer wg.Done()
+			latency, blockNumber, err := p.checkProvider(ctx, provider)
+			results[i] = result{latency: latency, blockNumber: blockNumber, err: err}
+		}(i, p.providers[i])
+	}
+	wg.Wait()
+
+	var highestBlock uint64
+	for _, result := range results {
+		if result.err == nil && result.blockNumber > highestBlock {
+			highestBlock = result.blockNumber
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	for i, provider := range p.providers {
+		err := results[i].err
+		if err == nil && highestBlock-results[i].blockNumber > p.healthCheck.MaxBlockLag {
+			err = fmt.Errorf("block %d is %d blocks behind %d", results[i].blockNumber, highestBlock-results[i].blockNumber, highestBlock)
+		}
+		if err == nil && p.healthCheck.MaxLatency > 0 && results[i].latency > p.healthCheck.MaxLatency {
+			err = fmt.Errorf("latency %v is above %v", results[i].latency, p.healthCheck.MaxLatency)
+		}
+
+		provider.healthy = err == nil
+		provider.latency = results[i].latency
+		provider.blockNumber = results[i].blockNumber
+		provider.lastError = err
+		provider.lastChecked = now
+	}
+}
+
+func (p *Pool) checkProvider(ctx context.Context, provider *provider) (time.Duration, uint64, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.healthCheck.Timeout)
+	defer cancel()
+
+	err := p.verifyChainId(ctx, provider)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	start := time.Now()
+	blockNumber, err := ethclient.NewClient(provider.rpcClient).BlockNumber(ctx)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get block number: %v", err)
+	}
+	return time.Since(start), blockNumber, nil
+}
+
+// Statuses returns the health of every provider in the order they were configured
+func (p *Pool) Statuses() []ProviderStatus {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	statuses := make([]ProviderStatus, 0, len(p.providers))
+	for _, provider := range p.providers {
+		statuses = append(statuses, ProviderStatus{
+			Url:         RedactUrl(provider.config.Url),
+			Priority:    provider.config.Priority,
+			Weight:      provider.config.Weight,
+			Healthy:     provider.healthy,
+			Latency:     provider.latency,
+			BlockNumber: provider.blockNumber,
+			LastError:   provider.lastError,
+			LastChecked: provider.lastChecked,
+		})
+	}
+	return statuses
+}
+
+// RoundTrip sends the request to the providers in order of preference until one of them answers successfully
+// JSON-RPC errors are returned with a 200 status and are not retried, only transport failures and error statuses are
+func (p *Pool) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body: %v", err)
+		}
+	}
+
+	var attempts []string
+	for _, provider := range p.orderedProviders() {
+		if err := req.Context().Err(); err != nil {
+			return nil, err
+		}
+
+		// Providers are never trusted with a request before they confirmed the chain they serve
+		err := p.verifyChainId(req.Context(), provider)
+		if err != nil {
+			p.markUnhealthy(provider, err)
+			attempts = append(attempts, fmt.Sprintf("%s (%v)", RedactUrl(provider.config.Url), err))
+			continue
+		}
+
+		providerReq := req.Clone(req.Context())
+		providerReq.URL = provider.url
+		providerReq.Host = provider.url.Host
+		providerReq.Body = io.NopCloser(bytes.NewReader(body))
+		providerReq.ContentLength = int64(len(body))
+
+		resp, err := p.transport.RoundTrip(providerReq)
+		if err == nil && (resp.StatusCode < 200 || resp.StatusCode >= 300) {
+			resp.Body.Close()
+			err = fmt.Errorf("unexpected status %s", resp.Status)
+		}
+		if err != nil {
+			if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+				return nil, err
+			}
+			p.markUnhealthy(provider, err)
+			attempts = append(attempts, fmt.Sprintf("%s (%v)", RedactUrl(provider.config.Url), err))
+			continue
+		}
+		p.markRecovered(provider)
+		return resp, nil
+	}
+
+	return nil, fmt.Errorf("all web3 providers for chain id %d failed: %s", p.chainId, strings.Join(attempts, ", "))
+}
+
+func (p *Pool) markUnhealthy(provider *provider, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	provider.healthy = false
+	provider.lastError = err
+	provider.failedAt = time.Now()
+}
+
+// markRecovered clears the unhealthy flag set by a failed request once the provider answers again
+// When background checks run they are left to decide, since a provider can answer while lagging behind
+func (p *Pool) markRecovered(provider *provider) {
+	if !p.healthCheck.Disabled {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	provider.healthy = true
+	provider.lastError = nil
+}
+
+// isAvailable reports whether a provider should be preferred, providers that failed a request are retried once the
+// check interval has passed when background checks are disabled since nothing else would bring them back
+func (p *Pool) isAvailable(provider *provider, now time.Time) bool {
+	if provider.healthy {
+		return true
+	}
+	return p.healthCheck.Disabled && now.Sub(provider.failedAt) >= p.healthCheck.Interval
+}
+
+// orderedProviders returns the healthy providers by priority followed by the unhealthy ones as a last resort
+// Healthy providers that share a priority are shuffled according to their weights
+func (p *Pool) orderedProviders() []*provider {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	now := time.Now()
+	keys := make(map[*provider]float64, len(p.providers))
+	available := make(map[*provider]bool, len(p.providers))
+	ordered := make([]*provider, 0, len(p.providers))
+	for _, provider := range p.providers {
+		ordered = append(ordered, provider)
+		available[provider] = p.isAvailable(provider, now)
+		// Weighted random sampling without replacement: a higher weight pushes the key closer to 1
+		keys[provider] = math.Pow(rand.Float64(), 1/float64(provider.config.Weight))
+	}
+
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if available[ordered[i]] != available[ordered[j]] {
+			return available[ordered[i]]
+		}
+		if ordered[i].config.Priority != ordered[j].config.Priority {
+			return ordered[i].config.Priority < ordered[j].config.Priority
+		}
+		if !available[ordered[i]] {
+			return false
+		}
+		return keys[ordered[i]] > keys[ordered[j]]
+	})
+	return ordered
+}
+
+// RedactUrl strips the path, query and credentials of a URL since providers commonly embed API keys in them
+func RedactUrl(rawUrl string) string {
+	parsedUrl, err := url.Parse(rawUrl)
+	if err != nil || parsedUrl.Host == "" {
+		return "<invalid url>"
+	}
+	return fmt.Sprintf("%s://%s", parsedUrl.Scheme, parsedUrl.Host)
+}
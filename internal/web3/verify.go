@@ -0,0 +1,104 @@
+package web3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// ChainIdMismatchError is returned when a provider serves another chain than the one it is configured for
+type ChainIdMismatchError struct {
+	Url      string
+	Expected int
+	Actual   *big.Int
+}
+
+func (e *ChainIdMismatchError) Error() string {
+	return fmt.Sprintf("web3 provider %s is on chain id %s but is configured for chain id %d", e.Url, e.Actual, e.Expected)
+}
+
+// VerifyChainId checks that every provider serves the chain of the pool
+// Providers that cannot be reached are not an error here, they are verified before their first request instead
+func (p *Pool) VerifyChainId(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, p.healthCheck.Timeout)
+	defer cancel()
+
+	errs := make([]error, len(p.providers))
+	var wg sync.WaitGroup
+	for i := range p.providers {
+		wg.Add(1)
+		go func(i int, provider *provider) {
+			defer wg.Done()
+			errs[i] = p.verifyChainId(ctx, provider)
+		}(i, p.providers[i])
+	}
+	wg.Wait()
+
+	var mismatches []error
+	for _, err := range errs {
+		var mismatchErr *ChainIdMismatchError
+		if errors.As(err, &mismatchErr) {
+			mismatches = append(mismatches, err)
+		}
+	}
+	return errors.Join(mismatches...)
+}
+
+// verifyChainId reads the chain id of a provider the first time it is needed and caches the outcome
+// A provider on another chain is marked unhealthy for good and every later call returns the same error
+func (p *Pool) verifyChainId(ctx context.Context, provider *provider) error {
+	p.mu.RLock()
+	verified, chainIdErr := provider.chainIdVerified, provider.chainIdErr
+	p.mu.RUnlock()
+	if chainIdErr != nil {
+		return chainIdErr
+	}
+	if verified {
+		return nil
+	}
+
+	chainId, err := ethclient.NewClient(provider.rpcClient).ChainID(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get chain id: %v", err)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if chainId.Cmp(big.NewInt(int64(p.chainId))) != 0 {
+		provider.chainIdErr = &ChainIdMismatchError{
+			Url:      RedactUrl(provider.config.Url),
+			Expected: p.chainId,
+			Actual:   chainId,
+		}
+		provider.healthy = false
+		provider.lastError = provider.chainIdErr
+		return provider.chainIdErr
+	}
+	provider.chainIdVerified = true
+	return nil
+}
+
+// VerifyCodeHash checks that every provider returns the expected code for a contract
+// Unlike the chain id check, every provider must answer since this guards against RPCs serving tampered state
+func (p *Pool) VerifyCodeHash(ctx context.Context, address common.Address, expectedCodeHash common.Hash) error {
+	ctx, cancel := context.WithTimeout(ctx, p.healthCheck.Timeout)
+	defer cancel()
+
+	for _, provider := range p.providers {
+		code, err := ethclient.NewClient(provider.rpcClient).CodeAt(ctx, address, nil)
+		if err != nil {
+			return fmt.Errorf("failed to get code of %s from %s: %v", address.Hex(), RedactUrl(provider.config.Url), err)
+		}
+		codeHash := crypto.Keccak256Hash(code)
+		if codeHash != expectedCodeHash {
+			return fmt.Errorf("contract %s on web3 provider %s has code hash %s instead of %s", address.Hex(), RedactUrl(provider.config.Url), codeHash.Hex(), expectedCodeHash.Hex())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package web3
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"net/http"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPoolVerifyChainId(t *testing.T) {
+	testcases := []struct {
+		description          string
+		provider             *testProvider
+		expectedErrorMessage string
+	}{
+		{
+			description: "Provider on the configured chain",
+			provider:    &testProvider{chainId: 1, status: http.StatusOK},
+		},
+		{
+			description: "Unreachable provider is verified later",
+			provider:    &testProvider{status: http.StatusServiceUnavailable},
+		},
+		{
+			description:          "Error - provider on another chain",
+			provider:             &testProvider{chainId: 56, status: http.StatusOK},
+			expectedErrorMessage: "is on chain id 56 but is configured for chain id 1",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.description, func(t *testing.T) {
+			pool, err := NewPool(1, []ProviderConfig{
+				{Url: setupProvider(t, tc.provider)},
+			}, HealthCheckConfig{})
+			require.NoError(t, err)
+			t.Cleanup(pool.Close)
+
+			err = pool.VerifyChainId(context.Background())
+			if tc.expectedErrorMessage != "" {
+				require.ErrorContains(t, err, tc.expectedErrorMessage)
+				var mismatchErr *ChainIdMismatchError
+				require.True(t, errors.As(err, &mismatchErr))
+				require.Equal(t, big.NewInt(56), mismatchErr.Actual)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestPoolSkipsProviderOnAnotherChain(t *testing.T) {
+	wrongChain := &testProvider{chainId: 56, blockNumber: 200, status: http.StatusOK}
+	rightChain := &testProvider{chainId: 1, blockNumber: 100, status: http.StatusOK}
+
+	pool, err := NewPool(1, []ProviderConfig{
+		{Url: setupProvider(t, wrongChain), Priority: 0},
+		{Url: setupProvider(t, rightChain), Priority: 1},
+	}, HealthCheckConfig{})
+	require.NoError(t, err)
+	t.Cleanup(pool.Close)
+
+	for i := 0; i < 3; i++ {
+		blockNumber, err := pool.EthClient().BlockNumber(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, uint64(100), blockNumber)
+	}
+
+	// Only the first chain id check reaches the mismatching provider, its outcome is cached afterwards
+	require.Equal(t, int32(1), wrongChain.requests.Load())
+	require.False(t, pool.Statuses()[0].Healthy)
+}
+
+func TestPoolVerifyCodeHash(t *testing.T) {
+	routerCode := []byte{0x60, 0x80, 0x60, 0x40}
+	router := common.HexToAddress("0x1111111254eeb25477b68fb85ed929f73a960582")
+
+	testcases := []struct {
+		description          string
+		provider             *testProvider
+		expectedErrorMessage string
+	}{
+		{
+			description: "Code matches",
+			provider:    &testProvider{chainId: 1, code: routerCode, status: http.StatusOK},
+		},
+		{
+			description:          "Error - code differs",
+			provider:             &testProvider{chainId: 1, code: []byte{0x00}, status: http.StatusOK},
+			expectedErrorMessage: "has code hash " + crypto.Keccak256Hash([]byte{0x00}).Hex(),
+		},
+		{
+			description:          "Error - provider unreachable",
+			provider:             &testProvider{status: http.StatusServiceUnavailable},
+			expectedErrorMessage: "failed to get code of 0x1111111254EEB25477B68fb85Ed929f73A960582",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.description, func(t *testing.T) {
+			pool, err := NewPool(1, []ProviderConfig{
+				{Url: setupProvider(t, tc.provider)},
+			}, HealthCheckConfig{})
+			require.NoError(t, err)
+			t.Cleanup(pool.Close)
+
+			err = pool.VerifyCodeHash(context.Background(), router, crypto.Keccak256Hash(routerCode))
+			if tc.expectedErrorMessage != "" {
+				require.ErrorContains(t, err, tc.expectedErrorMessage)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package wrap
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/1inch/1inch-sdk-go/client/models"
+	"github.com/1inch/1inch-sdk-go/helpers"
+)
+
+// ConfirmWrapNativeWithUser asks the user to confirm a deposit into, or a withdrawal from, the wrapped native token contract
+func ConfirmWrapNativeWithUser(summary *models.Summary) (bool, error) {
+	stdOut := helpers.StdOutPrinter{}
+	return confirmWrapNativeWithUser(summary, os.Stdin, stdOut)
+}
+
+func confirmWrapNativeWithUser(summary *models.Summary, reader io.Reader, writer helpers.Printer) (bool, error) {
+	action := string(summary.Kind)
+
+	writer.Printf("%s summary:\n", strings.ToUpper(action[:1])+action[1:])
+	writer.Printf("    %-30s %s\n", "Wallet:", summary.Wallet)
+	writer.Printf("    %-30s %s\n", "From:", summary.Selling)
+	writer.Printf("    %-30s %s\n", "To:", summary.Buying)
+	writer.Printf("\n")
+	writer.Printf("WARNING: The %s will be executed onchain next and costs gas\n", action)
+	writer.Printf("Would you like to %s these tokens onchain now? [y/N]: ", action)
+
+	inputReader := bufio.NewReader(reader)
+	input, _ := inputReader.ReadString('\n')
+	input = strings.ToLower(strings.TrimSpace(input))
+
+	switch input {
+	case "y":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
@@ -0,0 +1,61 @@
+package wrap
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/1inch/1inch-sdk-go/client/models"
+	"github.com/1inch/1inch-sdk-go/helpers"
+	"github.com/1inch/1inch-sdk-go/helpers/consts/addresses"
+)
+
+func TestConfirmWrapNativeWithUser(t *testing.T) {
+	tests := []struct {
+		name           string
+		userInput      string
+		unwrap         bool
+		expectedResult bool
+	}{
+		{
+			name:           "User inputs 'y'",
+			userInput:      "y\n",
+			expectedResult: true,
+		},
+		{
+			name:           "User inputs 'Y' to unwrap",
+			userInput:      "Y\n",
+			unwrap:         true,
+			expectedResult: true,
+		},
+		{
+			name:           "User inputs 'n'",
+			userInput:      "n\n",
+			expectedResult: false,
+		},
+		{
+			name:           "User inputs nothing",
+			userInput:      "\n",
+			expectedResult: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			reader := bytes.NewBufferString(tc.userInput)
+			writer := helpers.NoOpPrinter{}
+			native := &models.SummaryAmount{Token: &models.TokenInfo{Symbol: "ETH", Decimals: 18}, Amount: big.NewInt(1e18)}
+			wrapped := &models.SummaryAmount{Token: &models.TokenInfo{Symbol: "WETH", Decimals: 18}, Amount: big.NewInt(1e18)}
+			summary := &models.Summary{Kind: models.SummaryWrap, Wallet: addresses.Vitalik, Selling: native, Buying: wrapped}
+			if tc.unwrap {
+				summary = &models.Summary{Kind: models.SummaryUnwrap, Wallet: addresses.Vitalik, Selling: wrapped, Buying: native}
+			}
+			result, err := confirmWrapNativeWithUser(summary, reader, writer)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}